@@ -2,7 +2,7 @@
  * ChainVault V3 后端服务主程序
  * 
  * 功能概述：
- * 1. 加载配置文件（.env）
+ * 1. 加载配置（默认值 → CONFIG_FILE 配置文件 → 环境变量 / .env）并校验
  * 2. 连接 MySQL 数据库
  * 3. 启动区块链事件监听器（自动同步链上数据）
  * 4. 启动 RESTful API 服务器
@@ -14,129 +14,308 @@
  * - 数据库：缓存链上数据，提供快速查询
  * 
  * 运行方式：
- * go run cmd/api/main.go
+ * go run -tags sqlite_fts5 ./cmd/migrate up   # 首次运行或有新迁移时
+ * go run -tags sqlite_fts5 cmd/api/main.go
+ * （使用 SQLite 时必须带 sqlite_fts5 标签，资产搜索依赖 FTS5；Makefile 中的命令已带上）
+ * go run ./cmd/config print  # 查看生效的配置（敏感信息已隐藏）
  */
 
 package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 
 	"chain-vault-backend/internal/api"
+	"chain-vault-backend/internal/chain"
 	"chain-vault-backend/internal/config"
 	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/imaging"
 	"chain-vault-backend/internal/listener"
+	"chain-vault-backend/internal/logging"
+	"chain-vault-backend/internal/metrics"
+	"chain-vault-backend/internal/migrate"
+	"chain-vault-backend/internal/pagination"
+	"chain-vault-backend/internal/service"
+	"chain-vault-backend/migrations"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
-	log.Println("🚀 ChainVault V3 后端服务启动中...")
-	log.Println(strings.Repeat("=", 60))
-
 	// ==================== 1. 加载配置 ====================
-	// 从 .env 文件或环境变量加载配置
-	// 包括：合约地址、RPC地址、数据库连接等
-	cfg := config.Load()
-	log.Printf("✅ 配置加载成功")
-	log.Printf("   合约地址: %s", cfg.ContractAddress)
-	log.Printf("   RPC地址: %s", cfg.EthRPCURL)
-	log.Printf("   数据库: %s", maskPassword(cfg.DatabaseURL))
+	// 依次叠加默认值、CONFIG_FILE 指定的 YAML/TOML 文件和环境变量（含 .env 文件）
+	// 包括：合约地址、RPC地址、数据库连接等，任一项无效时列出全部问题并退出
+	cfg, err := config.Load("")
+	if err != nil {
+		fatal("invalid configuration", "error", err)
+	}
+
+	// 结构化日志：log.level 控制级别（debug 时输出 SQL），log.format 选择 text 或 json
+	if err := logging.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fatal("invalid logging configuration", "error", err)
+	}
+	redacted := cfg.Redacted()
+	slog.Info("starting ChainVault backend",
+		"contract", cfg.Chain.ContractAddress,
+		"rpc", redacted.Chain.RPCURL,
+		"database", redacted.Database.URL)
 
 	// ==================== 2. 连接数据库 ====================
 	// 连接 MySQL 数据库，用于缓存链上数据
 	// 优点：查询速度快，支持复杂查询，减少区块链调用
-	log.Println("\n🗄️  正在连接数据库...")
-	if err := database.Connect(cfg.DatabaseURL); err != nil {
-		log.Fatalf("❌ 数据库连接失败: %v", err)
+	if err := database.Connect(cfg.Database.URL); err != nil {
+		fatal("failed to connect to database", "error", err)
+	}
+
+	// 数据库连接池指标和就绪检查
+	sqlDB, err := database.GetDB().DB()
+	if err != nil {
+		fatal("failed to get database connection pool", "error", err)
+	}
+	if err := metrics.RegisterDB(sqlDB); err != nil {
+		fatal("failed to register database metrics", "error", err)
+	}
+	api.AddReadinessCheck("database", sqlDB.PingContext)
+
+	// 检查数据库迁移：有未执行的迁移时拒绝启动，避免代码与表结构不一致
+	migrator, err := migrate.New(database.GetDB(), migrations.FS, migrations.Funcs)
+	if err != nil {
+		fatal("failed to load migrations", "error", err)
+	}
+	pending, err := migrator.Pending()
+	if err != nil {
+		fatal("failed to check migrations", "error", err)
+	}
+	if len(pending) > 0 {
+		names := make([]string, len(pending))
+		for i, m := range pending {
+			names[i] = fmt.Sprintf("%03d_%s", m.Version, m.Name)
+		}
+		fatal("database has pending migrations, run: make migrate (or go run -tags sqlite_fts5 ./cmd/migrate up)", "pending", names)
+	}
+
+	// IPFS 节点：元数据读取和 ipfs 图片存储都使用该节点，需在创建图片存储前设置
+	service.SetIPFSOptions(service.IPFSOptions{
+		APIURL:  cfg.IPFS.APIURL,
+		Timeout: cfg.IPFS.Timeout.Duration,
+	})
+
+	// 图片存储：新图片写入 images.backend 指定的存储
+	if err := service.ConfigureBlobStores(cfg.Images.Backend, cfg.Images.Dir); err != nil {
+		fatal("invalid blob storage configuration", "error", err)
+	}
+	service.SetImageOptions(imaging.Options{
+		MaxBytes:  cfg.Images.MaxBytes(),
+		MaxPixels: cfg.Images.MaxPixels,
+	})
+	service.SetMaxAssetImages(cfg.Images.MaxPerAsset)
+	slog.Info("image storage configured", "backend", cfg.Images.Backend, "max_mb", cfg.Images.MaxMB)
+
+	// 价格源：为价格附加法币价值，文件价格源优先于固定价格
+	if cfg.Prices.FeedFile != "" {
+		service.SetPriceFeed(service.NewFilePriceFeed(cfg.Prices.FeedFile), cfg.Prices.Currency)
+		slog.Info("price feed configured", "file", cfg.Prices.FeedFile)
+	} else if cfg.Prices.ETHPrices != "" {
+		feed, err := service.NewStaticPriceFeed(cfg.Prices.ETHPrices)
+		if err != nil {
+			fatal("invalid price configuration", "error", err)
+		}
+		service.SetPriceFeed(feed, cfg.Prices.Currency)
+		slog.Info("price feed configured", "prices", cfg.Prices.ETHPrices)
+	} else {
+		slog.Warn("no price feed configured, responses will not include fiat values")
+	}
+
+	// 订单退货期：需与合约保持一致
+	service.SetOrderOptions(service.OrderOptions{
+		PaidRefundWindow:      cfg.Orders.PaidRefundWindow.Duration,
+		DeliveredRefundWindow: cfg.Orders.DeliveredRefundWindow.Duration,
+	})
+
+	// 资产搜索：读取元数据失败超过该次数后不再重试
+	service.SetMetadataMaxAttempts(cfg.Search.MetadataMaxAttempts)
+
+	// 列表接口的默认每页条数和上限
+	pagination.SetLimits(cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit)
+
+	// Webhook：投递失败按指数退避重试，达到次数上限后转为死信
+	service.SetWebhookOptions(service.WebhookOptions{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		Timeout:     cfg.Webhooks.Timeout.Duration,
+		// 默认拒绝内网回调地址，本地开发时可开启
+		AllowPrivateTargets: cfg.Webhooks.AllowPrivateTargets,
+	})
+
+	// 旧版本把 base64 图片存在 assets.images 中，启动时迁移到图片存储
+	moved, err := service.NewImageService().MoveLegacyImages()
+	if err != nil {
+		fatal("failed to move legacy images", "error", err)
+	}
+	if moved > 0 {
+		slog.Info("moved legacy images to blob storage", "assets", moved)
+	}
+
+	// 图片处理上线前保存的图片：去除 GPS 信息，生成缩略图和感知哈希
+	reprocessed, err := service.NewImageService().ReprocessImages()
+	if err != nil {
+		fatal("failed to reprocess legacy images", "error", err)
+	}
+	if reprocessed > 0 {
+		slog.Info("generated thumbnails and perceptual hashes for legacy images", "images", reprocessed)
+	}
+
+	// 为感知哈希建立分段索引，用于检测不同资产间的重复照片
+	indexed, err := service.NewImageService().IndexPHashes()
+	if err != nil {
+		fatal("failed to index perceptual hashes", "error", err)
+	}
+	if indexed > 0 {
+		slog.Info("indexed perceptual hashes", "hashes", indexed)
 	}
-	log.Println("✅ 数据库连接成功")
 
 	// ==================== 3. 启动事件监听器 ====================
 	// 事件监听器的作用：
 	// 1. 监听智能合约发出的事件（AssetRegistered, OrderCreated等）
 	// 2. 自动将事件数据同步到数据库
 	// 3. 扫描历史区块，确保数据完整性
-	if cfg.ContractAddress != "" {
-		log.Println("\n📡 正在启动事件监听器...")
-		log.Printf("   监听合约: %s", cfg.ContractAddress)
-		
+	if cfg.Chain.ContractAddress != "" {
 		// 创建事件监听器实例
 		eventListener, err := listener.NewEventListener(cfg)
 		if err != nil {
-			log.Fatalf("❌ 事件监听器创建失败: %v", err)
+			fatal("failed to create event listener", "error", err)
 		}
 
 		// 创建可取消的上下文（用于优雅关闭）
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
+		// 就绪检查：监听器启动失败或长时间没有进展时 /readyz 返回 503
+		api.AddReadinessCheck("listener", func(ctx context.Context) error {
+			return eventListener.Healthy()
+		})
+
 		// 在后台 goroutine 中启动事件监听
 		// 这样不会阻塞主程序，API服务器可以同时运行
 		go func() {
-			log.Println("✅ 事件监听器已启动（后台运行）")
 			if err := eventListener.Start(ctx); err != nil {
-				log.Printf("⚠️  事件监听器错误: %v", err)
+				slog.Error("event listener stopped", "error", err)
 			}
 		}()
 
+		// 资产搜索：后台读取新资产的元数据，将分类、型号、颜色、尺码写入搜索索引
+		go service.NewSearchService().RunMetadataIndexer(ctx, cfg.Search.IndexInterval.Duration)
+
+		// Webhook：后台投递发件箱中到期的记录
+		go service.NewWebhookService().RunDispatcher(ctx, cfg.Webhooks.DispatchInterval.Duration)
+
 		// 设置优雅关闭处理
 		// 当收到 Ctrl+C 或 SIGTERM 信号时，优雅地关闭服务
 		go func() {
 			sigChan := make(chan os.Signal, 1)
 			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 			<-sigChan
-			log.Println("\n🛑 收到关闭信号，正在优雅关闭...")
+			slog.Info("shutdown signal received, stopping background workers")
 			cancel()
 		}()
+
+		// 链客户端：供交易状态查询接口使用
+		chainClient, err := chain.NewClient(cfg)
+		if err != nil {
+			fatal("failed to create chain client", "error", err)
+		}
+		api.SetChainClient(chainClient)
+		api.AddReadinessCheck("rpc", func(ctx context.Context) error {
+			_, err := chainClient.GetLatestBlock(ctx)
+			return err
+		})
+
+		// 交易中继：管理员操作（授权品牌、验证资产、手续费管理）由后端签名上链
+		// 数据库不直接修改，等待事件监听器同步链上结果
+		if cfg.Relayer.PrivateKey != "" || cfg.Relayer.Keystore != "" {
+			relayer, err := chain.NewRelayer(cfg)
+			if err != nil {
+				fatal("failed to create relayer", "error", err)
+			}
+			api.SetRelayer(relayer)
+			slog.Info("relayer enabled", "address", relayer.Address().Hex())
+		} else {
+			slog.Warn("RELAYER_PRIVATE_KEY and RELAYER_KEYSTORE are not set, admin on-chain operations are disabled")
+		}
 	} else {
-		log.Println("\n⚠️  警告: CONTRACT_ADDRESS 未设置，事件监听器已禁用")
-		log.Println("   请在 .env 文件中设置 CONTRACT_ADDRESS")
+		slog.Warn("CONTRACT_ADDRESS is not set, event listener is disabled")
 	}
 
 	// ==================== 4. 启动 API 服务器 ====================
-	log.Println("\n🌐 正在启动 API 服务器...")
-	
-	// 创建 Gin 路由器（使用默认配置，包含日志和恢复中间件）
-	r := gin.Default()
+	// Gin 的调试输出（路由列表等）不是结构化日志，只在 debug 级别保留
+	if os.Getenv(gin.EnvGinMode) == "" && !strings.EqualFold(cfg.Log.Level, "debug") {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// 创建 Gin 路由器：恢复中间件，请求 ID 和访问日志
+	// 请求 ID 写入 X-Request-ID 响应头，并随 context 传到服务和 SQL 日志
+	r := gin.New()
+	r.Use(gin.Recovery(), logging.Middleware())
+
+	// 按路由统计请求耗时，由 /metrics 导出
+	r.Use(metrics.Middleware())
 
 	// ==================== CORS 跨域中间件 ====================
-	// 允许前端（localhost:5173）访问后端 API
-	// 在生产环境中应该限制为特定域名
-	r.Use(func(c *gin.Context) {
-		// 允许所有来源（开发环境）
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		// 允许携带凭证
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		// 允许的请求头
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		// 允许的请求方法
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		// 处理预检请求（OPTIONS）
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
+	// 允许 server.cors_origins 中的来源访问后端 API（默认 *，开发环境）
+	// 在生产环境中应该限制为前端域名，WebSocket 连接使用同一列表检查来源
+	api.SetAllowedOrigins(cfg.Server.CORSOrigins)
+	r.Use(api.CORS())
 
-		c.Next()
-	})
+	// ==================== 钱包签名登录 ====================
+	// 写接口需要先通过 Sign-In with Ethereum 登录，请求头携带 Authorization: Bearer <token>
+	api.SetAuthService(service.NewAuthService(cfg))
+	if len(cfg.Auth.AdminAddresses) == 0 {
+		slog.Warn("ADMIN_ADDRESSES is not set, admin endpoints will return 403")
+	}
+	requireAuth := api.RequireAuth()
+	requireAdmin := api.RequireAdmin()
 
 	// ==================== API 路由配置 ====================
 	
-	// 健康检查接口
-	// 用于监控服务是否正常运行
+	// 健康检查接口（存活检查）
+	// 只表示进程在运行，不检查依赖
 	r.GET("/health", api.HealthCheck)
+
+	// 就绪检查：GET /readyz
+	//   - 检查数据库、RPC 节点和事件监听器（未设置 CONTRACT_ADDRESS 时只检查数据库）
+	//   - 全部正常返回 200，否则返回 503；响应：{"status": "ok", "checks": {"database": {"status": "ok", "latencyMs": 1}, ...}}
+	r.GET("/readyz", api.Readiness)
+
+	// Prometheus 指标：GET /metrics
+	//   - 请求耗时、监听器落后区块数、已处理事件数、RPC 错误数、数据库连接池、IPFS 请求耗时
+	r.GET("/metrics", metrics.Handler())
+	
+	// -------------------- 登录相关 API --------------------
+	// 获取登录随机数：GET /auth/nonce
+	//   - 返回：{"nonce": "...", "expiresAt": "..."}，10 分钟内有效且只能使用一次
+	r.GET("/auth/nonce", api.GetAuthNonce)
+	
+	// 签名登录：POST /auth/verify
+	//   - 请求体：{"message": "EIP-4361 消息", "signature": "0x..."}
+	//   - 返回：{"token": "...", "data": {"address": "0x...", "expiresAt": "...", "isAdmin": false}}
+	r.POST("/auth/verify", api.VerifyAuth)
+	
+	// 当前登录信息：GET /auth/me（需要登录）
+	r.GET("/auth/me", requireAuth, api.GetAuthSession)
+	
+	// 退出登录：POST /auth/logout（需要登录）
+	r.POST("/auth/logout", requireAuth, api.Logout)
 	
 	// -------------------- 资产相关 API --------------------
-	// 资产列表：GET /assets?limit=20&offset=0&owner=0x...
-	//   - 支持分页（limit, offset）
-	//   - 支持按所有者筛选（owner）
+	// 资产列表：GET /assets?limit=20&cursor=...&owner=0x...
+	//   - 支持游标分页（limit, cursor），响应中的 next_cursor 用于请求下一页
+	//   - 支持组合筛选：owner、brand、status、listed、minPrice/maxPrice（wei）、createdFrom/createdTo、fromBlock/toBlock
+	//   - 支持排序：sort=newest（默认）/oldest/price_asc/price_desc
 	r.GET("/assets", api.ListAssets)
 	
 	// 资产详情：GET /assets/123
@@ -144,26 +323,43 @@ func main() {
 	r.GET("/assets/:id", api.GetAsset)
 	
 	// 更新资产图片：PUT /assets/:id/images
-	//   - 请求体：{"images": ["data:image/jpeg;base64,...", ...]}
-	//   - 用于在资产注册后更新图片
-	r.PUT("/assets/:id/images", api.UpdateAssetImages)
+	//   - 请求体：{"images": ["data:image/jpeg;base64,...", ...], "txHash": "0x..."}
+	//   - 用于在资产注册后更新图片，需要以资产所有者登录；会替换原有的全部图片
+	r.PUT("/assets/:id/images", requireAuth, api.UpdateAssetImages)
+	
+	// 资产图片：GET /assets/123/images/0?size=thumbnail
+	//   - 返回第 n 张图片（从 0 开始），资产 JSON 的 images / thumbnails 字段即为这些地址
+	//   - size 可选 thumbnail（长边 320px）、medium（长边 1024px），为 WebP 格式；不带 size 返回原图
+	//   - 带 ?v=<内容哈希前缀> 时可长期缓存
+	r.GET("/assets/:id/images/:n", api.GetAssetImage)
+	
+	// 资产重复照片风险：GET /assets/123/risk
+	//   - 照片的感知哈希与序列号或持有人不同的其他资产相近（汉明距离不超过 6）时列出这些资产
+	//   - level：none / low（仅与同一持有人的资产相同）/ medium（与其他持有人相同）/ high（且对方更早上传）
+	r.GET("/assets/:id/risk", api.GetAssetRisk)
+	
+	// 资产所有权历史：GET /assets/123/history
+	//   - 按链上顺序返回注册及每次转移的记录（from、to、区块时间、交易哈希）
+	r.GET("/assets/:id/history", api.GetAssetHistory)
 	
 	// 通过序列号查询：GET /assets/serial/NK-AJ1-001
 	//   - 用于扫描NFC标签后查询资产
 	r.GET("/assets/serial/:serialNumber", api.GetAssetBySerialNumber)
 	
-	// 在售资产列表：GET /assets/listed?limit=20&offset=0
+	// 在售资产列表：GET /assets/listed?limit=20&cursor=...
 	//   - 返回所有isListed=true的资产
 	r.GET("/assets/listed", api.GetListedAssets)
 	
 	// -------------------- 搜索 API --------------------
-	// 搜索资产：GET /search?q=Nike&limit=20&offset=0
-	//   - 支持按名称或序列号搜索
-	//   - 支持分页
+	// 搜索资产：GET /search?q=Nike&brand=0x...&status=verified&listed=true&minPrice=0&maxPrice=1000000000000000000&sort=price_asc
+	//   - 关键词匹配名称、序列号、品牌名称及元数据中的分类、型号、颜色、尺码（MySQL FULLTEXT / SQLite FTS5）
+	//   - 筛选：brand、category、status、listed、minPrice / maxPrice（wei），q 可为空
+	//   - 排序 sort：relevance（有关键词时默认）、newest（无关键词时默认）、price_asc、price_desc
+	//   - 返回 total 和 facets（brand / category / status / listed 各取值的结果数）
 	r.GET("/search", api.SearchAssets)
 	
 	// -------------------- 品牌相关 API --------------------
-	// 品牌列表：GET /brands?limit=20&offset=0&authorized=true
+	// 品牌列表：GET /brands?limit=20&cursor=...&authorized=true
 	//   - 支持分页
 	//   - 支持只查询已授权品牌（authorized=true）
 	r.GET("/brands", api.ListBrands)
@@ -172,13 +368,54 @@ func main() {
 	//   - 返回指定地址的品牌信息
 	r.GET("/brands/:address", api.GetBrand)
 	
+	// 品牌方风险看板：GET /brands/0x123.../risk
+	//   - 列出该品牌资产中照片与其他资产相同的资产，按风险等级从高到低排序
+	//   - 需要以该品牌地址或管理员地址登录
+	r.GET("/brands/:address/risk", requireAuth, api.GetBrandRisk)
+	
 	// 授权品牌：POST /brands/authorize
-	//   - 管理员功能
+	//   - 管理员功能，通过交易中继调用合约 authorizeBrand
 	//   - 请求体：{"address": "0x...", "authorized": true}
-	r.POST("/brands/authorize", api.AuthorizeBrand)
+	//   - 返回：202 {"txHash": "0x...", "status": "pending"}
+	r.POST("/brands/authorize", requireAuth, requireAdmin, api.AuthorizeBrand)
+	
+	// -------------------- 管理员上链操作 API --------------------
+	// 以下接口需要以管理员地址（ADMIN_ADDRESSES）登录，
+	// 通过交易中继提交交易并立即返回交易哈希，数据库在事件监听器收到对应事件后更新
+	
+	// 验证资产：POST /assets/123/verify
+	//   - 请求体：{"status": 2, "brand": "0x..."}（2 已验证，3 已拒绝；brand 可选）
+	r.POST("/assets/:id/verify", requireAuth, requireAdmin, api.VerifyAsset)
+	
+	// 设置平台手续费：PUT /admin/platform-fee
+	//   - 请求体：{"feePercent": 2}（0-10）
+	r.PUT("/admin/platform-fee", requireAuth, requireAdmin, api.SetPlatformFee)
+	
+	// 提取平台手续费：POST /admin/withdraw-fees
+	r.POST("/admin/withdraw-fees", requireAuth, requireAdmin, api.WithdrawPlatformFees)
+	
+	// 中继交易状态：GET /admin/transactions/0x...
+	//   - 返回：pending / confirmed / failed / dropped 以及区块号、gas 用量
+	r.GET("/admin/transactions/:hash", requireAuth, requireAdmin, api.GetRelayedTx)
+	
+	// -------------------- 所有权历史 API --------------------
+	// 地址所有权历史：GET /owners/0x.../history?limit=20&cursor=...
+	//   - 返回该地址转入或转出的所有记录，按时间倒序
+	r.GET("/owners/:address/history", api.GetOwnerHistory)
+	
+	// -------------------- 交易相关 API --------------------
+	// 交易状态：GET /tx/0x...
+	//   - 返回 pending / mined / confirmed / failed、区块号、确认数、解析后的事件
+	//   - records 为监听器根据该交易写入的事件记录，payloads 为依附于该交易的延迟数据
+	r.GET("/tx/:hash", api.GetTransaction)
+	
+	// 提交延迟数据：POST /tx/0x.../payloads
+	//   - 请求体：{"kind": "asset_images", "data": ["data:image/..."]}
+	//   - 交易被监听器索引后写入对应记录，返回 202
+	r.POST("/tx/:hash/payloads", requireAuth, api.SubmitTxPayload)
 	
 	// -------------------- 订单相关 API --------------------
-	// 订单列表：GET /orders?user=0x...&limit=20&offset=0
+	// 订单列表：GET /orders?user=0x...&limit=20&cursor=...
 	//   - 必须指定user（买家或卖家地址）
 	//   - 支持分页
 	r.GET("/orders", api.ListOrders)
@@ -188,7 +425,7 @@ func main() {
 	r.GET("/orders/:id", api.GetOrder)
 	
 	// 资产交易历史：GET /orders/asset/123
-	//   - 返回指定资产的所有订单记录
+	//   - 分页返回指定资产的订单记录
 	r.GET("/orders/asset/:assetId", api.GetOrdersByAsset)
 	
 	// -------------------- 用户信誉相关 API --------------------
@@ -197,29 +434,30 @@ func main() {
 	r.GET("/reputation/:address", api.GetUserReputation)
 	
 	// 创建评价：POST /reviews
-	//   - 请求体：{"orderId": 123, "reviewerAddress": "0x...", "revieweeAddress": "0x...", "role": "seller", "rating": 5, "comment": "..."}
-	r.POST("/reviews", api.CreateReview)
+	//   - 请求体：{"orderId": 123, "rating": 5, "comment": "..."}
+	//   - 需要登录，评价人为登录地址且必须是订单的买家或卖家，被评价人为订单的另一方
+	r.POST("/reviews", requireAuth, api.CreateReview)
 	
 	// 获取用户评价列表：GET /reviews/0x...?role=seller
-	//   - 返回用户收到的评价列表
+	//   - 分页返回用户收到的评价列表
 	//   - role参数可选（seller或buyer）
 	r.GET("/reviews/:address", api.GetUserReviews)
 	
 	// -------------------- IPFS 相关 API --------------------
 	// 上传单张图片：POST /ipfs/upload/image
-	//   - 表单字段：image (文件)
+	//   - 需要登录，表单字段：image (文件)
 	//   - 返回：{"hash": "QmXxx...", "uri": "ipfs://QmXxx..."}
-	r.POST("/ipfs/upload/image", api.UploadImage)
+	r.POST("/ipfs/upload/image", requireAuth, api.UploadImage)
 	
 	// 批量上传图片：POST /ipfs/upload/images
-	//   - 表单字段：images (多个文件)
+	//   - 需要登录，表单字段：images (多个文件)
 	//   - 返回：{"hashes": ["QmXxx...", ...], "uris": [...]}
-	r.POST("/ipfs/upload/images", api.UploadMultipleImages)
+	r.POST("/ipfs/upload/images", requireAuth, api.UploadMultipleImages)
 	
 	// 生成元数据：POST /ipfs/metadata
-	//   - 请求体：{name, serialNumber, imageHashes, ...}
+	//   - 需要登录，请求体：{name, serialNumber, imageHashes, ...}
 	//   - 返回：{"uri": "ipfs://QmMetadata..."}
-	r.POST("/ipfs/metadata", api.GenerateMetadata)
+	r.POST("/ipfs/metadata", requireAuth, api.GenerateMetadata)
 	
 	// 获取元数据：GET /ipfs/metadata?uri=ipfs://QmXxx...
 	//   - 返回：完整的元数据JSON对象
@@ -233,34 +471,67 @@ func main() {
 	// 统计数据：GET /stats
 	//   - 返回：总资产数、总订单数、每日统计等
 	r.GET("/stats", api.GetStats)
+	
+	// ETH 价格：GET /prices/eth?currency=USD&wei=1500000000000000000
+	//   - 返回 1 ETH 的法币价格，传入 wei 时同时返回折算的 ETH 和法币金额
+	//   - 未配置价格源时返回 503
+	r.GET("/prices/eth", api.GetETHPrice)
+
+	// -------------------- 实时推送 API --------------------
+	// 事件推送（SSE）：GET /stream?assetId=1&owner=0x...&participant=0x...&type=AssetTransferred
+	//   - 每条消息的 data 为事件 JSON：{id, type, assetId, orderId, brand, owners, participants, txHash, blockNum, data}
+	//   - assetId、owner、participant 满足任意一项即推送，type 限定事件类型；都可重复或用逗号分隔
+	//   - 断线重连时带上 Last-Event-ID 请求头（EventSource 自动处理），补发之后的事件
+	r.GET("/stream", api.StreamEvents)
+
+	// 事件推送（WebSocket）：GET /ws?assetId=1&lastEventId=123
+	//   - 订阅参数与 /stream 相同，续传使用 lastEventId 参数
+	//   - 客户端读取太慢时以 1013 关闭连接，需带上最后的事件 ID 重连
+	r.GET("/ws", api.StreamWebSocket)
+
+	// -------------------- Webhook API --------------------
+	// 以下接口需要登录，只能管理登录地址自己的 Webhook（管理员可以管理全部）
+
+	// 登记 Webhook：POST /webhooks
+	//   - 请求体：{"url": "https://...", "scope": "brand", "eventTypes": ["AssetVerified", "OrderCompleted"]}
+	//   - scope=brand 接收该品牌资产的事件，scope=owner 接收登录地址转入、转出的资产及参与的订单的事件
+	//   - 返回 201，secret 为签名密钥，只在创建时返回
+	r.POST("/webhooks", requireAuth, api.CreateWebhook)
+
+	// Webhook 列表：GET /webhooks
+	r.GET("/webhooks", requireAuth, api.ListWebhooks)
+
+	// Webhook 详情：GET /webhooks/1
+	r.GET("/webhooks/:id", requireAuth, api.GetWebhook)
+
+	// 修改 Webhook：PUT /webhooks/1
+	//   - 请求体：{"url": "...", "eventTypes": [...], "active": false}，未提供的字段保持不变
+	r.PUT("/webhooks/:id", requireAuth, api.UpdateWebhook)
+
+	// 删除 Webhook：DELETE /webhooks/1（同时删除投递记录）
+	r.DELETE("/webhooks/:id", requireAuth, api.DeleteWebhook)
+
+	// 投递记录：GET /webhooks/1/deliveries?status=dead&limit=20&cursor=...
+	//   - status 可选 pending / delivered / dead，按 ID 倒序分页
+	r.GET("/webhooks/:id/deliveries", requireAuth, api.ListWebhookDeliveries)
+
+	// 重放投递：POST /webhooks/1/deliveries/replay
+	//   - 请求体：{"deliveryIds": [1, 2]}，为空时重放全部死信
+	//   - 重试次数清零并立即重新投递，返回 202 {"replayed": 2}
+	r.POST("/webhooks/:id/deliveries/replay", requireAuth, api.ReplayWebhookDeliveries)
 
 	// ==================== 启动服务器 ====================
-	log.Println("\n✅ API 服务器配置完成")
-	log.Println("📡 监听端口: :8080")
-	log.Println("🌐 API 地址: http://localhost:8080")
-	log.Println("\n可用的 API 端点:")
-	log.Println("  - GET  /health              健康检查")
-	log.Println("  - GET  /assets              资产列表")
-	log.Println("  - GET  /search              搜索资产")
-	log.Println("  - GET  /brands              品牌列表")
-	log.Println("  - GET  /orders              订单列表")
-	log.Println("  - POST /ipfs/upload/image   上传图片")
-	log.Println("\n🎉 服务器启动成功，等待请求...")
-	log.Println(strings.Repeat("=", 60))
-	
+	slog.Info("API server listening", "addr", cfg.Server.Addr)
+
 	// 启动 HTTP 服务器（阻塞）
-	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("❌ 服务器启动失败: %v", err)
+	if err := r.Run(cfg.Server.Addr); err != nil {
+		fatal("API server stopped", "error", err)
 	}
 }
 
-// maskPassword 隐藏数据库连接字符串中的密码
-// 用于日志输出时保护敏感信息
-func maskPassword(dbURL string) string {
-	// 简单实现：只显示前20个字符
-	if len(dbURL) > 20 {
-		return dbURL[:20] + "***"
-	}
-	return dbURL
+// fatal 记录错误日志后退出
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
 
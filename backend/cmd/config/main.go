@@ -0,0 +1,74 @@
+/**
+ * 配置查看工具
+ *
+ * 用法：
+ *   go run ./cmd/config print                    输出生效的配置（YAML），敏感信息已隐藏
+ *   go run ./cmd/config -format toml print       以 TOML 格式输出
+ *   go run ./cmd/config -config app.yaml print   使用指定的配置文件（默认使用 CONFIG_FILE）
+ *
+ * 配置与 API 服务相同：默认值 → 配置文件 → 环境变量（含 .env 文件），
+ * 输出可直接作为配置文件使用（隐藏的密钥需重新填写）。
+ * 配置无效时在标准错误中列出全部问题，退出码为 1
+ */
+
+package main
+
+import (
+	"chain-vault-backend/internal/config"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	configFile := flag.String("config", "", "配置文件（YAML 或 TOML），默认使用 CONFIG_FILE 环境变量")
+	format := flag.String("format", "yaml", "输出格式：yaml 或 toml")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 || args[0] != "print" {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Read(*configFile)
+	if err != nil {
+		fail(err)
+	}
+	data, err := cfg.Redacted().Encode(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(2)
+	}
+	os.Stdout.Write(data)
+
+	if err := cfg.Validate(); err != nil {
+		fail(err)
+	}
+}
+
+// fail 逐行列出配置问题后退出
+func fail(err error) {
+	var invalid *config.ValidationError
+	if !errors.As(err, &invalid) {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "❌ 配置无效:")
+	for _, problem := range invalid.Problems {
+		fmt.Fprintln(os.Stderr, "  -", problem)
+	}
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `用法: config [-config 文件] [-format yaml|toml] print
+
+命令:
+  print   输出生效的配置（默认值、配置文件和环境变量叠加后），敏感信息已隐藏
+
+选项:`)
+	flag.PrintDefaults()
+}
@@ -1,29 +1,131 @@
+/**
+ * 数据库迁移工具
+ *
+ * 用法：
+ *   go run -tags sqlite_fts5 ./cmd/migrate up            执行所有未执行的迁移
+ *   go run -tags sqlite_fts5 ./cmd/migrate down [N]      回滚最近 N 个迁移（默认 1）
+ *   go run -tags sqlite_fts5 ./cmd/migrate status        查看迁移状态
+ *   go run -tags sqlite_fts5 ./cmd/migrate create NAME   创建新的迁移文件（mysql 与 sqlite 各一对 up/down）
+ *
+ * 使用 SQLite 时必须带 sqlite_fts5 标签：迁移 005 创建 FTS5 全文索引，缺少该模块时连接数据库即报错
+ *
+ * 数据库连接与 API 服务相同（配置文件的 database.url 或 DATABASE_URL），迁移文件内嵌在程序中，
+ * 新建迁移后重新运行本命令即可生效
+ */
+
 package main
 
 import (
 	"chain-vault-backend/internal/config"
 	"chain-vault-backend/internal/database"
-	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/migrate"
+	"chain-vault-backend/migrations"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 )
 
 func main() {
-	// 加载配置
-	cfg := config.Load()
-	
-	// 连接数据库
-	if err := database.Connect(cfg.DatabaseURL); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	dir := flag.String("dir", "migrations", "迁移文件目录（create 使用）")
+	configFile := flag.String("config", "", "配置文件（YAML 或 TOML），默认使用 CONFIG_FILE 环境变量")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	// create 只生成文件，不需要连接数据库
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatal("用法: migrate create NAME")
+		}
+		files, err := migrate.Create(*dir, args[1])
+		if err != nil {
+			log.Fatalf("❌ 创建迁移失败: %v", err)
+		}
+		for _, file := range files {
+			fmt.Println("✅ 已创建", file)
+		}
+		return
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("❌ 配置无效: %v", err)
+	}
+	if err := database.Connect(cfg.Database.URL); err != nil {
+		log.Fatalf("❌ 数据库连接失败: %v", err)
 	}
-	
-	// 执行自动迁移（会添加新字段）
-	db := database.GetDB()
-	if err := db.AutoMigrate(&model.Asset{}); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	migrator, err := migrate.New(database.GetDB(), migrations.FS, migrations.Funcs)
+	if err != nil {
+		log.Fatalf("❌ 加载迁移失败: %v", err)
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := migrator.Up()
+		for _, m := range done {
+			fmt.Printf("✅ 已执行 %03d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(done) == 0 {
+			fmt.Println("✅ 没有需要执行的迁移")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				log.Fatalf("❌ 无效的回滚数量: %s", args[1])
+			}
+		}
+		done, err := migrator.Down(steps)
+		for _, m := range done {
+			fmt.Printf("↩️  已回滚 %03d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(done) == 0 {
+			fmt.Println("✅ 没有可回滚的迁移")
+		}
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Printf("数据库方言: %s\n", migrator.Dialect())
+		for _, s := range statuses {
+			state := "未执行"
+			if s.AppliedAt != nil {
+				state = "已执行 " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Missing {
+				state += "（迁移文件不存在）"
+			}
+			fmt.Printf("  %03d_%-30s %s\n", s.Version, s.Name, state)
+		}
+
+	default:
+		usage()
+		os.Exit(2)
 	}
-	
-	fmt.Println("✅ 数据库迁移完成！")
-	fmt.Println("✅ Images 字段已添加到 assets 表")
 }
 
+func usage() {
+	fmt.Fprintln(os.Stderr, `用法: migrate [-dir migrations] [-config 文件] <命令>
+
+命令:
+  up            执行所有未执行的迁移
+  down [N]      回滚最近 N 个迁移（默认 1）
+  status        查看迁移状态
+  create NAME   在 mysql/ 和 sqlite/ 目录下创建新的迁移文件`)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	"chain-vault-backend/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// parseAddress 解析请求中名为 name 的地址字段并转为校验和格式，格式错误时返回 400
+// 路径参数、查询参数和请求体中的地址都经过这里，错误信息格式保持一致
+func parseAddress(c *gin.Context, name, value string) (model.Address, bool) {
+	address, err := model.ParseAddress(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": name + ": " + err.Error(),
+		})
+		return "", false
+	}
+	return address, true
+}
+
+// paramAddress 解析路径参数中的地址
+func paramAddress(c *gin.Context, name string) (model.Address, bool) {
+	return parseAddress(c, name, c.Param(name))
+}
+
+// queryAddress 解析可选的地址查询参数，未提供时返回空地址
+func queryAddress(c *gin.Context, name string) (model.Address, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return "", true
+	}
+	return parseAddress(c, name, value)
+}
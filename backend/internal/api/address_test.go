@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthorizeBrandRejectsInvalidAddress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/brands/authorize", AuthorizeBrand)
+
+	tests := []struct {
+		body      string
+		wantError string
+	}{
+		{`{"address": "0x1234", "authorized": true}`, "address: "},
+		{`{"address": "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "authorized": true}`, "address: "},
+		{`{"address": "0xZZZeb6053f3e94c9b9a09f33669435e7ef1beaed", "authorized": true}`, "address: "},
+		{`{"authorized": true}`, "Invalid request"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/brands/authorize", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		var resp struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		if w.Code != http.StatusBadRequest || !strings.HasPrefix(resp.Error, tt.wantError) {
+			t.Errorf("body %s: got %d %q, want 400 with error prefix %q", tt.body, w.Code, resp.Error, tt.wantError)
+		}
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{" 0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED ", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"", "", false},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", "", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		got, ok := parseAddress(c, "brand", tt.value)
+		if ok != tt.ok || string(got) != tt.want {
+			t.Errorf("parseAddress(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+		if !ok && w.Code != http.StatusBadRequest {
+			t.Errorf("parseAddress(%q) status = %d, want 400", tt.value, w.Code)
+		}
+	}
+}
+
+// 路径参数、查询参数和请求体中的无效地址返回相同的错误信息
+func TestAddressErrorsMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	respond := func(c *gin.Context, ok bool) {
+		if ok {
+			c.Status(http.StatusNoContent)
+		}
+	}
+	r.GET("/param/:address", func(c *gin.Context) {
+		_, ok := paramAddress(c, "address")
+		respond(c, ok)
+	})
+	r.GET("/query", func(c *gin.Context) {
+		_, ok := queryAddress(c, "address")
+		respond(c, ok)
+	})
+	r.POST("/body", func(c *gin.Context) {
+		var req struct {
+			Address string `json:"address"`
+		}
+		c.ShouldBindJSON(&req)
+		_, ok := parseAddress(c, "address", req.Address)
+		respond(c, ok)
+	})
+
+	const invalid = "0x1234"
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/param/"+invalid, nil),
+		httptest.NewRequest(http.MethodGet, "/query?address="+invalid, nil),
+		httptest.NewRequest(http.MethodPost, "/body", strings.NewReader(`{"address": "`+invalid+`"}`)),
+	}
+	var bodies []string
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: status = %d, want 400", req.Method, req.URL, w.Code)
+		}
+		bodies = append(bodies, w.Body.String())
+	}
+	if bodies[0] != bodies[1] || bodies[1] != bodies[2] {
+		t.Errorf("error responses differ: %q", bodies)
+	}
+
+	// 未提供的查询参数不是错误
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/query", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("missing query address: status = %d, want 204", w.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"chain-vault-backend/internal/chain"
+	"chain-vault-backend/internal/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// relayer 交易中继，未配置签名密钥时为 nil
+var relayer *chain.Relayer
+
+// SetRelayer 设置交易中继，由 main 在启动时调用
+func SetRelayer(r *chain.Relayer) {
+	relayer = r
+}
+
+// requireRelayer 检查中继是否可用，不可用时直接返回 503
+func requireRelayer(c *gin.Context) bool {
+	if relayer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Transaction relayer is not configured",
+		})
+		return false
+	}
+	return true
+}
+
+// respondRelayed 返回已提交的交易，链上状态由事件监听器同步到数据库
+func respondRelayed(c *gin.Context, tx *chain.RelayedTx, err error) {
+	if err != nil {
+		if errors.Is(err, chain.ErrEstimateGas) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Transaction would fail: " + err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "Failed to submit transaction: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"txHash": tx.Hash,
+		"status": tx.Status,
+		"data":   tx,
+	})
+}
+
+// VerifyAsset 通过中继提交资产验证结果（管理员功能）
+func VerifyAsset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid asset ID",
+		})
+		return
+	}
+
+	var req struct {
+		Status int    `json:"status" binding:"required"` // 2 已验证，3 已拒绝
+		Brand  string `json:"brand"`                     // 可选，验证通过时改写资产的品牌
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request",
+		})
+		return
+	}
+	if req.Status != int(model.Verified) && req.Status != int(model.Rejected) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Status must be 2 (verified) or 3 (rejected)",
+		})
+		return
+	}
+	var brand model.Address
+	if req.Brand != "" {
+		var ok bool
+		if brand, ok = parseAddress(c, "brand", req.Brand); !ok {
+			return
+		}
+	}
+
+	if !requireRelayer(c) {
+		return
+	}
+	tx, err := relayer.VerifyAsset(c.Request.Context(), id, uint8(req.Status), brand.Common())
+	respondRelayed(c, tx, err)
+}
+
+// SetPlatformFee 通过中继修改平台手续费（管理员功能）
+func SetPlatformFee(c *gin.Context) {
+	var req struct {
+		FeePercent *uint64 `json:"feePercent" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request",
+		})
+		return
+	}
+	if *req.FeePercent > 10 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Fee percent must be between 0 and 10",
+		})
+		return
+	}
+
+	if !requireRelayer(c) {
+		return
+	}
+	tx, err := relayer.SetPlatformFee(c.Request.Context(), *req.FeePercent)
+	respondRelayed(c, tx, err)
+}
+
+// WithdrawPlatformFees 通过中继提取合约中的平台手续费（管理员功能）
+func WithdrawPlatformFees(c *gin.Context) {
+	if !requireRelayer(c) {
+		return
+	}
+	tx, err := relayer.WithdrawPlatformFees(c.Request.Context())
+	respondRelayed(c, tx, err)
+}
+
+// GetRelayedTx 查询中继提交的交易状态
+func GetRelayedTx(c *gin.Context) {
+	hash := c.Param("hash")
+	if len(common.FromHex(hash)) != common.HashLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction hash",
+		})
+		return
+	}
+
+	if !requireRelayer(c) {
+		return
+	}
+	tx, ok := relayer.GetTx(common.HexToHash(hash))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Transaction not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tx,
+	})
+}
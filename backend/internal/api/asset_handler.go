@@ -1,12 +1,18 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 
+	"chain-vault-backend/internal/imaging"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/service"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,61 +28,57 @@ func getAssetService() *service.AssetService {
 	return assetService
 }
 
-func ListAssets(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-	owner := c.Query("owner")
+var (
+	imageService     *service.ImageService
+	imageServiceOnce sync.Once
+)
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
+func getImageService() *service.ImageService {
+	imageServiceOnce.Do(func() {
+		imageService = service.NewImageService()
+	})
+	return imageService
+}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		offset = 0
+// ListAssets 获取资产列表，支持按所有者、品牌、验证状态、上架状态、价格、创建时间和区块范围组合筛选
+func ListAssets(c *gin.Context) {
+	page, ok := parsePage(c)
+	if !ok {
+		return
 	}
 
-	var assets []interface{}
-	var total int64
+	params := service.AssetListParams{
+		Owner:       c.Query("owner"),
+		Brand:       c.Query("brand"),
+		Status:      c.Query("status"),
+		Listed:      c.Query("listed"),
+		MinPrice:    c.Query("minPrice"),
+		MaxPrice:    c.Query("maxPrice"),
+		CreatedFrom: c.Query("createdFrom"),
+		CreatedTo:   c.Query("createdTo"),
+		FromBlock:   c.Query("fromBlock"),
+		ToBlock:     c.Query("toBlock"),
+		Sort:        c.Query("sort"),
+	}
 
-	if owner != "" {
-		// 查询特定所有者的资产
-		assetList, err := getAssetService().GetAssetsByOwner(owner, limit, offset)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to fetch assets",
-			})
-			return
-		}
-		for _, asset := range assetList {
-			assets = append(assets, asset)
-		}
-		total, _ = getAssetService().GetTotalCount()
-	} else {
-		// 查询所有资产
-		assetList, err := getAssetService().ListAssets(limit, offset)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to fetch assets",
-			})
-			return
-		}
-		for _, asset := range assetList {
-			assets = append(assets, asset)
-		}
-		total, _ = getAssetService().GetTotalCount()
+	result, err := getAssetService().WithContext(c.Request.Context()).ListAssets(params, page)
+	if errors.Is(err, service.ErrInvalidFilter) || errors.Is(err, pagination.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch assets",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": assets,
-		"total": total,
-		"limit": limit,
-		"offset": offset,
-	})
+	if !fillFiat(c, result.Items) {
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
 
 func GetAsset(c *gin.Context) {
@@ -89,7 +91,7 @@ func GetAsset(c *gin.Context) {
 		return
 	}
 
-	asset, err := getAssetService().GetAsset(id)
+	asset, err := getAssetService().WithContext(c.Request.Context()).GetAsset(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch asset",
@@ -104,13 +106,16 @@ func GetAsset(c *gin.Context) {
 		return
 	}
 
+	if !fillFiat(c, asset) {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data": asset,
 	})
 }
 
 func GetStats(c *gin.Context) {
-	total, err := getAssetService().GetTotalCount()
+	total, err := getAssetService().WithContext(c.Request.Context()).GetTotalCount()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch stats",
@@ -119,10 +124,10 @@ func GetStats(c *gin.Context) {
 	}
 
 	// 获取前10个所有者
-	topOwners, _ := getAssetService().GetTopOwners(10)
+	topOwners, _ := getAssetService().WithContext(c.Request.Context()).GetTopOwners(10)
 	
 	// 获取最近7天的统计
-	dailyStats, _ := getAssetService().GetDailyStats(7)
+	dailyStats, _ := getAssetService().WithContext(c.Request.Context()).GetDailyStats(7)
 
 	c.JSON(http.StatusOK, gin.H{
 		"totalAssets": total,
@@ -138,7 +143,8 @@ func HealthCheck(c *gin.Context) {
 	})
 }
 
-// UpdateAssetImages 更新资产的图片
+// UpdateAssetImages 更新资产的图片，只有资产所有者可以修改
+// 资产尚未被监听器索引时，如果请求带有注册交易的 txHash，图片会暂存并在索引后写入（返回 202）
 func UpdateAssetImages(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
@@ -151,8 +157,10 @@ func UpdateAssetImages(c *gin.Context) {
 
 	var req struct {
 		Images []string `json:"images" binding:"required"`
+		TxHash string   `json:"txHash"` // 可选，注册该资产的交易哈希
 	}
 
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxImagesRequestBytes())
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid request: " + err.Error(),
@@ -168,7 +176,64 @@ func UpdateAssetImages(c *gin.Context) {
 		}
 	}
 
-	err = getAssetService().UpdateAssetImages(id, base64Images)
+	asset, err := getAssetService().WithContext(c.Request.Context()).GetAsset(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch asset",
+		})
+		return
+	}
+
+	if asset == nil {
+		if req.TxHash == "" || len(common.FromHex(req.TxHash)) != common.HashLength {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Asset not found; include the registration txHash to attach images once it is indexed",
+			})
+			return
+		}
+
+		data, _ := json.Marshal(base64Images)
+		payload, err := getPayloadService().WithContext(c.Request.Context()).SubmitPayload(common.HexToHash(req.TxHash).Hex(), model.PayloadAssetImages, authAddress(c), data)
+		if errors.Is(err, service.ErrInvalidPayload) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to save images",
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Images will be saved once the transaction is indexed",
+			"data":    payload,
+		})
+		return
+	}
+
+	if asset.Owner != authAddress(c) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the asset owner can update images",
+		})
+		return
+	}
+
+	images, err := getImageService().WithContext(c.Request.Context()).ReplaceImages(id, base64Images)
+	if errors.Is(err, imaging.ErrTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidImage) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update images: " + err.Error(),
@@ -176,8 +241,101 @@ func UpdateAssetImages(c *gin.Context) {
 		return
 	}
 
+	urls := make([]string, 0, len(images))
+	for i := range images {
+		urls = append(urls, images[i].URL())
+	}
+
+	// 图片已保存，风险检查失败不影响本次更新
+	risk, _ := getAssetService().WithContext(c.Request.Context()).FlagReusedPhotos(id)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Images updated successfully",
+		"data":    urls,
+		"risk":    risk,
+	})
+}
+
+// GetAssetRisk 获取资产的重复照片风险：照片是否与序列号或持有人不同的其他资产相同
+func GetAssetRisk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid asset ID",
+		})
+		return
+	}
+
+	risk, err := getAssetService().WithContext(c.Request.Context()).GetAssetRisk(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check asset risk",
+		})
+		return
+	}
+	if risk == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Asset not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": risk,
 	})
 }
 
+// GetAssetImage 返回资产的第 n 张图片（从 0 开始）
+// 带有与内容匹配的 v 参数时允许长期缓存，否则通过 ETag 协商缓存
+func GetAssetImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid asset ID",
+		})
+		return
+	}
+	position, err := strconv.Atoi(c.Param("n"))
+	if err != nil || position < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid image index",
+		})
+		return
+	}
+
+	variant := c.Query("size")
+	if variant != "" && variant != model.VariantThumbnail && variant != model.VariantMedium {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "size must be thumbnail or medium",
+		})
+		return
+	}
+
+	content, err := getImageService().WithContext(c.Request.Context()).GetImage(id, position, variant)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch image",
+		})
+		return
+	}
+	if content == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Image not found",
+		})
+		return
+	}
+
+	// v 参数是原图哈希的前缀，缩放版本由原图决定，同样可以长期缓存
+	etag := `"` + content.Hash + `"`
+	if version := c.Query("v"); version != "" && strings.HasPrefix(content.SourceHash, version) {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "no-cache")
+	}
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, content.MimeType, content.Data)
+}
+
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"chain-vault-backend/internal/auth"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// authAddressKey 中间件写入 gin.Context 的已登录地址
+const authAddressKey = "authAddress"
+
+// authService 钱包签名登录服务，由 main 在启动时设置
+var authService *service.AuthService
+
+// SetAuthService 设置登录服务，由 main 在启动时调用
+func SetAuthService(s *service.AuthService) {
+	authService = s
+}
+
+// bearerToken 读取 Authorization: Bearer <token>
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+// RequireAuth 要求请求携带有效的会话 token，并将登录地址绑定到请求上
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authService == nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Authentication is not configured",
+			})
+			return
+		}
+
+		session, err := authService.WithContext(c.Request.Context()).Authenticate(bearerToken(c))
+		if errors.Is(err, service.ErrSessionInvalid) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Sign in with your wallet first",
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to verify session",
+			})
+			return
+		}
+
+		c.Set(authAddressKey, session.Address)
+		c.Next()
+	}
+}
+
+// RequireAdmin 要求登录地址是配置的管理员，需放在 RequireAuth 之后
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authService.IsAdmin(authAddress(c)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "Admin privileges required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// authAddress 返回 RequireAuth 绑定的登录地址（EIP-55 格式）
+func authAddress(c *gin.Context) model.Address {
+	address, _ := c.Get(authAddressKey)
+	addr, _ := address.(model.Address)
+	return addr
+}
+
+// GetAuthNonce 生成登录随机数
+func GetAuthNonce(c *gin.Context) {
+	if authService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Authentication is not configured",
+		})
+		return
+	}
+
+	nonce, err := authService.WithContext(c.Request.Context()).IssueNonce()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate nonce",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": nonce,
+	})
+}
+
+// VerifyAuth 校验钱包签名的 SIWE 消息并签发会话 token
+func VerifyAuth(c *gin.Context) {
+	var req struct {
+		Message   string `json:"message" binding:"required"`
+		Signature string `json:"signature" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if authService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Authentication is not configured",
+		})
+		return
+	}
+
+	session, token, err := authService.WithContext(c.Request.Context()).Login(req.Message, req.Signature)
+	switch {
+	case errors.Is(err, auth.ErrInvalidMessage):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, auth.ErrInvalidSignature), errors.Is(err, service.ErrNonceInvalid):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token": token,
+		"data": gin.H{
+			"address":   session.Address,
+			"chainId":   session.ChainID,
+			"expiresAt": session.ExpiresAt,
+			"isAdmin":   authService.IsAdmin(session.Address),
+		},
+	})
+}
+
+// GetAuthSession 返回当前登录的地址
+func GetAuthSession(c *gin.Context) {
+	address := authAddress(c)
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"address": address,
+			"isAdmin": authService.IsAdmin(address),
+		},
+	})
+}
+
+// Logout 注销当前会话
+func Logout(c *gin.Context) {
+	if err := authService.WithContext(c.Request.Context()).Logout(bearerToken(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to sign out",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Signed out",
+	})
+}
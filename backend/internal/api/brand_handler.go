@@ -2,9 +2,10 @@ package api
 
 import (
 	"net/http"
-	"strconv"
 	"sync"
 
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -23,27 +24,19 @@ func getBrandService() *service.BrandService {
 
 // ListBrands 获取品牌列表
 func ListBrands(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-	authorizedOnly := c.Query("authorized") == "true"
-
-	limit, _ := strconv.Atoi(limitStr)
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-
-	offset, _ := strconv.Atoi(offsetStr)
-	if offset < 0 {
-		offset = 0
+	page, ok := parsePage(c)
+	if !ok {
+		return
 	}
+	authorizedOnly := c.Query("authorized") == "true"
 
-	var brands interface{}
+	var result *pagination.Page[model.Brand]
 	var err error
 
 	if authorizedOnly {
-		brands, err = getBrandService().ListAuthorizedBrands()
+		result, err = getBrandService().WithContext(c.Request.Context()).ListAuthorizedBrands(page)
 	} else {
-		brands, err = getBrandService().ListBrands(limit, offset)
+		result, err = getBrandService().WithContext(c.Request.Context()).ListBrands(page)
 	}
 
 	if err != nil {
@@ -53,21 +46,17 @@ func ListBrands(c *gin.Context) {
 		return
 	}
 
-	total, _ := getBrandService().GetTotalCount()
-
-	c.JSON(http.StatusOK, gin.H{
-		"data":   brands,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
 
 // GetBrand 获取品牌详情
 func GetBrand(c *gin.Context) {
-	address := c.Param("address")
+	address, ok := paramAddress(c, "address")
+	if !ok {
+		return
+	}
 
-	brand, err := getBrandService().GetBrand(address)
+	brand, err := getBrandService().WithContext(c.Request.Context()).GetBrand(address)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch brand",
@@ -88,6 +77,7 @@ func GetBrand(c *gin.Context) {
 }
 
 // AuthorizeBrand 授权品牌（管理员功能）
+// 通过中继提交 authorizeBrand 交易，数据库在监听到 BrandAuthorized 事件后更新
 func AuthorizeBrand(c *gin.Context) {
 	var req struct {
 		Address    string `json:"address" binding:"required"`
@@ -101,19 +91,44 @@ func AuthorizeBrand(c *gin.Context) {
 		return
 	}
 
-	// TODO: 验证管理员权限
+	address, ok := parseAddress(c, "address", req.Address)
+	if !ok {
+		return
+	}
+
+	if !requireRelayer(c) {
+		return
+	}
+	tx, err := relayer.AuthorizeBrand(c.Request.Context(), address.Common(), req.Authorized)
+	respondRelayed(c, tx, err)
+}
+
+// GetBrandRisk 品牌方看板：列出品牌资产中照片与其他资产相同的资产，按风险等级从高到低排序
+// 仅品牌方本人或管理员可以查看
+func GetBrandRisk(c *gin.Context) {
+	address, ok := paramAddress(c, "address")
+	if !ok {
+		return
+	}
 
-	err := getBrandService().UpdateAuthorization(req.Address, req.Authorized)
+	caller := authAddress(c)
+	if caller != address && !authService.IsAdmin(caller) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the brand or an admin can view its risk dashboard",
+		})
+		return
+	}
+
+	risks, err := getAssetService().WithContext(c.Request.Context()).GetBrandRisk(address)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to update authorization",
+			"error": "Failed to check brand risk",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Authorization updated successfully",
+		"data":  risks,
+		"total": len(risks),
 	})
 }
-
-
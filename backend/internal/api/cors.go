@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// allowedOrigins 允许跨域访问的来源，包含 * 时允许全部，由 main 在启动时设置
+var allowedOrigins = []string{"*"}
+
+// SetAllowedOrigins 设置允许跨域访问的来源，同时用于 WebSocket 的来源检查，由 main 在启动时调用
+func SetAllowedOrigins(origins []string) {
+	allowedOrigins = origins
+}
+
+// originAllowed 判断请求来源是否允许；不带 Origin 的请求不是跨域请求，总是允许
+func originAllowed(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS 跨域中间件：允许全部来源时返回 *，否则回显允许的 Origin 并设置 Vary
+// 不允许的来源不返回 CORS 头，由浏览器拦截
+func CORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		origin := c.GetHeader("Origin")
+		switch {
+		case len(allowedOrigins) == 1 && allowedOrigins[0] == "*":
+			header.Set("Access-Control-Allow-Origin", "*")
+		case origin != "" && originAllowed(origin):
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Allow-Credentials", "true")
+			header.Add("Vary", "Origin")
+		default:
+			header.Add("Vary", "Origin")
+		}
+		header.Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Last-Event-ID, X-Request-ID")
+		header.Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		header.Set("Access-Control-Expose-Headers", "X-Request-ID")
+
+		// 处理预检请求（OPTIONS）
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout 单项就绪检查的超时时间
+const readinessTimeout = 3 * time.Second
+
+// ReadinessCheck 一项就绪检查，返回 nil 表示正常
+type ReadinessCheck func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check ReadinessCheck
+}
+
+var (
+	readinessMu     sync.RWMutex
+	readinessChecks []namedCheck
+)
+
+// AddReadinessCheck 注册就绪检查，GET /readyz 按注册顺序返回各项结果
+func AddReadinessCheck(name string, check ReadinessCheck) {
+	readinessMu.Lock()
+	defer readinessMu.Unlock()
+	readinessChecks = append(readinessChecks, namedCheck{name: name, check: check})
+}
+
+// checkResult 一项检查的结果
+type checkResult struct {
+	Status    string `json:"status"` // ok 或 fail
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// Readiness 并行执行所有就绪检查，全部正常时返回 200，否则返回 503
+func Readiness(c *gin.Context) {
+	readinessMu.RLock()
+	checks := append([]namedCheck(nil), readinessChecks...)
+	readinessMu.RUnlock()
+
+	results := make(map[string]checkResult, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(ctx)
+			result := checkResult{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = "fail"
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			results[nc.name] = result
+			mu.Unlock()
+		}(nc)
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	for _, result := range results {
+		if result.Status != "ok" {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(code, gin.H{
+		"status": status,
+		"checks": results,
+	})
+}
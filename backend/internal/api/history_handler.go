@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"chain-vault-backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	historyService     *service.HistoryService
+	historyServiceOnce sync.Once
+)
+
+func getHistoryService() *service.HistoryService {
+	historyServiceOnce.Do(func() {
+		historyService = service.NewHistoryService()
+	})
+	return historyService
+}
+
+// GetAssetHistory 获取资产的完整所有权链（从注册到当前持有人）
+func GetAssetHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid asset ID",
+		})
+		return
+	}
+
+	asset, err := getAssetService().WithContext(c.Request.Context()).GetAsset(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch asset",
+		})
+		return
+	}
+	if asset == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Asset not found",
+		})
+		return
+	}
+
+	histories, err := getHistoryService().WithContext(c.Request.Context()).GetHistoryByAsset(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch asset history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  histories,
+		"total": len(histories),
+	})
+}
+
+// GetOwnerHistory 获取地址转入和转出的所有权记录
+func GetOwnerHistory(c *gin.Context) {
+	address, ok := paramAddress(c, "address")
+	if !ok {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	result, err := getHistoryService().WithContext(c.Request.Context()).GetHistoryByOwner(address, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch owner history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(result, page))
+}
@@ -3,11 +3,13 @@ package api
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
 
+	"chain-vault-backend/internal/imaging"
 	"chain-vault-backend/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -24,10 +26,10 @@ func getIPFSService() *service.IPFSService {
 	return ipfsService
 }
 
-// UploadImage 上传图片并转为 base64
+// UploadImage 校验上传的图片、去除 GPS 信息并转为 base64
 // 注意：当前实现为 base64 存储，保留 IPFS 相关代码以便日后切换
 func UploadImage(c *gin.Context) {
-	file, _, err := c.Request.FormFile("image")
+	file, header, err := c.Request.FormFile("image")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to get image file",
@@ -36,22 +38,12 @@ func UploadImage(c *gin.Context) {
 	}
 	defer file.Close()
 
-	// 读取文件内容
-	fileData, err := io.ReadAll(file)
+	dataURI, err := sanitizeUpload(file, header.Size)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to read file",
-		})
+		respondImageError(c, header.Filename, err)
 		return
 	}
 
-	// 转为 base64
-	base64Str := base64.StdEncoding.EncodeToString(fileData)
-	
-	// 根据文件类型添加 data URI 前缀
-	mimeType := http.DetectContentType(fileData)
-	dataURI := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Str)
-
 	c.JSON(http.StatusOK, gin.H{
 		"base64": dataURI,
 		"hash":   "", // 保留字段，兼容前端
@@ -60,7 +52,8 @@ func UploadImage(c *gin.Context) {
 	})
 }
 
-// UploadMultipleImages 批量上传图片并转为 base64
+// UploadMultipleImages 批量校验上传的图片、去除 GPS 信息并转为 base64
+// 任意一张图片不合法时整个请求失败
 // 注意：当前实现为 base64 存储，保留 IPFS 相关代码以便日后切换
 func UploadMultipleImages(c *gin.Context) {
 	form, err := c.MultipartForm()
@@ -84,19 +77,18 @@ func UploadMultipleImages(c *gin.Context) {
 	for _, fileHeader := range files {
 		file, err := fileHeader.Open()
 		if err != nil {
-			continue
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Failed to read " + fileHeader.Filename,
+			})
+			return
 		}
 
-		fileData, err := io.ReadAll(file)
+		dataURI, err := sanitizeUpload(file, fileHeader.Size)
 		file.Close()
 		if err != nil {
-			continue
+			respondImageError(c, fileHeader.Filename, err)
+			return
 		}
-
-		// 转为 base64
-		base64Str := base64.StdEncoding.EncodeToString(fileData)
-		mimeType := http.DetectContentType(fileData)
-		dataURI := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Str)
 		base64Images = append(base64Images, dataURI)
 	}
 
@@ -108,6 +100,43 @@ func UploadMultipleImages(c *gin.Context) {
 	})
 }
 
+// sanitizeUpload 读取上传的文件，校验并去除 GPS 信息后转为 data URL
+func sanitizeUpload(file io.Reader, size int64) (string, error) {
+	limit := int64(service.ImageOptions().MaxBytes)
+	if size > limit {
+		return "", fmt.Errorf("%w: %w: %d bytes exceeds the limit of %d", service.ErrInvalidImage, imaging.ErrTooLarge, size, limit)
+	}
+	// 多读一个字节，用于发现与声明大小不符的超大文件
+	fileData, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return "", err
+	}
+
+	cleaned, mimeType, err := getImageService().SanitizeImage(fileData)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(cleaned)), nil
+}
+
+// respondImageError 按图片处理错误返回 413、400 或 500
+func respondImageError(c *gin.Context, filename string, err error) {
+	switch {
+	case errors.Is(err, imaging.ErrTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": filename + ": " + err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidImage):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": filename + ": " + err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read " + filename,
+		})
+	}
+}
+
 // GenerateMetadata 生成元数据（不上传到IPFS，直接返回JSON）
 // 注意：当前实现为本地存储，保留 IPFS 相关代码以便日后切换
 func GenerateMetadata(c *gin.Context) {
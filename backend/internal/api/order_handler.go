@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"sync"
 
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -23,31 +25,32 @@ func getOrderService() *service.OrderService {
 
 // ListOrders 获取订单列表
 func ListOrders(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-	user := c.Query("user")
-	buyer := c.Query("buyer")
-	seller := c.Query("seller")
-
-	limit, _ := strconv.Atoi(limitStr)
-	if limit <= 0 || limit > 100 {
-		limit = 20
+	page, ok := parsePage(c)
+	if !ok {
+		return
 	}
-
-	offset, _ := strconv.Atoi(offsetStr)
-	if offset < 0 {
-		offset = 0
+	user, ok := queryAddress(c, "user")
+	if !ok {
+		return
+	}
+	buyer, ok := queryAddress(c, "buyer")
+	if !ok {
+		return
+	}
+	seller, ok := queryAddress(c, "seller")
+	if !ok {
+		return
 	}
 
-	var orders interface{}
+	var result *pagination.Page[model.Order]
 	var err error
 
 	if user != "" {
-		orders, err = getOrderService().GetOrdersByUser(user, limit, offset)
+		result, err = getOrderService().WithContext(c.Request.Context()).GetOrdersByUser(user, page)
 	} else if buyer != "" {
-		orders, err = getOrderService().GetOrdersByBuyer(buyer, limit, offset)
+		result, err = getOrderService().WithContext(c.Request.Context()).GetOrdersByBuyer(buyer, page)
 	} else if seller != "" {
-		orders, err = getOrderService().GetOrdersBySeller(seller, limit, offset)
+		result, err = getOrderService().WithContext(c.Request.Context()).GetOrdersBySeller(seller, page)
 	} else {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Must specify user, buyer, or seller",
@@ -62,14 +65,10 @@ func ListOrders(c *gin.Context) {
 		return
 	}
 
-	total, _ := getOrderService().GetTotalCount()
-
-	c.JSON(http.StatusOK, gin.H{
-		"data":   orders,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	if !fillFiat(c, result.Items) {
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
 
 // GetOrder 获取订单详情
@@ -83,7 +82,7 @@ func GetOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := getOrderService().GetOrder(id)
+	order, err := getOrderService().WithContext(c.Request.Context()).GetOrder(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch order",
@@ -98,6 +97,9 @@ func GetOrder(c *gin.Context) {
 		return
 	}
 
+	if !fillFiat(c, order) {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data": order,
 	})
@@ -114,7 +116,12 @@ func GetOrdersByAsset(c *gin.Context) {
 		return
 	}
 
-	orders, err := getOrderService().GetOrdersByAsset(assetID)
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	result, err := getOrderService().WithContext(c.Request.Context()).GetOrdersByAsset(assetID, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch orders",
@@ -122,9 +129,10 @@ func GetOrdersByAsset(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": orders,
-	})
+	if !fillFiat(c, result.Items) {
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
 
 
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"chain-vault-backend/internal/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// parsePage 解析列表接口的 limit、cursor 和 offset 参数，无效时返回 400
+func parsePage(c *gin.Context) (pagination.Params, bool) {
+	page, err := pagination.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return page, false
+	}
+	return page, true
+}
+
+// pageResponse 列表响应：当前页、符合条件的总数和下一页游标，没有下一页时 next_cursor 为 null
+func pageResponse[T any](result *pagination.Page[T], page pagination.Params) gin.H {
+	var nextCursor interface{}
+	if result.NextCursor != "" {
+		nextCursor = result.NextCursor
+	}
+	return gin.H{
+		"data":        result.Items,
+		"total":       result.Total,
+		"limit":       page.Limit,
+		"next_cursor": nextCursor,
+	}
+}
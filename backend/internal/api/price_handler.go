@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	priceService     *service.PriceService
+	priceServiceOnce sync.Once
+)
+
+func getPriceService() *service.PriceService {
+	priceServiceOnce.Do(func() {
+		priceService = service.NewPriceService()
+	})
+	return priceService
+}
+
+// GetETHPrice 查询 ETH 的法币价格，传入 wei 时同时返回折算结果
+func GetETHPrice(c *gin.Context) {
+	if !getPriceService().Enabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Price feed is not configured",
+		})
+		return
+	}
+	currency := getPriceService().Currency(c.Query("currency"))
+	if currency == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "currency is required",
+		})
+		return
+	}
+
+	var amount model.Wei
+	if value := c.Query("wei"); value != "" {
+		parsed, err := model.ParseWei(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid wei amount: " + err.Error(),
+			})
+			return
+		}
+		amount = parsed
+	}
+
+	value, err := getPriceService().Convert(c.Request.Context(), amount, currency)
+	if errors.Is(err, service.ErrUnsupportedCurrency) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "Failed to get ETH price",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"currency": currency,
+			"rate":     value.Rate,
+			"wei":      amount,
+			"eth":      amount.Ether(),
+			"amount":   value.Amount,
+		},
+	})
+}
+
+// fillFiat 按 currency 参数（缺省为 PRICE_CURRENCY）为资产或订单填充 priceFiat
+// 币种不受支持时返回 400 并返回 false
+func fillFiat(c *gin.Context, v interface{}) bool {
+	ctx := c.Request.Context()
+	currency := c.Query("currency")
+
+	var err error
+	switch items := v.(type) {
+	case []model.Asset:
+		err = getPriceService().FillAssets(ctx, currency, items)
+	case *model.Asset:
+		assets := []model.Asset{*items}
+		err = getPriceService().FillAssets(ctx, currency, assets)
+		items.PriceFiat = assets[0].PriceFiat
+	case []model.Order:
+		err = getPriceService().FillOrders(ctx, currency, items)
+	case *model.Order:
+		orders := []model.Order{*items}
+		err = getPriceService().FillOrders(ctx, currency, orders)
+		items.PriceFiat = orders[0].PriceFiat
+	}
+
+	if errors.Is(err, service.ErrUnsupportedCurrency) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"chain-vault-backend/internal/model"
 	"chain-vault-backend/internal/service"
 	"net/http"
@@ -23,15 +24,12 @@ func getReputationService() *service.ReputationService {
 
 // GetUserReputation 获取用户信誉
 func GetUserReputation(c *gin.Context) {
-	userAddress := c.Param("address")
-	if userAddress == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "User address is required",
-		})
+	userAddress, ok := paramAddress(c, "address")
+	if !ok {
 		return
 	}
 	
-	reputation, err := getReputationService().GetUserReputation(userAddress)
+	reputation, err := getReputationService().WithContext(c.Request.Context()).GetUserReputation(userAddress)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to get user reputation: " + err.Error(),
@@ -45,15 +43,16 @@ func GetUserReputation(c *gin.Context) {
 }
 
 // CreateReview 创建评价
+// 评价人为当前登录地址，必须是订单的买家或卖家；被评价人和角色由订单确定
 func CreateReview(c *gin.Context) {
 	var req struct {
-		OrderID         uint64 `json:"orderId" binding:"required"`
-		ReviewerAddress string `json:"reviewerAddress" binding:"required"`
-		RevieweeAddress string `json:"revieweeAddress" binding:"required"`
-		Role            string `json:"role" binding:"required"` // seller 或 buyer
-		Rating          int    `json:"rating" binding:"required,min=1,max=5"`
-		Comment         string `json:"comment"`
-		Tags            string `json:"tags"` // JSON 数组字符串
+		OrderID         uint64        `json:"orderId" binding:"required"`
+		ReviewerAddress model.Address `json:"reviewerAddress"` // 可选，需与登录地址一致
+		RevieweeAddress model.Address `json:"revieweeAddress"` // 可选，需与订单的另一方一致
+		Role            string        `json:"role"`            // 可选，被评价人在订单中的角色：seller 或 buyer
+		Rating          int           `json:"rating" binding:"required,min=1,max=5"`
+		Comment         string        `json:"comment"`
+		Tags            string        `json:"tags"` // JSON 数组字符串
 	}
 	
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -63,17 +62,66 @@ func CreateReview(c *gin.Context) {
 		return
 	}
 	
+	reviewer := authAddress(c)
+	if req.ReviewerAddress != "" && req.ReviewerAddress != reviewer {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "reviewerAddress does not match the signed-in wallet",
+		})
+		return
+	}
+	
+	order, err := getOrderService().WithContext(c.Request.Context()).GetOrder(req.OrderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch order",
+		})
+		return
+	}
+	if order == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Order not found",
+		})
+		return
+	}
+	
+	var reviewee model.Address
+	var role string
+	switch {
+	case order.Buyer == reviewer:
+		reviewee, role = order.Seller, "seller"
+	case order.Seller == reviewer:
+		reviewee, role = order.Buyer, "buyer"
+	default:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the buyer or seller of the order can review it",
+		})
+		return
+	}
+	if (req.RevieweeAddress != "" && req.RevieweeAddress != reviewee) || (req.Role != "" && req.Role != role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "revieweeAddress and role must be the other party of the order",
+		})
+		return
+	}
+	
 	review := &model.UserReview{
 		OrderID:         req.OrderID,
-		ReviewerAddress: req.ReviewerAddress,
-		RevieweeAddress: req.RevieweeAddress,
-		Role:            req.Role,
+		ReviewerAddress: reviewer,
+		RevieweeAddress: reviewee,
+		Role:            role,
 		Rating:          req.Rating,
 		Comment:         req.Comment,
 		Tags:            req.Tags,
 	}
 	
-	if err := getReputationService().CreateReview(review); err != nil {
+	err = getReputationService().WithContext(c.Request.Context()).CreateReview(review)
+	if errors.Is(err, service.ErrReviewExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "You have already reviewed this order",
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to create review: " + err.Error(),
 		})
@@ -88,17 +136,18 @@ func CreateReview(c *gin.Context) {
 
 // GetUserReviews 获取用户评价列表
 func GetUserReviews(c *gin.Context) {
-	userAddress := c.Param("address")
+	userAddress, ok := paramAddress(c, "address")
+	if !ok {
+		return
+	}
 	role := c.Query("role") // seller 或 buyer，可选
 	
-	if userAddress == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "User address is required",
-		})
+	page, ok := parsePage(c)
+	if !ok {
 		return
 	}
 	
-	reviews, err := getReputationService().GetUserReviews(userAddress, role)
+	result, err := getReputationService().WithContext(c.Request.Context()).GetUserReviews(userAddress, role, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to get reviews: " + err.Error(),
@@ -106,8 +155,5 @@ func GetUserReviews(c *gin.Context) {
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{
-		"data":  reviews,
-		"total": len(reviews),
-	})
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
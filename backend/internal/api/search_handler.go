@@ -1,36 +1,54 @@
 package api
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
+	"sync"
 
+	"chain-vault-backend/internal/pagination"
+	"chain-vault-backend/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	searchService     *service.SearchService
+	searchServiceOnce sync.Once
+)
+
+func getSearchService() *service.SearchService {
+	searchServiceOnce.Do(func() {
+		searchService = service.NewSearchService()
+	})
+	return searchService
+}
+
 // SearchAssets 搜索资产
+// 关键词匹配名称、序列号、品牌名称及元数据中的分类、型号、颜色、尺码；可只按筛选条件浏览
 func SearchAssets(c *gin.Context) {
-	keyword := c.Query("q")
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	if keyword == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Search keyword is required",
-		})
+	page, ok := parsePage(c)
+	if !ok {
 		return
 	}
 
-	limit, _ := strconv.Atoi(limitStr)
-	if limit <= 0 || limit > 100 {
-		limit = 20
+	params := service.SearchParams{
+		Keyword:  c.Query("q"),
+		Brand:    c.Query("brand"),
+		Category: c.Query("category"),
+		Status:   c.Query("status"),
+		Listed:   c.Query("listed"),
+		MinPrice: c.Query("minPrice"),
+		MaxPrice: c.Query("maxPrice"),
+		Sort:     c.Query("sort"),
+		Page:     page,
 	}
 
-	offset, _ := strconv.Atoi(offsetStr)
-	if offset < 0 {
-		offset = 0
+	result, err := getSearchService().WithContext(c.Request.Context()).Search(params)
+	if errors.Is(err, service.ErrInvalidSearch) || errors.Is(err, pagination.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
-
-	assets, err := getAssetService().SearchAssets(keyword, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to search assets",
@@ -38,19 +56,21 @@ func SearchAssets(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":    assets,
-		"keyword": keyword,
-		"limit":   limit,
-		"offset":  offset,
-	})
+	if !fillFiat(c, result.Items) {
+		return
+	}
+	response := pageResponse(result.Page, page)
+	response["facets"] = result.Facets
+	response["keyword"] = params.Keyword
+	response["sort"] = result.Sort
+	c.JSON(http.StatusOK, response)
 }
 
 // GetAssetBySerialNumber 通过序列号查询资产
 func GetAssetBySerialNumber(c *gin.Context) {
 	serialNumber := c.Param("serialNumber")
 
-	asset, err := getAssetService().GetAssetBySerialNumber(serialNumber)
+	asset, err := getAssetService().WithContext(c.Request.Context()).GetAssetBySerialNumber(serialNumber)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch asset",
@@ -65,6 +85,9 @@ func GetAssetBySerialNumber(c *gin.Context) {
 		return
 	}
 
+	if !fillFiat(c, asset) {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data": asset,
 	})
@@ -72,20 +95,18 @@ func GetAssetBySerialNumber(c *gin.Context) {
 
 // GetListedAssets 获取在售资产
 func GetListedAssets(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, _ := strconv.Atoi(limitStr)
-	if limit <= 0 || limit > 100 {
-		limit = 20
+	page, ok := parsePage(c)
+	if !ok {
+		return
 	}
 
-	offset, _ := strconv.Atoi(offsetStr)
-	if offset < 0 {
-		offset = 0
+	result, err := getAssetService().WithContext(c.Request.Context()).GetListedAssets(page)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
-
-	assets, err := getAssetService().GetListedAssets(limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch listed assets",
@@ -93,18 +114,8 @@ func GetListedAssets(c *gin.Context) {
 		return
 	}
 
-	// 获取在售资产总数
-	total, err := getAssetService().GetListedAssetsCount()
-	if err != nil {
-		// 如果获取总数失败，使用当前返回的数组长度
-		total = int64(len(assets))
+	if !fillFiat(c, result.Items) {
+		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"data":   assets,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	c.JSON(http.StatusOK, pageResponse(result, page))
 }
-
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chain-vault-backend/internal/events"
+	"chain-vault-backend/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// streamHeartbeat 连接空闲时发送心跳的间隔，避免代理断开长连接
+	streamHeartbeat = 15 * time.Second
+	// wsWriteTimeout WebSocket 单条消息的写超时
+	wsWriteTimeout = 10 * time.Second
+)
+
+// errSubscriptionOverflow 客户端读取太慢，订阅缓冲区已满
+var errSubscriptionOverflow = errors.New("subscription buffer overflowed, reconnect with the last event id")
+
+var (
+	streamService     *service.EventStreamService
+	streamServiceOnce sync.Once
+)
+
+func getStreamService() *service.EventStreamService {
+	streamServiceOnce.Do(func() {
+		streamService = service.NewEventStreamService()
+	})
+	return streamService
+}
+
+// upgrader 与 CORS 设置一致，只接受允许的来源
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return originAllowed(r.Header.Get("Origin")) },
+}
+
+// StreamEvents 通过 Server-Sent Events 推送链上变化
+// 浏览器 EventSource 断线重连时自动带上 Last-Event-ID，从该事件之后补发
+func StreamEvents(c *gin.Context) {
+	filter, ok := parseStreamFilter(c)
+	if !ok {
+		return
+	}
+	lastID, resume, ok := parseLastEventID(c)
+	if !ok {
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // 关闭 Nginx 的响应缓冲
+	c.Status(http.StatusOK)
+	io.WriteString(c.Writer, "retry: 3000\n\n")
+	c.Writer.Flush()
+
+	send := func(ev events.Event) error {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		if ev.ID != 0 {
+			fmt.Fprintf(c.Writer, "id: %d\n", ev.ID)
+		}
+		if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	ping := func() error {
+		if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	// 出错时直接结束响应，客户端会自动重连并续传
+	streamEvents(c.Request.Context(), filter, lastID, resume, send, ping)
+}
+
+// StreamWebSocket 通过 WebSocket 推送链上变化，每条消息为一个事件的 JSON
+// 续传使用 lastEventId 参数；订阅溢出时以 1013 关闭连接，客户端应带上最后的事件 ID 重连
+func StreamWebSocket(c *gin.Context) {
+	filter, ok := parseStreamFilter(c)
+	if !ok {
+		return
+	}
+	lastID, resume, ok := parseLastEventID(c)
+	if !ok {
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 已写入错误响应
+		return
+	}
+	defer conn.Close()
+
+	// 客户端不需要发送消息；读取失败说明连接已关闭，停止推送
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(ev events.Event) error {
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		return conn.WriteJSON(ev)
+	}
+	ping := func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+	}
+
+	err = streamEvents(ctx, filter, lastID, resume, send, ping)
+	if errors.Is(err, errSubscriptionOverflow) {
+		message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error())
+		conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteTimeout))
+	}
+}
+
+// streamEvents 先补发 lastID 之后的事件，再转发实时事件，直到连接断开、发送失败或订阅溢出
+// 补发前已订阅，补发期间发布的事件按 ID 去重
+func streamEvents(ctx context.Context, filter events.Filter, lastID uint64, resume bool, send func(events.Event) error, ping func() error) error {
+	sub := events.Default().Subscribe(filter)
+	defer sub.Close()
+
+	if resume {
+		replayed, err := getStreamService().WithContext(ctx).Replay(lastID, filter, send)
+		if err != nil {
+			return err
+		}
+		lastID = replayed
+	}
+
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.C:
+			if !ok {
+				return errSubscriptionOverflow
+			}
+			if ev.ID != 0 && ev.ID <= lastID {
+				continue
+			}
+			if err := send(ev); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseStreamFilter 解析订阅条件：assetId、owner、participant、type，可重复传入或用逗号分隔，无效时返回 400
+func parseStreamFilter(c *gin.Context) (events.Filter, bool) {
+	var filter events.Filter
+	for _, value := range queryList(c, "assetId") {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid assetId: " + value,
+			})
+			return filter, false
+		}
+		filter.AssetIDs = append(filter.AssetIDs, id)
+	}
+	for _, name := range []string{"owner", "participant"} {
+		for _, value := range queryList(c, name) {
+			address, ok := parseAddress(c, name, value)
+			if !ok {
+				return filter, false
+			}
+			if name == "owner" {
+				filter.Owners = append(filter.Owners, address)
+			} else {
+				filter.Participants = append(filter.Participants, address)
+			}
+		}
+	}
+	filter.Types = queryList(c, "type")
+	return filter, true
+}
+
+// parseLastEventID 读取 Last-Event-ID 请求头或 lastEventId 参数，resume 表示需要补发
+func parseLastEventID(c *gin.Context) (id uint64, resume bool, ok bool) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("lastEventId")
+	}
+	if value == "" {
+		return 0, false, true
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid last event id: " + value,
+		})
+		return 0, false, false
+	}
+	return id, true, true
+}
+
+// queryList 读取可重复、可用逗号分隔的查询参数
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, value := range c.QueryArray(name) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"chain-vault-backend/internal/chain"
+	"chain-vault-backend/internal/service"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// 交易在 GET /tx/:hash 中的状态
+const (
+	txStatusPending   = "pending"   // 尚未打包
+	txStatusMined     = "mined"     // 已打包，监听器尚未索引（未达到确认数或仍在同步）
+	txStatusConfirmed = "confirmed" // 已被监听器索引，数据库记录已生成
+	txStatusFailed    = "failed"    // 已打包但执行失败
+)
+
+var (
+	// chainClient 用于查询交易，未设置合约地址时为 nil
+	chainClient *chain.Client
+
+	syncService     *service.SyncService
+	syncServiceOnce sync.Once
+
+	payloadService     *service.PayloadService
+	payloadServiceOnce sync.Once
+)
+
+// SetChainClient 设置查询交易使用的链客户端，由 main 在启动时调用
+func SetChainClient(client *chain.Client) {
+	chainClient = client
+}
+
+func getSyncService() *service.SyncService {
+	syncServiceOnce.Do(func() {
+		syncService = service.NewSyncService()
+	})
+	return syncService
+}
+
+func getPayloadService() *service.PayloadService {
+	payloadServiceOnce.Do(func() {
+		payloadService = service.NewPayloadService()
+	})
+	return payloadService
+}
+
+// parseTxHash 解析路径中的交易哈希，统一为小写十六进制
+func parseTxHash(c *gin.Context) (string, bool) {
+	hash := c.Param("hash")
+	if len(common.FromHex(hash)) != common.HashLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction hash",
+		})
+		return "", false
+	}
+	return common.HexToHash(hash).Hex(), true
+}
+
+// GetTransaction 查询交易状态、解析后的事件以及监听器据此生成的数据库记录
+func GetTransaction(c *gin.Context) {
+	hash, ok := parseTxHash(c)
+	if !ok {
+		return
+	}
+
+	if chainClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Chain client is not configured",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	info, err := chainClient.GetTxInfo(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Transaction not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "Failed to fetch transaction: " + err.Error(),
+		})
+		return
+	}
+
+	records, err := getSyncService().WithContext(c.Request.Context()).GetEventsByTx(hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch indexed records",
+		})
+		return
+	}
+
+	payloads, err := getPayloadService().WithContext(c.Request.Context()).GetPayloadsByTx(hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch payloads",
+		})
+		return
+	}
+
+	status := txStatusPending
+	var confirmations uint64
+	if !info.Pending {
+		status = txStatusMined
+		if !info.Success {
+			status = txStatusFailed
+		} else {
+			contract := chainClient.GetContractAddress().Hex()
+			lastBlock, synced, err := getSyncService().WithContext(c.Request.Context()).GetCheckpoint(contract)
+			if err == nil && synced && lastBlock >= info.BlockNumber {
+				status = txStatusConfirmed
+			}
+		}
+		if latest, err := chainClient.GetLatestBlock(ctx); err == nil && latest >= info.BlockNumber {
+			confirmations = latest - info.BlockNumber + 1
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"txHash":        hash,
+			"status":        status,
+			"blockNumber":   info.BlockNumber,
+			"confirmations": confirmations,
+			"transaction":   info,
+			"records":       records,
+			"payloads":      payloads,
+		},
+	})
+}
+
+// SubmitTxPayload 提交依附于交易的延迟数据（如资产图片）
+// 交易已被索引时立即写入，否则在监听器索引该交易后写入；写入时要求登录地址是对应记录的所有者
+func SubmitTxPayload(c *gin.Context) {
+	hash, ok := parseTxHash(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Kind string          `json:"kind" binding:"required"` // 目前支持 asset_images
+		Data json.RawMessage `json:"data" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	payload, err := getPayloadService().WithContext(c.Request.Context()).SubmitPayload(hash, req.Kind, authAddress(c), req.Data)
+	if errors.Is(err, service.ErrInvalidPayload) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save payload",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data": payload,
+	})
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	webhookService     *service.WebhookService
+	webhookServiceOnce sync.Once
+)
+
+func getWebhookService() *service.WebhookService {
+	webhookServiceOnce.Do(func() {
+		webhookService = service.NewWebhookService()
+	})
+	return webhookService
+}
+
+// CreateWebhook 为登录地址登记 Webhook，响应中的 secret 只返回这一次
+func CreateWebhook(c *gin.Context) {
+	var req struct {
+		URL        string   `json:"url" binding:"required"`
+		Scope      string   `json:"scope" binding:"required"` // brand 或 owner
+		EventTypes []string `json:"eventTypes"`               // 为空表示全部事件
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	subscription, err := getWebhookService().WithContext(c.Request.Context()).CreateSubscription(authAddress(c), req.Scope, req.URL, req.EventTypes)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create webhook",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":   subscription,
+		"secret": subscription.Secret,
+	})
+}
+
+// ListWebhooks 列出登录地址的 Webhook，管理员可以看到全部
+func ListWebhooks(c *gin.Context) {
+	subscriptions, err := getWebhookService().WithContext(c.Request.Context()).ListSubscriptions(webhookCaller(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch webhooks",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  subscriptions,
+		"total": len(subscriptions),
+	})
+}
+
+// GetWebhook 获取 Webhook 详情
+func GetWebhook(c *gin.Context) {
+	subscription, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": subscription,
+	})
+}
+
+// UpdateWebhook 修改 Webhook 的回调地址、事件类型或启用状态，未提供的字段保持不变
+func UpdateWebhook(c *gin.Context) {
+	subscription, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		URL        *string   `json:"url"`
+		EventTypes *[]string `json:"eventTypes"`
+		Active     *bool     `json:"active"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	err := getWebhookService().WithContext(c.Request.Context()).UpdateSubscription(subscription, req.URL, req.EventTypes, req.Active)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update webhook",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": subscription,
+	})
+}
+
+// DeleteWebhook 删除 Webhook 及其投递记录
+func DeleteWebhook(c *gin.Context) {
+	subscription, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := getWebhookService().WithContext(c.Request.Context()).DeleteSubscription(subscription.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete webhook",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted",
+	})
+}
+
+// ListWebhookDeliveries 分页列出 Webhook 的投递记录，status 可选 pending / delivered / dead
+func ListWebhookDeliveries(c *gin.Context) {
+	subscription, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	result, err := getWebhookService().WithContext(c.Request.Context()).ListDeliveries(subscription.ID, c.Query("status"), page)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch webhook deliveries",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(result, page))
+}
+
+// ReplayWebhookDeliveries 重新投递指定记录，未指定时重放全部死信
+func ReplayWebhookDeliveries(c *gin.Context) {
+	subscription, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		DeliveryIDs []uint64 `json:"deliveryIds"`
+	}
+	// 请求体可以为空
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	replayed, err := getWebhookService().WithContext(c.Request.Context()).ReplayDeliveries(subscription.ID, req.DeliveryIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to replay webhook deliveries",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"replayed": replayed,
+	})
+}
+
+// findWebhook 读取路径中的 Webhook，不存在或不属于登录地址时返回 404
+func findWebhook(c *gin.Context) (*model.WebhookSubscription, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid webhook ID",
+		})
+		return nil, false
+	}
+
+	subscription, err := getWebhookService().WithContext(c.Request.Context()).GetSubscription(id, webhookCaller(c))
+	if errors.Is(err, service.ErrWebhookNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Webhook not found",
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch webhook",
+		})
+		return nil, false
+	}
+	return subscription, true
+}
+
+// webhookCaller 管理员可以管理所有 Webhook，返回空地址表示不限制
+func webhookCaller(c *gin.Context) model.Address {
+	caller := authAddress(c)
+	if authService.IsAdmin(caller) {
+		return ""
+	}
+	return caller
+}
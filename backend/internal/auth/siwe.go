@@ -0,0 +1,180 @@
+// Package auth 实现 EIP-4361（Sign-In with Ethereum）消息的解析与签名校验
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const headerSuffix = " wants you to sign in with your Ethereum account:"
+
+var (
+	// ErrInvalidMessage 消息不符合 EIP-4361 格式
+	ErrInvalidMessage = errors.New("invalid SIWE message")
+	// ErrInvalidSignature 签名无法解析或与消息中的地址不一致
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Message 解析后的 SIWE 消息
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        uint64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage 按 EIP-4361 解析钱包签名的明文消息
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+
+	msg := &Message{Domain: strings.TrimSuffix(lines[0], headerSuffix)}
+	// domain 前可以带 scheme，例如 https://example.com
+	if i := strings.Index(msg.Domain, "://"); i >= 0 {
+		msg.Domain = msg.Domain[i+3:]
+	}
+	if msg.Domain == "" {
+		return nil, fmt.Errorf("%w: empty domain", ErrInvalidMessage)
+	}
+
+	if !common.IsHexAddress(lines[1]) || !strings.HasPrefix(lines[1], "0x") {
+		return nil, fmt.Errorf("%w: invalid address", ErrInvalidMessage)
+	}
+	msg.Address = common.HexToAddress(lines[1])
+
+	// 地址之后是空行，然后是可选的 statement，直到 URI 字段
+	i := 2
+	var statement []string
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], "URI: "); i++ {
+		if lines[i] != "" {
+			statement = append(statement, lines[i])
+		}
+	}
+	msg.Statement = strings.Join(statement, "\n")
+
+	fields := make(map[string]string)
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if line == "Resources:" {
+			for i++; i < len(lines) && strings.HasPrefix(lines[i], "- "); i++ {
+				msg.Resources = append(msg.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			i--
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidMessage, key)
+		}
+		fields[key] = value
+	}
+
+	msg.URI = fields["URI"]
+	msg.Version = fields["Version"]
+	msg.Nonce = fields["Nonce"]
+	msg.RequestID = fields["Request ID"]
+	if msg.URI == "" || msg.Nonce == "" {
+		return nil, fmt.Errorf("%w: URI and Nonce are required", ErrInvalidMessage)
+	}
+	if msg.Version != "1" {
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, msg.Version)
+	}
+
+	chainID, err := strconv.ParseUint(fields["Chain ID"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid Chain ID", ErrInvalidMessage)
+	}
+	msg.ChainID = chainID
+
+	if msg.IssuedAt, err = time.Parse(time.RFC3339, fields["Issued At"]); err != nil {
+		return nil, fmt.Errorf("%w: invalid Issued At", ErrInvalidMessage)
+	}
+	if msg.ExpirationTime, err = parseOptionalTime(fields["Expiration Time"]); err != nil {
+		return nil, fmt.Errorf("%w: invalid Expiration Time", ErrInvalidMessage)
+	}
+	if msg.NotBefore, err = parseOptionalTime(fields["Not Before"]); err != nil {
+		return nil, fmt.Errorf("%w: invalid Not Before", ErrInvalidMessage)
+	}
+
+	return msg, nil
+}
+
+// ValidAt 检查消息在 now 时是否处于有效期内
+func (m *Message) ValidAt(now time.Time) error {
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return fmt.Errorf("%w: message expired", ErrInvalidMessage)
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return fmt.Errorf("%w: message not yet valid", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// RecoverAddress 从 personal_sign 签名中恢复签名地址
+func RecoverAddress(message, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+	// 钱包返回的 v 为 27/28，crypto.SigToPub 需要 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Verify 解析消息并校验签名者就是消息中的地址
+func Verify(message, signature string) (*Message, error) {
+	msg, err := ParseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := RecoverAddress(message, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != msg.Address {
+		return nil, ErrInvalidSignature
+	}
+	return msg, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
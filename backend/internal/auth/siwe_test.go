@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+// buildMessage 按 EIP-4361 拼接消息，fields 中的空值省略
+func buildMessage(header, address, statement string, fields ...[2]string) string {
+	lines := []string{header + headerSuffix, address, ""}
+	if statement != "" {
+		lines = append(lines, statement, "")
+	}
+	for _, field := range fields {
+		if field[1] != "" {
+			lines = append(lines, field[0]+": "+field[1])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func standardFields() [][2]string {
+	return [][2]string{
+		{"URI", "https://localhost:5173"},
+		{"Version", "1"},
+		{"Chain ID", "31337"},
+		{"Nonce", "abc123"},
+		{"Issued At", "2024-01-01T00:00:00Z"},
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	valid := buildMessage("localhost:5173", testAddress, "Sign in to ChainVault", standardFields()...)
+
+	withResources := valid + "\nResources:\n- ipfs://bafy1\n- https://example.com/terms"
+	withTimes := buildMessage("https://app.example.com", strings.ToLower(testAddress), "",
+		append(standardFields(),
+			[2]string{"Expiration Time", "2024-01-02T00:00:00Z"},
+			[2]string{"Not Before", "2024-01-01T00:00:00Z"},
+			[2]string{"Request ID", "req-1"})...)
+
+	tests := []struct {
+		name    string
+		raw     string
+		check   func(t *testing.T, m *Message)
+		wantErr bool
+	}{
+		{"valid", valid, func(t *testing.T, m *Message) {
+			if m.Domain != "localhost:5173" || m.Address != common.HexToAddress(testAddress) ||
+				m.Statement != "Sign in to ChainVault" || m.ChainID != 31337 || m.Nonce != "abc123" ||
+				!m.IssuedAt.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected message %+v", m)
+			}
+		}, false},
+		{"crlf line endings", strings.ReplaceAll(valid, "\n", "\r\n"), func(t *testing.T, m *Message) {
+			if m.Nonce != "abc123" {
+				t.Errorf("nonce = %q", m.Nonce)
+			}
+		}, false},
+		{"resources", withResources, func(t *testing.T, m *Message) {
+			if len(m.Resources) != 2 || m.Resources[1] != "https://example.com/terms" {
+				t.Errorf("resources = %v", m.Resources)
+			}
+		}, false},
+		{"scheme, lowercase address and optional fields", withTimes, func(t *testing.T, m *Message) {
+			if m.Domain != "app.example.com" || m.Statement != "" || m.RequestID != "req-1" ||
+				m.ExpirationTime == nil || m.NotBefore == nil {
+				t.Errorf("unexpected message %+v", m)
+			}
+		}, false},
+		{"missing header", strings.SplitN(valid, "\n", 2)[1], nil, true},
+		{"empty domain", buildMessage("", testAddress, "", standardFields()...), nil, true},
+		{"address without 0x", buildMessage("localhost", testAddress[2:], "", standardFields()...), nil, true},
+		{"short address", buildMessage("localhost", testAddress[:40], "", standardFields()...), nil, true},
+		{"missing nonce", strings.Replace(valid, "Nonce: abc123", "", 1), nil, true},
+		{"missing URI", strings.Replace(valid, "URI: https://localhost:5173\n", "", 1), nil, true},
+		{"wrong version", strings.Replace(valid, "Version: 1", "Version: 2", 1), nil, true},
+		{"bad chain id", strings.Replace(valid, "Chain ID: 31337", "Chain ID: mainnet", 1), nil, true},
+		{"bad issued at", strings.Replace(valid, "2024-01-01T00:00:00Z", "yesterday", 1), nil, true},
+		{"bad expiration", valid + "\nExpiration Time: soon", nil, true},
+		{"duplicate field", valid + "\nNonce: other", nil, true},
+		{"garbage line", valid + "\nnot a field", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMessage(tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMessage) {
+					t.Errorf("error = %v, want ErrInvalidMessage", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, m)
+		})
+	}
+}
+
+func TestValidAt(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := notBefore.Add(time.Hour)
+	m := &Message{NotBefore: &notBefore, ExpirationTime: &expires}
+
+	tests := []struct {
+		now time.Time
+		ok  bool
+	}{
+		{notBefore.Add(-time.Second), false},
+		{notBefore, true},
+		{expires.Add(-time.Second), true},
+		{expires, false},
+	}
+	for _, tt := range tests {
+		err := m.ValidAt(tt.now)
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidAt(%s) = %v, want ok=%v", tt.now, err, tt.ok)
+		}
+	}
+	if err := (&Message{}).ValidAt(time.Now()); err != nil {
+		t.Errorf("message without time bounds: %v", err)
+	}
+}
+
+// sign 模拟钱包的 personal_sign，v 为 27/28
+func sign(t *testing.T, message string) (common.Address, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return crypto.PubkeyToAddress(key.PublicKey), hexutil.Encode(sig)
+}
+
+func TestVerify(t *testing.T) {
+	// 消息里的地址必须是签名者，所以先生成密钥再拼消息
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	message := buildMessage("localhost:5173", signer.Hex(), "", standardFields()...)
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletSig := make([]byte, len(sig))
+	copy(walletSig, sig)
+	walletSig[crypto.RecoveryIDOffset] += 27
+
+	_, otherSig := sign(t, message)
+	tampered := strings.Replace(message, "abc123", "abc124", 1)
+
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		wantErr   error
+	}{
+		{"wallet signature (v = 27/28)", message, hexutil.Encode(walletSig), nil},
+		{"raw signature (v = 0/1)", message, hexutil.Encode(sig), nil},
+		{"signed by another key", message, otherSig, ErrInvalidSignature},
+		{"tampered message", tampered, hexutil.Encode(walletSig), ErrInvalidSignature},
+		{"not hex", message, "0xzz", ErrInvalidSignature},
+		{"wrong length", message, hexutil.Encode(walletSig[:64]), ErrInvalidSignature},
+		{"invalid message", "hello", hexutil.Encode(walletSig), ErrInvalidMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Verify(tt.message, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Address != signer {
+				t.Errorf("address = %s, want %s", m.Address.Hex(), signer.Hex())
+			}
+		})
+	}
+}
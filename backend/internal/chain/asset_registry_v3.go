@@ -0,0 +1,3386 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AssetRegistryV3Asset is an auto generated low-level Go binding around an user-defined struct.
+type AssetRegistryV3Asset struct {
+	AssetId      *big.Int
+	Owner        common.Address
+	Brand        common.Address
+	Name         string
+	SerialNumber string
+	MetadataURI  string
+	Status       uint8
+	CreatedAt    *big.Int
+	IsListed     bool
+	Price        *big.Int
+}
+
+// AssetRegistryV3MetaData contains all meta data concerning the AssetRegistryV3 contract.
+var AssetRegistryV3MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"AssetListed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"brand\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"}],\"name\":\"AssetRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"}],\"name\":\"AssetTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"}],\"name\":\"AssetUnlisted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"enumAssetRegistryV3.VerificationStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"verifier\",\"type\":\"address\"}],\"name\":\"AssetVerified\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"brandAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"isAuthorized\",\"type\":\"bool\"}],\"name\":\"BrandAuthorized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"brandAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"brandName\",\"type\":\"string\"}],\"name\":\"BrandRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"OrderCancelled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"OrderCompleted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"buyer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"OrderCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"OrderDelivered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"buyer\",\"type\":\"address\"}],\"name\":\"OrderPaid\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"refundAmount\",\"type\":\"uint256\"}],\"name\":\"OrderRefunded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"OrderShipped\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"admin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"assetCounter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"assetOrderHistory\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"assetOwnerHistory\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"assets\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"brand\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"metadataURI\",\"type\":\"string\"},{\"internalType\":\"enumAssetRegistryV3.VerificationStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isListed\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"brandAddress\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"authorized\",\"type\":\"bool\"}],\"name\":\"authorizeBrand\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"brandList\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"brands\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"brandAddress\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"brandName\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"isAuthorized\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"registeredAt\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"cancelOrder\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"completeOrder\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"confirmDelivery\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"}],\"name\":\"createOrder\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getAllBrands\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"}],\"name\":\"getAssetBySerialNumber\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"brand\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"metadataURI\",\"type\":\"string\"},{\"internalType\":\"enumAssetRegistryV3.VerificationStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isListed\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"internalType\":\"structAssetRegistryV3.Asset\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"}],\"name\":\"getAssetOrderHistory\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"}],\"name\":\"getAssetOwnerHistory\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"getAssetsByOwner\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getListedAssets\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"getOrdersByUser\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"listAsset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"orderCounter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"orders\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"buyer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"},{\"internalType\":\"enumAssetRegistryV3.OrderStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"paidAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"shippedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deliveredAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"completedAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"canRefund\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"refundDeadline\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"platformFeePercent\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"metadataURI\",\"type\":\"string\"}],\"name\":\"registerAsset\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"serialNumber\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"metadataURI\",\"type\":\"string\"}],\"name\":\"registerAssetByUser\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"brandName\",\"type\":\"string\"}],\"name\":\"registerBrand\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"requestRefund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"serialNumberExists\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"serialNumberToAssetId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"feePercent\",\"type\":\"uint256\"}],\"name\":\"setPlatformFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"orderId\",\"type\":\"uint256\"}],\"name\":\"shipOrder\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"transferAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferAsset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"}],\"name\":\"unlistAsset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assetId\",\"type\":\"uint256\"},{\"internalType\":\"enumAssetRegistryV3.VerificationStatus\",\"name\":\"newStatus\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"brandAddress\",\"type\":\"address\"}],\"name\":\"verifyAsset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdrawPlatformFees\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// AssetRegistryV3ABI is the input ABI used to generate the binding from.
+// Deprecated: Use AssetRegistryV3MetaData.ABI instead.
+var AssetRegistryV3ABI = AssetRegistryV3MetaData.ABI
+
+// AssetRegistryV3 is an auto generated Go binding around an Ethereum contract.
+type AssetRegistryV3 struct {
+	AssetRegistryV3Caller     // Read-only binding to the contract
+	AssetRegistryV3Transactor // Write-only binding to the contract
+	AssetRegistryV3Filterer   // Log filterer for contract events
+}
+
+// AssetRegistryV3Caller is an auto generated read-only Go binding around an Ethereum contract.
+type AssetRegistryV3Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AssetRegistryV3Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type AssetRegistryV3Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AssetRegistryV3Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AssetRegistryV3Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AssetRegistryV3Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AssetRegistryV3Session struct {
+	Contract     *AssetRegistryV3  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// AssetRegistryV3CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AssetRegistryV3CallerSession struct {
+	Contract *AssetRegistryV3Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// AssetRegistryV3TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AssetRegistryV3TransactorSession struct {
+	Contract     *AssetRegistryV3Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// AssetRegistryV3Raw is an auto generated low-level Go binding around an Ethereum contract.
+type AssetRegistryV3Raw struct {
+	Contract *AssetRegistryV3 // Generic contract binding to access the raw methods on
+}
+
+// AssetRegistryV3CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AssetRegistryV3CallerRaw struct {
+	Contract *AssetRegistryV3Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// AssetRegistryV3TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AssetRegistryV3TransactorRaw struct {
+	Contract *AssetRegistryV3Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAssetRegistryV3 creates a new instance of AssetRegistryV3, bound to a specific deployed contract.
+func NewAssetRegistryV3(address common.Address, backend bind.ContractBackend) (*AssetRegistryV3, error) {
+	contract, err := bindAssetRegistryV3(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3{AssetRegistryV3Caller: AssetRegistryV3Caller{contract: contract}, AssetRegistryV3Transactor: AssetRegistryV3Transactor{contract: contract}, AssetRegistryV3Filterer: AssetRegistryV3Filterer{contract: contract}}, nil
+}
+
+// NewAssetRegistryV3Caller creates a new read-only instance of AssetRegistryV3, bound to a specific deployed contract.
+func NewAssetRegistryV3Caller(address common.Address, caller bind.ContractCaller) (*AssetRegistryV3Caller, error) {
+	contract, err := bindAssetRegistryV3(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3Caller{contract: contract}, nil
+}
+
+// NewAssetRegistryV3Transactor creates a new write-only instance of AssetRegistryV3, bound to a specific deployed contract.
+func NewAssetRegistryV3Transactor(address common.Address, transactor bind.ContractTransactor) (*AssetRegistryV3Transactor, error) {
+	contract, err := bindAssetRegistryV3(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3Transactor{contract: contract}, nil
+}
+
+// NewAssetRegistryV3Filterer creates a new log filterer instance of AssetRegistryV3, bound to a specific deployed contract.
+func NewAssetRegistryV3Filterer(address common.Address, filterer bind.ContractFilterer) (*AssetRegistryV3Filterer, error) {
+	contract, err := bindAssetRegistryV3(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3Filterer{contract: contract}, nil
+}
+
+// bindAssetRegistryV3 binds a generic wrapper to an already deployed contract.
+func bindAssetRegistryV3(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AssetRegistryV3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AssetRegistryV3 *AssetRegistryV3Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AssetRegistryV3.Contract.AssetRegistryV3Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AssetRegistryV3 *AssetRegistryV3Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.AssetRegistryV3Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AssetRegistryV3 *AssetRegistryV3Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.AssetRegistryV3Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AssetRegistryV3 *AssetRegistryV3CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AssetRegistryV3.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AssetRegistryV3 *AssetRegistryV3TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AssetRegistryV3 *AssetRegistryV3TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.contract.Transact(opts, method, params...)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Session) Admin() (common.Address, error) {
+	return _AssetRegistryV3.Contract.Admin(&_AssetRegistryV3.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) Admin() (common.Address, error) {
+	return _AssetRegistryV3.Contract.Admin(&_AssetRegistryV3.CallOpts)
+}
+
+// AssetCounter is a free data retrieval call binding the contract method 0x4b918786.
+//
+// Solidity: function assetCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) AssetCounter(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "assetCounter")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AssetCounter is a free data retrieval call binding the contract method 0x4b918786.
+//
+// Solidity: function assetCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) AssetCounter() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.AssetCounter(&_AssetRegistryV3.CallOpts)
+}
+
+// AssetCounter is a free data retrieval call binding the contract method 0x4b918786.
+//
+// Solidity: function assetCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) AssetCounter() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.AssetCounter(&_AssetRegistryV3.CallOpts)
+}
+
+// AssetOrderHistory is a free data retrieval call binding the contract method 0xc0301e5a.
+//
+// Solidity: function assetOrderHistory(uint256 , uint256 ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) AssetOrderHistory(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "assetOrderHistory", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AssetOrderHistory is a free data retrieval call binding the contract method 0xc0301e5a.
+//
+// Solidity: function assetOrderHistory(uint256 , uint256 ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) AssetOrderHistory(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _AssetRegistryV3.Contract.AssetOrderHistory(&_AssetRegistryV3.CallOpts, arg0, arg1)
+}
+
+// AssetOrderHistory is a free data retrieval call binding the contract method 0xc0301e5a.
+//
+// Solidity: function assetOrderHistory(uint256 , uint256 ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) AssetOrderHistory(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _AssetRegistryV3.Contract.AssetOrderHistory(&_AssetRegistryV3.CallOpts, arg0, arg1)
+}
+
+// AssetOwnerHistory is a free data retrieval call binding the contract method 0xa976e894.
+//
+// Solidity: function assetOwnerHistory(uint256 , uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) AssetOwnerHistory(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "assetOwnerHistory", arg0, arg1)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AssetOwnerHistory is a free data retrieval call binding the contract method 0xa976e894.
+//
+// Solidity: function assetOwnerHistory(uint256 , uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Session) AssetOwnerHistory(arg0 *big.Int, arg1 *big.Int) (common.Address, error) {
+	return _AssetRegistryV3.Contract.AssetOwnerHistory(&_AssetRegistryV3.CallOpts, arg0, arg1)
+}
+
+// AssetOwnerHistory is a free data retrieval call binding the contract method 0xa976e894.
+//
+// Solidity: function assetOwnerHistory(uint256 , uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) AssetOwnerHistory(arg0 *big.Int, arg1 *big.Int) (common.Address, error) {
+	return _AssetRegistryV3.Contract.AssetOwnerHistory(&_AssetRegistryV3.CallOpts, arg0, arg1)
+}
+
+// Assets is a free data retrieval call binding the contract method 0xcf35bdd0.
+//
+// Solidity: function assets(uint256 ) view returns(uint256 assetId, address owner, address brand, string name, string serialNumber, string metadataURI, uint8 status, uint256 createdAt, bool isListed, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) Assets(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	AssetId      *big.Int
+	Owner        common.Address
+	Brand        common.Address
+	Name         string
+	SerialNumber string
+	MetadataURI  string
+	Status       uint8
+	CreatedAt    *big.Int
+	IsListed     bool
+	Price        *big.Int
+}, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "assets", arg0)
+
+	outstruct := new(struct {
+		AssetId      *big.Int
+		Owner        common.Address
+		Brand        common.Address
+		Name         string
+		SerialNumber string
+		MetadataURI  string
+		Status       uint8
+		CreatedAt    *big.Int
+		IsListed     bool
+		Price        *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.AssetId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Owner = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Brand = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.Name = *abi.ConvertType(out[3], new(string)).(*string)
+	outstruct.SerialNumber = *abi.ConvertType(out[4], new(string)).(*string)
+	outstruct.MetadataURI = *abi.ConvertType(out[5], new(string)).(*string)
+	outstruct.Status = *abi.ConvertType(out[6], new(uint8)).(*uint8)
+	outstruct.CreatedAt = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+	outstruct.IsListed = *abi.ConvertType(out[8], new(bool)).(*bool)
+	outstruct.Price = *abi.ConvertType(out[9], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Assets is a free data retrieval call binding the contract method 0xcf35bdd0.
+//
+// Solidity: function assets(uint256 ) view returns(uint256 assetId, address owner, address brand, string name, string serialNumber, string metadataURI, uint8 status, uint256 createdAt, bool isListed, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Session) Assets(arg0 *big.Int) (struct {
+	AssetId      *big.Int
+	Owner        common.Address
+	Brand        common.Address
+	Name         string
+	SerialNumber string
+	MetadataURI  string
+	Status       uint8
+	CreatedAt    *big.Int
+	IsListed     bool
+	Price        *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Assets(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// Assets is a free data retrieval call binding the contract method 0xcf35bdd0.
+//
+// Solidity: function assets(uint256 ) view returns(uint256 assetId, address owner, address brand, string name, string serialNumber, string metadataURI, uint8 status, uint256 createdAt, bool isListed, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) Assets(arg0 *big.Int) (struct {
+	AssetId      *big.Int
+	Owner        common.Address
+	Brand        common.Address
+	Name         string
+	SerialNumber string
+	MetadataURI  string
+	Status       uint8
+	CreatedAt    *big.Int
+	IsListed     bool
+	Price        *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Assets(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// BrandList is a free data retrieval call binding the contract method 0x460f2c01.
+//
+// Solidity: function brandList(uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) BrandList(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "brandList", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BrandList is a free data retrieval call binding the contract method 0x460f2c01.
+//
+// Solidity: function brandList(uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3Session) BrandList(arg0 *big.Int) (common.Address, error) {
+	return _AssetRegistryV3.Contract.BrandList(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// BrandList is a free data retrieval call binding the contract method 0x460f2c01.
+//
+// Solidity: function brandList(uint256 ) view returns(address)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) BrandList(arg0 *big.Int) (common.Address, error) {
+	return _AssetRegistryV3.Contract.BrandList(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// Brands is a free data retrieval call binding the contract method 0x5b7fd9b5.
+//
+// Solidity: function brands(address ) view returns(address brandAddress, string brandName, bool isAuthorized, uint256 registeredAt)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) Brands(opts *bind.CallOpts, arg0 common.Address) (struct {
+	BrandAddress common.Address
+	BrandName    string
+	IsAuthorized bool
+	RegisteredAt *big.Int
+}, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "brands", arg0)
+
+	outstruct := new(struct {
+		BrandAddress common.Address
+		BrandName    string
+		IsAuthorized bool
+		RegisteredAt *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.BrandAddress = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.BrandName = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.IsAuthorized = *abi.ConvertType(out[2], new(bool)).(*bool)
+	outstruct.RegisteredAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Brands is a free data retrieval call binding the contract method 0x5b7fd9b5.
+//
+// Solidity: function brands(address ) view returns(address brandAddress, string brandName, bool isAuthorized, uint256 registeredAt)
+func (_AssetRegistryV3 *AssetRegistryV3Session) Brands(arg0 common.Address) (struct {
+	BrandAddress common.Address
+	BrandName    string
+	IsAuthorized bool
+	RegisteredAt *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Brands(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// Brands is a free data retrieval call binding the contract method 0x5b7fd9b5.
+//
+// Solidity: function brands(address ) view returns(address brandAddress, string brandName, bool isAuthorized, uint256 registeredAt)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) Brands(arg0 common.Address) (struct {
+	BrandAddress common.Address
+	BrandName    string
+	IsAuthorized bool
+	RegisteredAt *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Brands(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// GetAllBrands is a free data retrieval call binding the contract method 0x60e8ddb0.
+//
+// Solidity: function getAllBrands() view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetAllBrands(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getAllBrands")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetAllBrands is a free data retrieval call binding the contract method 0x60e8ddb0.
+//
+// Solidity: function getAllBrands() view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetAllBrands() ([]common.Address, error) {
+	return _AssetRegistryV3.Contract.GetAllBrands(&_AssetRegistryV3.CallOpts)
+}
+
+// GetAllBrands is a free data retrieval call binding the contract method 0x60e8ddb0.
+//
+// Solidity: function getAllBrands() view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetAllBrands() ([]common.Address, error) {
+	return _AssetRegistryV3.Contract.GetAllBrands(&_AssetRegistryV3.CallOpts)
+}
+
+// GetAssetBySerialNumber is a free data retrieval call binding the contract method 0x5f4bc456.
+//
+// Solidity: function getAssetBySerialNumber(string serialNumber) view returns((uint256,address,address,string,string,string,uint8,uint256,bool,uint256))
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetAssetBySerialNumber(opts *bind.CallOpts, serialNumber string) (AssetRegistryV3Asset, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getAssetBySerialNumber", serialNumber)
+
+	if err != nil {
+		return *new(AssetRegistryV3Asset), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(AssetRegistryV3Asset)).(*AssetRegistryV3Asset)
+
+	return out0, err
+
+}
+
+// GetAssetBySerialNumber is a free data retrieval call binding the contract method 0x5f4bc456.
+//
+// Solidity: function getAssetBySerialNumber(string serialNumber) view returns((uint256,address,address,string,string,string,uint8,uint256,bool,uint256))
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetAssetBySerialNumber(serialNumber string) (AssetRegistryV3Asset, error) {
+	return _AssetRegistryV3.Contract.GetAssetBySerialNumber(&_AssetRegistryV3.CallOpts, serialNumber)
+}
+
+// GetAssetBySerialNumber is a free data retrieval call binding the contract method 0x5f4bc456.
+//
+// Solidity: function getAssetBySerialNumber(string serialNumber) view returns((uint256,address,address,string,string,string,uint8,uint256,bool,uint256))
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetAssetBySerialNumber(serialNumber string) (AssetRegistryV3Asset, error) {
+	return _AssetRegistryV3.Contract.GetAssetBySerialNumber(&_AssetRegistryV3.CallOpts, serialNumber)
+}
+
+// GetAssetOrderHistory is a free data retrieval call binding the contract method 0x4deac015.
+//
+// Solidity: function getAssetOrderHistory(uint256 assetId) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetAssetOrderHistory(opts *bind.CallOpts, assetId *big.Int) ([]*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getAssetOrderHistory", assetId)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetAssetOrderHistory is a free data retrieval call binding the contract method 0x4deac015.
+//
+// Solidity: function getAssetOrderHistory(uint256 assetId) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetAssetOrderHistory(assetId *big.Int) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetAssetOrderHistory(&_AssetRegistryV3.CallOpts, assetId)
+}
+
+// GetAssetOrderHistory is a free data retrieval call binding the contract method 0x4deac015.
+//
+// Solidity: function getAssetOrderHistory(uint256 assetId) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetAssetOrderHistory(assetId *big.Int) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetAssetOrderHistory(&_AssetRegistryV3.CallOpts, assetId)
+}
+
+// GetAssetOwnerHistory is a free data retrieval call binding the contract method 0x562d7099.
+//
+// Solidity: function getAssetOwnerHistory(uint256 assetId) view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetAssetOwnerHistory(opts *bind.CallOpts, assetId *big.Int) ([]common.Address, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getAssetOwnerHistory", assetId)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetAssetOwnerHistory is a free data retrieval call binding the contract method 0x562d7099.
+//
+// Solidity: function getAssetOwnerHistory(uint256 assetId) view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetAssetOwnerHistory(assetId *big.Int) ([]common.Address, error) {
+	return _AssetRegistryV3.Contract.GetAssetOwnerHistory(&_AssetRegistryV3.CallOpts, assetId)
+}
+
+// GetAssetOwnerHistory is a free data retrieval call binding the contract method 0x562d7099.
+//
+// Solidity: function getAssetOwnerHistory(uint256 assetId) view returns(address[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetAssetOwnerHistory(assetId *big.Int) ([]common.Address, error) {
+	return _AssetRegistryV3.Contract.GetAssetOwnerHistory(&_AssetRegistryV3.CallOpts, assetId)
+}
+
+// GetAssetsByOwner is a free data retrieval call binding the contract method 0x276f0934.
+//
+// Solidity: function getAssetsByOwner(address owner) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetAssetsByOwner(opts *bind.CallOpts, owner common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getAssetsByOwner", owner)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetAssetsByOwner is a free data retrieval call binding the contract method 0x276f0934.
+//
+// Solidity: function getAssetsByOwner(address owner) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetAssetsByOwner(owner common.Address) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetAssetsByOwner(&_AssetRegistryV3.CallOpts, owner)
+}
+
+// GetAssetsByOwner is a free data retrieval call binding the contract method 0x276f0934.
+//
+// Solidity: function getAssetsByOwner(address owner) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetAssetsByOwner(owner common.Address) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetAssetsByOwner(&_AssetRegistryV3.CallOpts, owner)
+}
+
+// GetListedAssets is a free data retrieval call binding the contract method 0xf7e19eff.
+//
+// Solidity: function getListedAssets() view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetListedAssets(opts *bind.CallOpts) ([]*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getListedAssets")
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetListedAssets is a free data retrieval call binding the contract method 0xf7e19eff.
+//
+// Solidity: function getListedAssets() view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetListedAssets() ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetListedAssets(&_AssetRegistryV3.CallOpts)
+}
+
+// GetListedAssets is a free data retrieval call binding the contract method 0xf7e19eff.
+//
+// Solidity: function getListedAssets() view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetListedAssets() ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetListedAssets(&_AssetRegistryV3.CallOpts)
+}
+
+// GetOrdersByUser is a free data retrieval call binding the contract method 0x18f1c709.
+//
+// Solidity: function getOrdersByUser(address user) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Caller) GetOrdersByUser(opts *bind.CallOpts, user common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "getOrdersByUser", user)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetOrdersByUser is a free data retrieval call binding the contract method 0x18f1c709.
+//
+// Solidity: function getOrdersByUser(address user) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3Session) GetOrdersByUser(user common.Address) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetOrdersByUser(&_AssetRegistryV3.CallOpts, user)
+}
+
+// GetOrdersByUser is a free data retrieval call binding the contract method 0x18f1c709.
+//
+// Solidity: function getOrdersByUser(address user) view returns(uint256[])
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) GetOrdersByUser(user common.Address) ([]*big.Int, error) {
+	return _AssetRegistryV3.Contract.GetOrdersByUser(&_AssetRegistryV3.CallOpts, user)
+}
+
+// OrderCounter is a free data retrieval call binding the contract method 0xb789bf52.
+//
+// Solidity: function orderCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) OrderCounter(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "orderCounter")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// OrderCounter is a free data retrieval call binding the contract method 0xb789bf52.
+//
+// Solidity: function orderCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) OrderCounter() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.OrderCounter(&_AssetRegistryV3.CallOpts)
+}
+
+// OrderCounter is a free data retrieval call binding the contract method 0xb789bf52.
+//
+// Solidity: function orderCounter() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) OrderCounter() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.OrderCounter(&_AssetRegistryV3.CallOpts)
+}
+
+// Orders is a free data retrieval call binding the contract method 0xa85c38ef.
+//
+// Solidity: function orders(uint256 ) view returns(uint256 orderId, uint256 assetId, address seller, address buyer, uint256 price, uint8 status, uint256 createdAt, uint256 paidAt, uint256 shippedAt, uint256 deliveredAt, uint256 completedAt, bool canRefund, uint256 refundDeadline)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) Orders(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	OrderId        *big.Int
+	AssetId        *big.Int
+	Seller         common.Address
+	Buyer          common.Address
+	Price          *big.Int
+	Status         uint8
+	CreatedAt      *big.Int
+	PaidAt         *big.Int
+	ShippedAt      *big.Int
+	DeliveredAt    *big.Int
+	CompletedAt    *big.Int
+	CanRefund      bool
+	RefundDeadline *big.Int
+}, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "orders", arg0)
+
+	outstruct := new(struct {
+		OrderId        *big.Int
+		AssetId        *big.Int
+		Seller         common.Address
+		Buyer          common.Address
+		Price          *big.Int
+		Status         uint8
+		CreatedAt      *big.Int
+		PaidAt         *big.Int
+		ShippedAt      *big.Int
+		DeliveredAt    *big.Int
+		CompletedAt    *big.Int
+		CanRefund      bool
+		RefundDeadline *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.OrderId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.AssetId = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Seller = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.Buyer = *abi.ConvertType(out[3], new(common.Address)).(*common.Address)
+	outstruct.Price = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Status = *abi.ConvertType(out[5], new(uint8)).(*uint8)
+	outstruct.CreatedAt = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.PaidAt = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+	outstruct.ShippedAt = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+	outstruct.DeliveredAt = *abi.ConvertType(out[9], new(*big.Int)).(**big.Int)
+	outstruct.CompletedAt = *abi.ConvertType(out[10], new(*big.Int)).(**big.Int)
+	outstruct.CanRefund = *abi.ConvertType(out[11], new(bool)).(*bool)
+	outstruct.RefundDeadline = *abi.ConvertType(out[12], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Orders is a free data retrieval call binding the contract method 0xa85c38ef.
+//
+// Solidity: function orders(uint256 ) view returns(uint256 orderId, uint256 assetId, address seller, address buyer, uint256 price, uint8 status, uint256 createdAt, uint256 paidAt, uint256 shippedAt, uint256 deliveredAt, uint256 completedAt, bool canRefund, uint256 refundDeadline)
+func (_AssetRegistryV3 *AssetRegistryV3Session) Orders(arg0 *big.Int) (struct {
+	OrderId        *big.Int
+	AssetId        *big.Int
+	Seller         common.Address
+	Buyer          common.Address
+	Price          *big.Int
+	Status         uint8
+	CreatedAt      *big.Int
+	PaidAt         *big.Int
+	ShippedAt      *big.Int
+	DeliveredAt    *big.Int
+	CompletedAt    *big.Int
+	CanRefund      bool
+	RefundDeadline *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Orders(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// Orders is a free data retrieval call binding the contract method 0xa85c38ef.
+//
+// Solidity: function orders(uint256 ) view returns(uint256 orderId, uint256 assetId, address seller, address buyer, uint256 price, uint8 status, uint256 createdAt, uint256 paidAt, uint256 shippedAt, uint256 deliveredAt, uint256 completedAt, bool canRefund, uint256 refundDeadline)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) Orders(arg0 *big.Int) (struct {
+	OrderId        *big.Int
+	AssetId        *big.Int
+	Seller         common.Address
+	Buyer          common.Address
+	Price          *big.Int
+	Status         uint8
+	CreatedAt      *big.Int
+	PaidAt         *big.Int
+	ShippedAt      *big.Int
+	DeliveredAt    *big.Int
+	CompletedAt    *big.Int
+	CanRefund      bool
+	RefundDeadline *big.Int
+}, error) {
+	return _AssetRegistryV3.Contract.Orders(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) PlatformFeePercent(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "platformFeePercent")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) PlatformFeePercent() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.PlatformFeePercent(&_AssetRegistryV3.CallOpts)
+}
+
+// PlatformFeePercent is a free data retrieval call binding the contract method 0x8c639a85.
+//
+// Solidity: function platformFeePercent() view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) PlatformFeePercent() (*big.Int, error) {
+	return _AssetRegistryV3.Contract.PlatformFeePercent(&_AssetRegistryV3.CallOpts)
+}
+
+// SerialNumberExists is a free data retrieval call binding the contract method 0x598f8371.
+//
+// Solidity: function serialNumberExists(string ) view returns(bool)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) SerialNumberExists(opts *bind.CallOpts, arg0 string) (bool, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "serialNumberExists", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SerialNumberExists is a free data retrieval call binding the contract method 0x598f8371.
+//
+// Solidity: function serialNumberExists(string ) view returns(bool)
+func (_AssetRegistryV3 *AssetRegistryV3Session) SerialNumberExists(arg0 string) (bool, error) {
+	return _AssetRegistryV3.Contract.SerialNumberExists(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// SerialNumberExists is a free data retrieval call binding the contract method 0x598f8371.
+//
+// Solidity: function serialNumberExists(string ) view returns(bool)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) SerialNumberExists(arg0 string) (bool, error) {
+	return _AssetRegistryV3.Contract.SerialNumberExists(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// SerialNumberToAssetId is a free data retrieval call binding the contract method 0x14f9d76f.
+//
+// Solidity: function serialNumberToAssetId(string ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Caller) SerialNumberToAssetId(opts *bind.CallOpts, arg0 string) (*big.Int, error) {
+	var out []interface{}
+	err := _AssetRegistryV3.contract.Call(opts, &out, "serialNumberToAssetId", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SerialNumberToAssetId is a free data retrieval call binding the contract method 0x14f9d76f.
+//
+// Solidity: function serialNumberToAssetId(string ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) SerialNumberToAssetId(arg0 string) (*big.Int, error) {
+	return _AssetRegistryV3.Contract.SerialNumberToAssetId(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// SerialNumberToAssetId is a free data retrieval call binding the contract method 0x14f9d76f.
+//
+// Solidity: function serialNumberToAssetId(string ) view returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3CallerSession) SerialNumberToAssetId(arg0 string) (*big.Int, error) {
+	return _AssetRegistryV3.Contract.SerialNumberToAssetId(&_AssetRegistryV3.CallOpts, arg0)
+}
+
+// AuthorizeBrand is a paid mutator transaction binding the contract method 0x8898f2d0.
+//
+// Solidity: function authorizeBrand(address brandAddress, bool authorized) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) AuthorizeBrand(opts *bind.TransactOpts, brandAddress common.Address, authorized bool) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "authorizeBrand", brandAddress, authorized)
+}
+
+// AuthorizeBrand is a paid mutator transaction binding the contract method 0x8898f2d0.
+//
+// Solidity: function authorizeBrand(address brandAddress, bool authorized) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) AuthorizeBrand(brandAddress common.Address, authorized bool) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.AuthorizeBrand(&_AssetRegistryV3.TransactOpts, brandAddress, authorized)
+}
+
+// AuthorizeBrand is a paid mutator transaction binding the contract method 0x8898f2d0.
+//
+// Solidity: function authorizeBrand(address brandAddress, bool authorized) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) AuthorizeBrand(brandAddress common.Address, authorized bool) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.AuthorizeBrand(&_AssetRegistryV3.TransactOpts, brandAddress, authorized)
+}
+
+// CancelOrder is a paid mutator transaction binding the contract method 0x514fcac7.
+//
+// Solidity: function cancelOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) CancelOrder(opts *bind.TransactOpts, orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "cancelOrder", orderId)
+}
+
+// CancelOrder is a paid mutator transaction binding the contract method 0x514fcac7.
+//
+// Solidity: function cancelOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) CancelOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CancelOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// CancelOrder is a paid mutator transaction binding the contract method 0x514fcac7.
+//
+// Solidity: function cancelOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) CancelOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CancelOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// CompleteOrder is a paid mutator transaction binding the contract method 0xb6adaaff.
+//
+// Solidity: function completeOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) CompleteOrder(opts *bind.TransactOpts, orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "completeOrder", orderId)
+}
+
+// CompleteOrder is a paid mutator transaction binding the contract method 0xb6adaaff.
+//
+// Solidity: function completeOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) CompleteOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CompleteOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// CompleteOrder is a paid mutator transaction binding the contract method 0xb6adaaff.
+//
+// Solidity: function completeOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) CompleteOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CompleteOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// ConfirmDelivery is a paid mutator transaction binding the contract method 0xfd84cb97.
+//
+// Solidity: function confirmDelivery(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) ConfirmDelivery(opts *bind.TransactOpts, orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "confirmDelivery", orderId)
+}
+
+// ConfirmDelivery is a paid mutator transaction binding the contract method 0xfd84cb97.
+//
+// Solidity: function confirmDelivery(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) ConfirmDelivery(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ConfirmDelivery(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// ConfirmDelivery is a paid mutator transaction binding the contract method 0xfd84cb97.
+//
+// Solidity: function confirmDelivery(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) ConfirmDelivery(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ConfirmDelivery(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// CreateOrder is a paid mutator transaction binding the contract method 0xf8ad8cd7.
+//
+// Solidity: function createOrder(uint256 assetId) payable returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) CreateOrder(opts *bind.TransactOpts, assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "createOrder", assetId)
+}
+
+// CreateOrder is a paid mutator transaction binding the contract method 0xf8ad8cd7.
+//
+// Solidity: function createOrder(uint256 assetId) payable returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) CreateOrder(assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CreateOrder(&_AssetRegistryV3.TransactOpts, assetId)
+}
+
+// CreateOrder is a paid mutator transaction binding the contract method 0xf8ad8cd7.
+//
+// Solidity: function createOrder(uint256 assetId) payable returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) CreateOrder(assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.CreateOrder(&_AssetRegistryV3.TransactOpts, assetId)
+}
+
+// ListAsset is a paid mutator transaction binding the contract method 0x7bad9a3e.
+//
+// Solidity: function listAsset(uint256 assetId, uint256 price) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) ListAsset(opts *bind.TransactOpts, assetId *big.Int, price *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "listAsset", assetId, price)
+}
+
+// ListAsset is a paid mutator transaction binding the contract method 0x7bad9a3e.
+//
+// Solidity: function listAsset(uint256 assetId, uint256 price) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) ListAsset(assetId *big.Int, price *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ListAsset(&_AssetRegistryV3.TransactOpts, assetId, price)
+}
+
+// ListAsset is a paid mutator transaction binding the contract method 0x7bad9a3e.
+//
+// Solidity: function listAsset(uint256 assetId, uint256 price) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) ListAsset(assetId *big.Int, price *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ListAsset(&_AssetRegistryV3.TransactOpts, assetId, price)
+}
+
+// RegisterAsset is a paid mutator transaction binding the contract method 0x881d4c6e.
+//
+// Solidity: function registerAsset(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) RegisterAsset(opts *bind.TransactOpts, name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "registerAsset", name, serialNumber, metadataURI)
+}
+
+// RegisterAsset is a paid mutator transaction binding the contract method 0x881d4c6e.
+//
+// Solidity: function registerAsset(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) RegisterAsset(name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterAsset(&_AssetRegistryV3.TransactOpts, name, serialNumber, metadataURI)
+}
+
+// RegisterAsset is a paid mutator transaction binding the contract method 0x881d4c6e.
+//
+// Solidity: function registerAsset(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) RegisterAsset(name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterAsset(&_AssetRegistryV3.TransactOpts, name, serialNumber, metadataURI)
+}
+
+// RegisterAssetByUser is a paid mutator transaction binding the contract method 0xa197143d.
+//
+// Solidity: function registerAssetByUser(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) RegisterAssetByUser(opts *bind.TransactOpts, name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "registerAssetByUser", name, serialNumber, metadataURI)
+}
+
+// RegisterAssetByUser is a paid mutator transaction binding the contract method 0xa197143d.
+//
+// Solidity: function registerAssetByUser(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3Session) RegisterAssetByUser(name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterAssetByUser(&_AssetRegistryV3.TransactOpts, name, serialNumber, metadataURI)
+}
+
+// RegisterAssetByUser is a paid mutator transaction binding the contract method 0xa197143d.
+//
+// Solidity: function registerAssetByUser(string name, string serialNumber, string metadataURI) returns(uint256)
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) RegisterAssetByUser(name string, serialNumber string, metadataURI string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterAssetByUser(&_AssetRegistryV3.TransactOpts, name, serialNumber, metadataURI)
+}
+
+// RegisterBrand is a paid mutator transaction binding the contract method 0x9f9aab6f.
+//
+// Solidity: function registerBrand(string brandName) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) RegisterBrand(opts *bind.TransactOpts, brandName string) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "registerBrand", brandName)
+}
+
+// RegisterBrand is a paid mutator transaction binding the contract method 0x9f9aab6f.
+//
+// Solidity: function registerBrand(string brandName) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) RegisterBrand(brandName string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterBrand(&_AssetRegistryV3.TransactOpts, brandName)
+}
+
+// RegisterBrand is a paid mutator transaction binding the contract method 0x9f9aab6f.
+//
+// Solidity: function registerBrand(string brandName) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) RegisterBrand(brandName string) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RegisterBrand(&_AssetRegistryV3.TransactOpts, brandName)
+}
+
+// RequestRefund is a paid mutator transaction binding the contract method 0xa4b2409e.
+//
+// Solidity: function requestRefund(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) RequestRefund(opts *bind.TransactOpts, orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "requestRefund", orderId)
+}
+
+// RequestRefund is a paid mutator transaction binding the contract method 0xa4b2409e.
+//
+// Solidity: function requestRefund(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) RequestRefund(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RequestRefund(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// RequestRefund is a paid mutator transaction binding the contract method 0xa4b2409e.
+//
+// Solidity: function requestRefund(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) RequestRefund(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.RequestRefund(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// SetPlatformFee is a paid mutator transaction binding the contract method 0x12e8e2c3.
+//
+// Solidity: function setPlatformFee(uint256 feePercent) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) SetPlatformFee(opts *bind.TransactOpts, feePercent *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "setPlatformFee", feePercent)
+}
+
+// SetPlatformFee is a paid mutator transaction binding the contract method 0x12e8e2c3.
+//
+// Solidity: function setPlatformFee(uint256 feePercent) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) SetPlatformFee(feePercent *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.SetPlatformFee(&_AssetRegistryV3.TransactOpts, feePercent)
+}
+
+// SetPlatformFee is a paid mutator transaction binding the contract method 0x12e8e2c3.
+//
+// Solidity: function setPlatformFee(uint256 feePercent) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) SetPlatformFee(feePercent *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.SetPlatformFee(&_AssetRegistryV3.TransactOpts, feePercent)
+}
+
+// ShipOrder is a paid mutator transaction binding the contract method 0x194c302c.
+//
+// Solidity: function shipOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) ShipOrder(opts *bind.TransactOpts, orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "shipOrder", orderId)
+}
+
+// ShipOrder is a paid mutator transaction binding the contract method 0x194c302c.
+//
+// Solidity: function shipOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) ShipOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ShipOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// ShipOrder is a paid mutator transaction binding the contract method 0x194c302c.
+//
+// Solidity: function shipOrder(uint256 orderId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) ShipOrder(orderId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.ShipOrder(&_AssetRegistryV3.TransactOpts, orderId)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) TransferAdmin(opts *bind.TransactOpts, newAdmin common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "transferAdmin", newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) TransferAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.TransferAdmin(&_AssetRegistryV3.TransactOpts, newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) TransferAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.TransferAdmin(&_AssetRegistryV3.TransactOpts, newAdmin)
+}
+
+// TransferAsset is a paid mutator transaction binding the contract method 0xfa62ee8d.
+//
+// Solidity: function transferAsset(uint256 assetId, address newOwner) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) TransferAsset(opts *bind.TransactOpts, assetId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "transferAsset", assetId, newOwner)
+}
+
+// TransferAsset is a paid mutator transaction binding the contract method 0xfa62ee8d.
+//
+// Solidity: function transferAsset(uint256 assetId, address newOwner) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) TransferAsset(assetId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.TransferAsset(&_AssetRegistryV3.TransactOpts, assetId, newOwner)
+}
+
+// TransferAsset is a paid mutator transaction binding the contract method 0xfa62ee8d.
+//
+// Solidity: function transferAsset(uint256 assetId, address newOwner) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) TransferAsset(assetId *big.Int, newOwner common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.TransferAsset(&_AssetRegistryV3.TransactOpts, assetId, newOwner)
+}
+
+// UnlistAsset is a paid mutator transaction binding the contract method 0xdda547f4.
+//
+// Solidity: function unlistAsset(uint256 assetId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) UnlistAsset(opts *bind.TransactOpts, assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "unlistAsset", assetId)
+}
+
+// UnlistAsset is a paid mutator transaction binding the contract method 0xdda547f4.
+//
+// Solidity: function unlistAsset(uint256 assetId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) UnlistAsset(assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.UnlistAsset(&_AssetRegistryV3.TransactOpts, assetId)
+}
+
+// UnlistAsset is a paid mutator transaction binding the contract method 0xdda547f4.
+//
+// Solidity: function unlistAsset(uint256 assetId) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) UnlistAsset(assetId *big.Int) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.UnlistAsset(&_AssetRegistryV3.TransactOpts, assetId)
+}
+
+// VerifyAsset is a paid mutator transaction binding the contract method 0x31938f92.
+//
+// Solidity: function verifyAsset(uint256 assetId, uint8 newStatus, address brandAddress) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) VerifyAsset(opts *bind.TransactOpts, assetId *big.Int, newStatus uint8, brandAddress common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "verifyAsset", assetId, newStatus, brandAddress)
+}
+
+// VerifyAsset is a paid mutator transaction binding the contract method 0x31938f92.
+//
+// Solidity: function verifyAsset(uint256 assetId, uint8 newStatus, address brandAddress) returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) VerifyAsset(assetId *big.Int, newStatus uint8, brandAddress common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.VerifyAsset(&_AssetRegistryV3.TransactOpts, assetId, newStatus, brandAddress)
+}
+
+// VerifyAsset is a paid mutator transaction binding the contract method 0x31938f92.
+//
+// Solidity: function verifyAsset(uint256 assetId, uint8 newStatus, address brandAddress) returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) VerifyAsset(assetId *big.Int, newStatus uint8, brandAddress common.Address) (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.VerifyAsset(&_AssetRegistryV3.TransactOpts, assetId, newStatus, brandAddress)
+}
+
+// WithdrawPlatformFees is a paid mutator transaction binding the contract method 0xd0b7830b.
+//
+// Solidity: function withdrawPlatformFees() returns()
+func (_AssetRegistryV3 *AssetRegistryV3Transactor) WithdrawPlatformFees(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AssetRegistryV3.contract.Transact(opts, "withdrawPlatformFees")
+}
+
+// WithdrawPlatformFees is a paid mutator transaction binding the contract method 0xd0b7830b.
+//
+// Solidity: function withdrawPlatformFees() returns()
+func (_AssetRegistryV3 *AssetRegistryV3Session) WithdrawPlatformFees() (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.WithdrawPlatformFees(&_AssetRegistryV3.TransactOpts)
+}
+
+// WithdrawPlatformFees is a paid mutator transaction binding the contract method 0xd0b7830b.
+//
+// Solidity: function withdrawPlatformFees() returns()
+func (_AssetRegistryV3 *AssetRegistryV3TransactorSession) WithdrawPlatformFees() (*types.Transaction, error) {
+	return _AssetRegistryV3.Contract.WithdrawPlatformFees(&_AssetRegistryV3.TransactOpts)
+}
+
+// AssetRegistryV3AssetListedIterator is returned from FilterAssetListed and is used to iterate over the raw logs and unpacked data for AssetListed events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetListedIterator struct {
+	Event *AssetRegistryV3AssetListed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3AssetListedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3AssetListed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3AssetListed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3AssetListedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3AssetListedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3AssetListed represents a AssetListed event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetListed struct {
+	AssetId *big.Int
+	Seller  common.Address
+	Price   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterAssetListed is a free log retrieval operation binding the contract event 0x476606c547e15093eee9f27111d27bfb5d4a751983dec28c9100eb7bb39b8db1.
+//
+// Solidity: event AssetListed(uint256 indexed assetId, address indexed seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterAssetListed(opts *bind.FilterOpts, assetId []*big.Int, seller []common.Address) (*AssetRegistryV3AssetListedIterator, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "AssetListed", assetIdRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3AssetListedIterator{contract: _AssetRegistryV3.contract, event: "AssetListed", logs: logs, sub: sub}, nil
+}
+
+// WatchAssetListed is a free log subscription operation binding the contract event 0x476606c547e15093eee9f27111d27bfb5d4a751983dec28c9100eb7bb39b8db1.
+//
+// Solidity: event AssetListed(uint256 indexed assetId, address indexed seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchAssetListed(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3AssetListed, assetId []*big.Int, seller []common.Address) (event.Subscription, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "AssetListed", assetIdRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3AssetListed)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetListed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAssetListed is a log parse operation binding the contract event 0x476606c547e15093eee9f27111d27bfb5d4a751983dec28c9100eb7bb39b8db1.
+//
+// Solidity: event AssetListed(uint256 indexed assetId, address indexed seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseAssetListed(log types.Log) (*AssetRegistryV3AssetListed, error) {
+	event := new(AssetRegistryV3AssetListed)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetListed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3AssetRegisteredIterator is returned from FilterAssetRegistered and is used to iterate over the raw logs and unpacked data for AssetRegistered events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetRegisteredIterator struct {
+	Event *AssetRegistryV3AssetRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3AssetRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3AssetRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3AssetRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3AssetRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3AssetRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3AssetRegistered represents a AssetRegistered event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetRegistered struct {
+	AssetId      *big.Int
+	Owner        common.Address
+	Brand        common.Address
+	Name         string
+	SerialNumber string
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterAssetRegistered is a free log retrieval operation binding the contract event 0x4fa94b6cfca5aa5427aaea75aa09ff4683c007dbf68e18907254a3a25a1dbf0d.
+//
+// Solidity: event AssetRegistered(uint256 indexed assetId, address indexed owner, address indexed brand, string name, string serialNumber)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterAssetRegistered(opts *bind.FilterOpts, assetId []*big.Int, owner []common.Address, brand []common.Address) (*AssetRegistryV3AssetRegisteredIterator, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var brandRule []interface{}
+	for _, brandItem := range brand {
+		brandRule = append(brandRule, brandItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "AssetRegistered", assetIdRule, ownerRule, brandRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3AssetRegisteredIterator{contract: _AssetRegistryV3.contract, event: "AssetRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchAssetRegistered is a free log subscription operation binding the contract event 0x4fa94b6cfca5aa5427aaea75aa09ff4683c007dbf68e18907254a3a25a1dbf0d.
+//
+// Solidity: event AssetRegistered(uint256 indexed assetId, address indexed owner, address indexed brand, string name, string serialNumber)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchAssetRegistered(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3AssetRegistered, assetId []*big.Int, owner []common.Address, brand []common.Address) (event.Subscription, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var brandRule []interface{}
+	for _, brandItem := range brand {
+		brandRule = append(brandRule, brandItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "AssetRegistered", assetIdRule, ownerRule, brandRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3AssetRegistered)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAssetRegistered is a log parse operation binding the contract event 0x4fa94b6cfca5aa5427aaea75aa09ff4683c007dbf68e18907254a3a25a1dbf0d.
+//
+// Solidity: event AssetRegistered(uint256 indexed assetId, address indexed owner, address indexed brand, string name, string serialNumber)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseAssetRegistered(log types.Log) (*AssetRegistryV3AssetRegistered, error) {
+	event := new(AssetRegistryV3AssetRegistered)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3AssetTransferredIterator is returned from FilterAssetTransferred and is used to iterate over the raw logs and unpacked data for AssetTransferred events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetTransferredIterator struct {
+	Event *AssetRegistryV3AssetTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3AssetTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3AssetTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3AssetTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3AssetTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3AssetTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3AssetTransferred represents a AssetTransferred event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetTransferred struct {
+	AssetId *big.Int
+	From    common.Address
+	To      common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterAssetTransferred is a free log retrieval operation binding the contract event 0xa993eb3a10693085bc7afc1de0202310fbd5992b9e51edd263b198f62f20cdae.
+//
+// Solidity: event AssetTransferred(uint256 indexed assetId, address indexed from, address indexed to)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterAssetTransferred(opts *bind.FilterOpts, assetId []*big.Int, from []common.Address, to []common.Address) (*AssetRegistryV3AssetTransferredIterator, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "AssetTransferred", assetIdRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3AssetTransferredIterator{contract: _AssetRegistryV3.contract, event: "AssetTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchAssetTransferred is a free log subscription operation binding the contract event 0xa993eb3a10693085bc7afc1de0202310fbd5992b9e51edd263b198f62f20cdae.
+//
+// Solidity: event AssetTransferred(uint256 indexed assetId, address indexed from, address indexed to)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchAssetTransferred(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3AssetTransferred, assetId []*big.Int, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "AssetTransferred", assetIdRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3AssetTransferred)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAssetTransferred is a log parse operation binding the contract event 0xa993eb3a10693085bc7afc1de0202310fbd5992b9e51edd263b198f62f20cdae.
+//
+// Solidity: event AssetTransferred(uint256 indexed assetId, address indexed from, address indexed to)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseAssetTransferred(log types.Log) (*AssetRegistryV3AssetTransferred, error) {
+	event := new(AssetRegistryV3AssetTransferred)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3AssetUnlistedIterator is returned from FilterAssetUnlisted and is used to iterate over the raw logs and unpacked data for AssetUnlisted events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetUnlistedIterator struct {
+	Event *AssetRegistryV3AssetUnlisted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3AssetUnlistedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3AssetUnlisted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3AssetUnlisted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3AssetUnlistedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3AssetUnlistedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3AssetUnlisted represents a AssetUnlisted event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetUnlisted struct {
+	AssetId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterAssetUnlisted is a free log retrieval operation binding the contract event 0x64ccd31338f15e54e45fc8900243f6c5144beba6e0b0d7c84bcd5990bb5a8657.
+//
+// Solidity: event AssetUnlisted(uint256 indexed assetId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterAssetUnlisted(opts *bind.FilterOpts, assetId []*big.Int) (*AssetRegistryV3AssetUnlistedIterator, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "AssetUnlisted", assetIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3AssetUnlistedIterator{contract: _AssetRegistryV3.contract, event: "AssetUnlisted", logs: logs, sub: sub}, nil
+}
+
+// WatchAssetUnlisted is a free log subscription operation binding the contract event 0x64ccd31338f15e54e45fc8900243f6c5144beba6e0b0d7c84bcd5990bb5a8657.
+//
+// Solidity: event AssetUnlisted(uint256 indexed assetId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchAssetUnlisted(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3AssetUnlisted, assetId []*big.Int) (event.Subscription, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "AssetUnlisted", assetIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3AssetUnlisted)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetUnlisted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAssetUnlisted is a log parse operation binding the contract event 0x64ccd31338f15e54e45fc8900243f6c5144beba6e0b0d7c84bcd5990bb5a8657.
+//
+// Solidity: event AssetUnlisted(uint256 indexed assetId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseAssetUnlisted(log types.Log) (*AssetRegistryV3AssetUnlisted, error) {
+	event := new(AssetRegistryV3AssetUnlisted)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetUnlisted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3AssetVerifiedIterator is returned from FilterAssetVerified and is used to iterate over the raw logs and unpacked data for AssetVerified events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetVerifiedIterator struct {
+	Event *AssetRegistryV3AssetVerified // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3AssetVerifiedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3AssetVerified)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3AssetVerified)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3AssetVerifiedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3AssetVerifiedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3AssetVerified represents a AssetVerified event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3AssetVerified struct {
+	AssetId  *big.Int
+	Status   uint8
+	Verifier common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAssetVerified is a free log retrieval operation binding the contract event 0x4a3bc4d2b3855ea1336edd0d879a039912f0a30a10e98a6e63567a9271c1b2db.
+//
+// Solidity: event AssetVerified(uint256 indexed assetId, uint8 status, address verifier)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterAssetVerified(opts *bind.FilterOpts, assetId []*big.Int) (*AssetRegistryV3AssetVerifiedIterator, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "AssetVerified", assetIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3AssetVerifiedIterator{contract: _AssetRegistryV3.contract, event: "AssetVerified", logs: logs, sub: sub}, nil
+}
+
+// WatchAssetVerified is a free log subscription operation binding the contract event 0x4a3bc4d2b3855ea1336edd0d879a039912f0a30a10e98a6e63567a9271c1b2db.
+//
+// Solidity: event AssetVerified(uint256 indexed assetId, uint8 status, address verifier)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchAssetVerified(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3AssetVerified, assetId []*big.Int) (event.Subscription, error) {
+
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "AssetVerified", assetIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3AssetVerified)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetVerified", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAssetVerified is a log parse operation binding the contract event 0x4a3bc4d2b3855ea1336edd0d879a039912f0a30a10e98a6e63567a9271c1b2db.
+//
+// Solidity: event AssetVerified(uint256 indexed assetId, uint8 status, address verifier)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseAssetVerified(log types.Log) (*AssetRegistryV3AssetVerified, error) {
+	event := new(AssetRegistryV3AssetVerified)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "AssetVerified", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3BrandAuthorizedIterator is returned from FilterBrandAuthorized and is used to iterate over the raw logs and unpacked data for BrandAuthorized events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3BrandAuthorizedIterator struct {
+	Event *AssetRegistryV3BrandAuthorized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3BrandAuthorizedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3BrandAuthorized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3BrandAuthorized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3BrandAuthorizedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3BrandAuthorizedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3BrandAuthorized represents a BrandAuthorized event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3BrandAuthorized struct {
+	BrandAddress common.Address
+	IsAuthorized bool
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterBrandAuthorized is a free log retrieval operation binding the contract event 0x503dacb813ba6e091282b050f229d8422d74689e30ad2a524990d1b96ec5791c.
+//
+// Solidity: event BrandAuthorized(address indexed brandAddress, bool isAuthorized)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterBrandAuthorized(opts *bind.FilterOpts, brandAddress []common.Address) (*AssetRegistryV3BrandAuthorizedIterator, error) {
+
+	var brandAddressRule []interface{}
+	for _, brandAddressItem := range brandAddress {
+		brandAddressRule = append(brandAddressRule, brandAddressItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "BrandAuthorized", brandAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3BrandAuthorizedIterator{contract: _AssetRegistryV3.contract, event: "BrandAuthorized", logs: logs, sub: sub}, nil
+}
+
+// WatchBrandAuthorized is a free log subscription operation binding the contract event 0x503dacb813ba6e091282b050f229d8422d74689e30ad2a524990d1b96ec5791c.
+//
+// Solidity: event BrandAuthorized(address indexed brandAddress, bool isAuthorized)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchBrandAuthorized(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3BrandAuthorized, brandAddress []common.Address) (event.Subscription, error) {
+
+	var brandAddressRule []interface{}
+	for _, brandAddressItem := range brandAddress {
+		brandAddressRule = append(brandAddressRule, brandAddressItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "BrandAuthorized", brandAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3BrandAuthorized)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "BrandAuthorized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBrandAuthorized is a log parse operation binding the contract event 0x503dacb813ba6e091282b050f229d8422d74689e30ad2a524990d1b96ec5791c.
+//
+// Solidity: event BrandAuthorized(address indexed brandAddress, bool isAuthorized)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseBrandAuthorized(log types.Log) (*AssetRegistryV3BrandAuthorized, error) {
+	event := new(AssetRegistryV3BrandAuthorized)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "BrandAuthorized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3BrandRegisteredIterator is returned from FilterBrandRegistered and is used to iterate over the raw logs and unpacked data for BrandRegistered events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3BrandRegisteredIterator struct {
+	Event *AssetRegistryV3BrandRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3BrandRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3BrandRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3BrandRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3BrandRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3BrandRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3BrandRegistered represents a BrandRegistered event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3BrandRegistered struct {
+	BrandAddress common.Address
+	BrandName    string
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterBrandRegistered is a free log retrieval operation binding the contract event 0xd5ad14928e56441589ec28b9b1985b08b83606f54b8d18b8c4af7cd21898da81.
+//
+// Solidity: event BrandRegistered(address indexed brandAddress, string brandName)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterBrandRegistered(opts *bind.FilterOpts, brandAddress []common.Address) (*AssetRegistryV3BrandRegisteredIterator, error) {
+
+	var brandAddressRule []interface{}
+	for _, brandAddressItem := range brandAddress {
+		brandAddressRule = append(brandAddressRule, brandAddressItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "BrandRegistered", brandAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3BrandRegisteredIterator{contract: _AssetRegistryV3.contract, event: "BrandRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchBrandRegistered is a free log subscription operation binding the contract event 0xd5ad14928e56441589ec28b9b1985b08b83606f54b8d18b8c4af7cd21898da81.
+//
+// Solidity: event BrandRegistered(address indexed brandAddress, string brandName)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchBrandRegistered(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3BrandRegistered, brandAddress []common.Address) (event.Subscription, error) {
+
+	var brandAddressRule []interface{}
+	for _, brandAddressItem := range brandAddress {
+		brandAddressRule = append(brandAddressRule, brandAddressItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "BrandRegistered", brandAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3BrandRegistered)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "BrandRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBrandRegistered is a log parse operation binding the contract event 0xd5ad14928e56441589ec28b9b1985b08b83606f54b8d18b8c4af7cd21898da81.
+//
+// Solidity: event BrandRegistered(address indexed brandAddress, string brandName)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseBrandRegistered(log types.Log) (*AssetRegistryV3BrandRegistered, error) {
+	event := new(AssetRegistryV3BrandRegistered)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "BrandRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderCancelledIterator is returned from FilterOrderCancelled and is used to iterate over the raw logs and unpacked data for OrderCancelled events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCancelledIterator struct {
+	Event *AssetRegistryV3OrderCancelled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderCancelledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderCancelled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderCancelled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderCancelledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderCancelledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderCancelled represents a OrderCancelled event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCancelled struct {
+	OrderId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderCancelled is a free log retrieval operation binding the contract event 0x61b9399f2f0f32ca39ce8d7be32caed5ec22fe07a6daba3a467ed479ec606582.
+//
+// Solidity: event OrderCancelled(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderCancelled(opts *bind.FilterOpts, orderId []*big.Int) (*AssetRegistryV3OrderCancelledIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderCancelled", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderCancelledIterator{contract: _AssetRegistryV3.contract, event: "OrderCancelled", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderCancelled is a free log subscription operation binding the contract event 0x61b9399f2f0f32ca39ce8d7be32caed5ec22fe07a6daba3a467ed479ec606582.
+//
+// Solidity: event OrderCancelled(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderCancelled(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderCancelled, orderId []*big.Int) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderCancelled", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderCancelled)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCancelled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderCancelled is a log parse operation binding the contract event 0x61b9399f2f0f32ca39ce8d7be32caed5ec22fe07a6daba3a467ed479ec606582.
+//
+// Solidity: event OrderCancelled(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderCancelled(log types.Log) (*AssetRegistryV3OrderCancelled, error) {
+	event := new(AssetRegistryV3OrderCancelled)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCancelled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderCompletedIterator is returned from FilterOrderCompleted and is used to iterate over the raw logs and unpacked data for OrderCompleted events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCompletedIterator struct {
+	Event *AssetRegistryV3OrderCompleted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderCompletedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderCompleted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderCompleted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderCompletedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderCompletedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderCompleted represents a OrderCompleted event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCompleted struct {
+	OrderId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderCompleted is a free log retrieval operation binding the contract event 0x02a7d4d9472af7118644884b9a0ee443540c0027e938dd0aa35be8ecbe946c0a.
+//
+// Solidity: event OrderCompleted(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderCompleted(opts *bind.FilterOpts, orderId []*big.Int) (*AssetRegistryV3OrderCompletedIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderCompleted", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderCompletedIterator{contract: _AssetRegistryV3.contract, event: "OrderCompleted", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderCompleted is a free log subscription operation binding the contract event 0x02a7d4d9472af7118644884b9a0ee443540c0027e938dd0aa35be8ecbe946c0a.
+//
+// Solidity: event OrderCompleted(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderCompleted(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderCompleted, orderId []*big.Int) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderCompleted", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderCompleted)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCompleted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderCompleted is a log parse operation binding the contract event 0x02a7d4d9472af7118644884b9a0ee443540c0027e938dd0aa35be8ecbe946c0a.
+//
+// Solidity: event OrderCompleted(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderCompleted(log types.Log) (*AssetRegistryV3OrderCompleted, error) {
+	event := new(AssetRegistryV3OrderCompleted)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCompleted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderCreatedIterator is returned from FilterOrderCreated and is used to iterate over the raw logs and unpacked data for OrderCreated events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCreatedIterator struct {
+	Event *AssetRegistryV3OrderCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderCreated represents a OrderCreated event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderCreated struct {
+	OrderId *big.Int
+	AssetId *big.Int
+	Buyer   common.Address
+	Seller  common.Address
+	Price   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderCreated is a free log retrieval operation binding the contract event 0x49e931e521b3ceb66193af0aefdeddff97d898c2d6b6ba416482c630bcc2f578.
+//
+// Solidity: event OrderCreated(uint256 indexed orderId, uint256 indexed assetId, address indexed buyer, address seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderCreated(opts *bind.FilterOpts, orderId []*big.Int, assetId []*big.Int, buyer []common.Address) (*AssetRegistryV3OrderCreatedIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderCreated", orderIdRule, assetIdRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderCreatedIterator{contract: _AssetRegistryV3.contract, event: "OrderCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderCreated is a free log subscription operation binding the contract event 0x49e931e521b3ceb66193af0aefdeddff97d898c2d6b6ba416482c630bcc2f578.
+//
+// Solidity: event OrderCreated(uint256 indexed orderId, uint256 indexed assetId, address indexed buyer, address seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderCreated(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderCreated, orderId []*big.Int, assetId []*big.Int, buyer []common.Address) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+	var assetIdRule []interface{}
+	for _, assetIdItem := range assetId {
+		assetIdRule = append(assetIdRule, assetIdItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderCreated", orderIdRule, assetIdRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderCreated)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderCreated is a log parse operation binding the contract event 0x49e931e521b3ceb66193af0aefdeddff97d898c2d6b6ba416482c630bcc2f578.
+//
+// Solidity: event OrderCreated(uint256 indexed orderId, uint256 indexed assetId, address indexed buyer, address seller, uint256 price)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderCreated(log types.Log) (*AssetRegistryV3OrderCreated, error) {
+	event := new(AssetRegistryV3OrderCreated)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderDeliveredIterator is returned from FilterOrderDelivered and is used to iterate over the raw logs and unpacked data for OrderDelivered events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderDeliveredIterator struct {
+	Event *AssetRegistryV3OrderDelivered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderDeliveredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderDelivered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderDelivered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderDeliveredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderDeliveredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderDelivered represents a OrderDelivered event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderDelivered struct {
+	OrderId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderDelivered is a free log retrieval operation binding the contract event 0xe21fe02c1589242b30be2057f54fd54b7ddc463695d19ffdb54f9ebb0584f24a.
+//
+// Solidity: event OrderDelivered(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderDelivered(opts *bind.FilterOpts, orderId []*big.Int) (*AssetRegistryV3OrderDeliveredIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderDelivered", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderDeliveredIterator{contract: _AssetRegistryV3.contract, event: "OrderDelivered", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderDelivered is a free log subscription operation binding the contract event 0xe21fe02c1589242b30be2057f54fd54b7ddc463695d19ffdb54f9ebb0584f24a.
+//
+// Solidity: event OrderDelivered(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderDelivered(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderDelivered, orderId []*big.Int) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderDelivered", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderDelivered)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderDelivered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderDelivered is a log parse operation binding the contract event 0xe21fe02c1589242b30be2057f54fd54b7ddc463695d19ffdb54f9ebb0584f24a.
+//
+// Solidity: event OrderDelivered(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderDelivered(log types.Log) (*AssetRegistryV3OrderDelivered, error) {
+	event := new(AssetRegistryV3OrderDelivered)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderDelivered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderPaidIterator is returned from FilterOrderPaid and is used to iterate over the raw logs and unpacked data for OrderPaid events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderPaidIterator struct {
+	Event *AssetRegistryV3OrderPaid // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderPaidIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderPaid)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderPaid)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderPaidIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderPaidIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderPaid represents a OrderPaid event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderPaid struct {
+	OrderId *big.Int
+	Buyer   common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderPaid is a free log retrieval operation binding the contract event 0x5520aebd320f4220d5d0f01787c6e3c7312f88384646f41a18f75ac96ecea477.
+//
+// Solidity: event OrderPaid(uint256 indexed orderId, address indexed buyer)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderPaid(opts *bind.FilterOpts, orderId []*big.Int, buyer []common.Address) (*AssetRegistryV3OrderPaidIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderPaid", orderIdRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderPaidIterator{contract: _AssetRegistryV3.contract, event: "OrderPaid", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderPaid is a free log subscription operation binding the contract event 0x5520aebd320f4220d5d0f01787c6e3c7312f88384646f41a18f75ac96ecea477.
+//
+// Solidity: event OrderPaid(uint256 indexed orderId, address indexed buyer)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderPaid(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderPaid, orderId []*big.Int, buyer []common.Address) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderPaid", orderIdRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderPaid)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderPaid", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderPaid is a log parse operation binding the contract event 0x5520aebd320f4220d5d0f01787c6e3c7312f88384646f41a18f75ac96ecea477.
+//
+// Solidity: event OrderPaid(uint256 indexed orderId, address indexed buyer)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderPaid(log types.Log) (*AssetRegistryV3OrderPaid, error) {
+	event := new(AssetRegistryV3OrderPaid)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderPaid", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderRefundedIterator is returned from FilterOrderRefunded and is used to iterate over the raw logs and unpacked data for OrderRefunded events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderRefundedIterator struct {
+	Event *AssetRegistryV3OrderRefunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderRefundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderRefunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderRefunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderRefundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderRefundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderRefunded represents a OrderRefunded event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderRefunded struct {
+	OrderId      *big.Int
+	RefundAmount *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderRefunded is a free log retrieval operation binding the contract event 0xe9c7fa3286f1b85573e4b918330a290ab4c7983835ed2d6d6cdfdc1231e9c125.
+//
+// Solidity: event OrderRefunded(uint256 indexed orderId, uint256 refundAmount)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderRefunded(opts *bind.FilterOpts, orderId []*big.Int) (*AssetRegistryV3OrderRefundedIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderRefunded", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderRefundedIterator{contract: _AssetRegistryV3.contract, event: "OrderRefunded", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderRefunded is a free log subscription operation binding the contract event 0xe9c7fa3286f1b85573e4b918330a290ab4c7983835ed2d6d6cdfdc1231e9c125.
+//
+// Solidity: event OrderRefunded(uint256 indexed orderId, uint256 refundAmount)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderRefunded(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderRefunded, orderId []*big.Int) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderRefunded", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderRefunded)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderRefunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderRefunded is a log parse operation binding the contract event 0xe9c7fa3286f1b85573e4b918330a290ab4c7983835ed2d6d6cdfdc1231e9c125.
+//
+// Solidity: event OrderRefunded(uint256 indexed orderId, uint256 refundAmount)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderRefunded(log types.Log) (*AssetRegistryV3OrderRefunded, error) {
+	event := new(AssetRegistryV3OrderRefunded)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderRefunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AssetRegistryV3OrderShippedIterator is returned from FilterOrderShipped and is used to iterate over the raw logs and unpacked data for OrderShipped events raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderShippedIterator struct {
+	Event *AssetRegistryV3OrderShipped // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AssetRegistryV3OrderShippedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AssetRegistryV3OrderShipped)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AssetRegistryV3OrderShipped)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AssetRegistryV3OrderShippedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AssetRegistryV3OrderShippedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AssetRegistryV3OrderShipped represents a OrderShipped event raised by the AssetRegistryV3 contract.
+type AssetRegistryV3OrderShipped struct {
+	OrderId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterOrderShipped is a free log retrieval operation binding the contract event 0xe3ce5f36666aec295b2ed1a858caf4782066e5e8c1a5a5da7ba6551b14fcfdcb.
+//
+// Solidity: event OrderShipped(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) FilterOrderShipped(opts *bind.FilterOpts, orderId []*big.Int) (*AssetRegistryV3OrderShippedIterator, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.FilterLogs(opts, "OrderShipped", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRegistryV3OrderShippedIterator{contract: _AssetRegistryV3.contract, event: "OrderShipped", logs: logs, sub: sub}, nil
+}
+
+// WatchOrderShipped is a free log subscription operation binding the contract event 0xe3ce5f36666aec295b2ed1a858caf4782066e5e8c1a5a5da7ba6551b14fcfdcb.
+//
+// Solidity: event OrderShipped(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) WatchOrderShipped(opts *bind.WatchOpts, sink chan<- *AssetRegistryV3OrderShipped, orderId []*big.Int) (event.Subscription, error) {
+
+	var orderIdRule []interface{}
+	for _, orderIdItem := range orderId {
+		orderIdRule = append(orderIdRule, orderIdItem)
+	}
+
+	logs, sub, err := _AssetRegistryV3.contract.WatchLogs(opts, "OrderShipped", orderIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AssetRegistryV3OrderShipped)
+				if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderShipped", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOrderShipped is a log parse operation binding the contract event 0xe3ce5f36666aec295b2ed1a858caf4782066e5e8c1a5a5da7ba6551b14fcfdcb.
+//
+// Solidity: event OrderShipped(uint256 indexed orderId)
+func (_AssetRegistryV3 *AssetRegistryV3Filterer) ParseOrderShipped(log types.Log) (*AssetRegistryV3OrderShipped, error) {
+	event := new(AssetRegistryV3OrderShipped)
+	if err := _AssetRegistryV3.contract.UnpackLog(event, "OrderShipped", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
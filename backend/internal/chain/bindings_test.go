@@ -0,0 +1,251 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+const (
+	abiFile      = "abi/AssetRegistryV3.json"
+	bindingsFile = "asset_registry_v3.go"
+	// artifactFile Hardhat 编译产物，未编译合约时跳过对比（TestABIMatchesSource 不依赖编译产物）
+	artifactFile = "../../../contracts/artifacts/contracts/AssetRegistryV3.sol/AssetRegistryV3.json"
+	sourceFile   = "../../../contracts/contracts/AssetRegistryV3.sol"
+)
+
+// TestBindingsUpToDate 按 gen.go 的参数重新生成绑定，与提交的绑定文件对比
+func TestBindingsUpToDate(t *testing.T) {
+	abiJSON, err := os.ReadFile(abiFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := bind.Bind(
+		[]string{"AssetRegistryV3"},
+		[]string{string(abiJSON)},
+		[]string{""},
+		nil,
+		"chain",
+		bind.LangGo,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("failed to generate bindings: %v", err)
+	}
+	committed, err := os.ReadFile(bindingsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != string(committed) {
+		t.Errorf("%s is out of date with %s, run: make bindings", bindingsFile, abiFile)
+	}
+}
+
+// TestABIMatchesArtifact 提交的 ABI 与 Hardhat 编译产物中的 ABI 一致（忽略格式）
+func TestABIMatchesArtifact(t *testing.T) {
+	raw, err := os.ReadFile(artifactFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("%s not found, compile the contracts to compare", artifactFile)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var artifact struct {
+		ABI any `json:"abi"`
+	}
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		t.Fatalf("failed to parse artifact: %v", err)
+	}
+
+	raw, err = os.ReadFile(abiFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var committed any
+	if err := json.Unmarshal(raw, &committed); err != nil {
+		t.Fatalf("failed to parse %s: %v", abiFile, err)
+	}
+	if !reflect.DeepEqual(artifact.ABI, committed) {
+		t.Errorf("%s differs from the Hardhat artifact, run: make abi bindings", abiFile)
+	}
+}
+
+var (
+	solComment   = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	solEnum      = regexp.MustCompile(`\benum\s+(\w+)`)
+	solEvent     = regexp.MustCompile(`\bevent\s+(\w+)\s*\(([^)]*)\)\s*;`)
+	solFunction  = regexp.MustCompile(`\bfunction\s+(\w+)\s*\(([^)]*)\)([^{;]*)`)
+	solPublicVar = regexp.MustCompile(`(?m)^\s*(mapping\s*\(.*\)|[\w\[\]]+)\s+public\s+(?:constant\s+|immutable\s+)?(\w+)\s*[;=]`)
+	solMapping   = regexp.MustCompile(`^mapping\s*\(\s*(\w+)\s*=>\s*(.*)\)$`)
+)
+
+// solidityInterface 从合约源码中解析事件和外部可调用函数（含 public 状态变量的 getter）的签名
+// 事件签名带 indexed 标记，函数签名带 stateMutability，与 ABI 中对应的信息比较
+type solidityInterface struct {
+	enums     map[string]bool
+	events    []string
+	functions []string
+}
+
+func parseSolidity(t *testing.T, source string) *solidityInterface {
+	t.Helper()
+	source = solComment.ReplaceAllString(source, "")
+	si := &solidityInterface{enums: make(map[string]bool)}
+	for _, m := range solEnum.FindAllStringSubmatch(source, -1) {
+		si.enums[m[1]] = true
+	}
+
+	for _, m := range solEvent.FindAllStringSubmatch(source, -1) {
+		var params []string
+		for _, param := range splitParams(m[2]) {
+			fields := strings.Fields(param)
+			typ := si.abiType(t, fields[0])
+			for _, f := range fields[1:] {
+				if f == "indexed" {
+					typ += " indexed"
+				}
+			}
+			params = append(params, typ)
+		}
+		si.events = append(si.events, fmt.Sprintf("%s(%s)", m[1], strings.Join(params, ",")))
+	}
+
+	for _, m := range solFunction.FindAllStringSubmatch(source, -1) {
+		modifiers := strings.Fields(m[3])
+		if !contains(modifiers, "external") && !contains(modifiers, "public") {
+			continue
+		}
+		var params []string
+		for _, param := range splitParams(m[2]) {
+			params = append(params, si.abiType(t, strings.Fields(param)[0]))
+		}
+		mutability := "nonpayable"
+		for _, candidate := range []string{"view", "pure", "payable"} {
+			if contains(modifiers, candidate) {
+				mutability = candidate
+			}
+		}
+		si.functions = append(si.functions, fmt.Sprintf("%s(%s) %s", m[1], strings.Join(params, ","), mutability))
+	}
+
+	for _, m := range solPublicVar.FindAllStringSubmatch(source, -1) {
+		params := si.getterParams(t, strings.TrimSpace(m[1]))
+		si.functions = append(si.functions, fmt.Sprintf("%s(%s) view", m[2], strings.Join(params, ",")))
+	}
+	return si
+}
+
+// getterParams public 状态变量 getter 的参数：mapping 的每一层键和数组的每一层下标
+func (si *solidityInterface) getterParams(t *testing.T, typ string) []string {
+	if m := solMapping.FindStringSubmatch(typ); m != nil {
+		return append([]string{si.abiType(t, m[1])}, si.getterParams(t, strings.TrimSpace(m[2]))...)
+	}
+	if strings.HasSuffix(typ, "[]") {
+		return append([]string{"uint256"}, si.getterParams(t, strings.TrimSuffix(typ, "[]"))...)
+	}
+	return nil
+}
+
+// abiType 将 Solidity 类型转换为 ABI 类型名，枚举按 uint8 编码
+func (si *solidityInterface) abiType(t *testing.T, typ string) string {
+	t.Helper()
+	if base := strings.TrimSuffix(typ, "[]"); base != typ {
+		return si.abiType(t, base) + "[]"
+	}
+	switch {
+	case si.enums[typ]:
+		return "uint8"
+	case typ == "uint":
+		return "uint256"
+	case typ == "int":
+		return "int256"
+	case typ == "address", typ == "bool", typ == "string", typ == "bytes",
+		strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "bytes"):
+		return typ
+	}
+	t.Fatalf("unsupported Solidity type %q, extend abiType", typ)
+	return ""
+}
+
+func splitParams(list string) []string {
+	var params []string
+	for _, param := range strings.Split(list, ",") {
+		if param = strings.TrimSpace(param); param != "" {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// TestABIMatchesSource 提交的 ABI 中的事件和函数签名与合约源码一致，不需要编译合约
+func TestABIMatchesSource(t *testing.T) {
+	source, err := os.ReadFile(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := parseSolidity(t, string(source))
+
+	raw, err := os.ReadFile(abiFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", abiFile, err)
+	}
+	var events, functions []string
+	for _, event := range parsed.Events {
+		var params []string
+		for _, input := range event.Inputs {
+			typ := input.Type.String()
+			if input.Indexed {
+				typ += " indexed"
+			}
+			params = append(params, typ)
+		}
+		events = append(events, fmt.Sprintf("%s(%s)", event.RawName, strings.Join(params, ",")))
+	}
+	for _, method := range parsed.Methods {
+		functions = append(functions, method.Sig+" "+method.StateMutability)
+	}
+
+	tests := []struct {
+		kind      string
+		source    []string
+		committed []string
+	}{
+		{"events", want.events, events},
+		{"functions", want.functions, functions},
+	}
+	for _, tt := range tests {
+		sort.Strings(tt.source)
+		sort.Strings(tt.committed)
+		if len(tt.source) == 0 {
+			t.Errorf("no %s parsed from %s", tt.kind, sourceFile)
+		}
+		if !reflect.DeepEqual(tt.source, tt.committed) {
+			t.Errorf("%s in %s differ from %s, run: make abi bindings\nsource:    %q\ncommitted: %q",
+				tt.kind, abiFile, sourceFile, tt.source, tt.committed)
+		}
+	}
+}
@@ -1,89 +1,56 @@
 package chain
 
 import (
+	"bytes"
 	"chain-vault-backend/internal/config"
+	"chain-vault-backend/internal/metrics"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
-	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// AssetRegistryABI 是合约的 ABI（简化版，只包含我们需要的事件）
-const AssetRegistryABI = `[
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "name": "assetId", "type": "uint256"},
-			{"indexed": true, "name": "owner", "type": "address"},
-			{"indexed": true, "name": "brand", "type": "address"},
-			{"indexed": false, "name": "name", "type": "string"},
-			{"indexed": false, "name": "serialNumber", "type": "string"}
-		],
-		"name": "AssetRegistered",
-		"type": "event"
-	},
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "name": "assetId", "type": "uint256"},
-			{"indexed": true, "name": "from", "type": "address"},
-			{"indexed": true, "name": "to", "type": "address"}
-		],
-		"name": "AssetTransferred",
-		"type": "event"
-	},
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "name": "assetId", "type": "uint256"},
-			{"indexed": true, "name": "seller", "type": "address"},
-			{"indexed": false, "name": "price", "type": "uint256"}
-		],
-		"name": "AssetListed",
-		"type": "event"
-	},
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "name": "assetId", "type": "uint256"}
-		],
-		"name": "AssetUnlisted",
-		"type": "event"
-	}
-]`
-
 type Client struct {
 	client        *ethclient.Client
 	contractAddr common.Address
 	contractABI  abi.ABI
+	contract     *AssetRegistryV3
 }
 
 func NewClient(cfg *config.Config) (*Client, error) {
-	client, err := ethclient.Dial(cfg.EthRPCURL)
+	client, err := ethclient.Dial(cfg.Chain.RPCURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
 
-	contractAddr := common.HexToAddress(cfg.ContractAddress)
+	contractAddr := common.HexToAddress(cfg.Chain.ContractAddress)
 	if contractAddr == (common.Address{}) {
-		return nil, fmt.Errorf("invalid contract address: %s", cfg.ContractAddress)
+		return nil, fmt.Errorf("invalid contract address: %s", cfg.Chain.ContractAddress)
 	}
 
-	parsedABI, err := abi.JSON(strings.NewReader(AssetRegistryABI))
+	parsedABI, err := AssetRegistryV3MetaData.GetAbi()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %w", err)
 	}
 
+	contract, err := NewAssetRegistryV3(contractAddr, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind contract: %w", err)
+	}
+
 	return &Client{
 		client:        client,
 		contractAddr: contractAddr,
-		contractABI:  parsedABI,
+		contractABI:  *parsedABI,
+		contract:     contract,
 	}, nil
 }
 
@@ -99,130 +66,152 @@ func (c *Client) GetContractABI() abi.ABI {
 	return c.contractABI
 }
 
-// WatchEvents 监听合约事件
-func (c *Client) WatchEvents(ctx context.Context, fromBlock uint64, eventChan chan<- *AssetRegisteredEvent) error {
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{c.contractAddr},
-		FromBlock: new(big.Int).SetUint64(fromBlock),
+// Contract 返回合约的类型化绑定，用于解析事件和调用只读函数
+func (c *Client) Contract() *AssetRegistryV3 {
+	return c.contract
+}
+
+// GetLatestBlock 获取最新区块号
+func (c *Client) GetLatestBlock(ctx context.Context) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if observeRPC("eth_getBlockByNumber", err) != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// GetBlockTime 获取指定区块的出块时间
+func (c *Client) GetBlockTime(ctx context.Context, blockNumber uint64) (time.Time, error) {
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if observeRPC("eth_getBlockByNumber", err) != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// GetVerifyAssetBrand 从 verifyAsset 交易的输入中解析 brandAddress 参数
+// 合约只在 AssetVerified 事件中记录验证人，品牌地址需要从调用参数中获取
+// 如果交易不是 verifyAsset 调用（例如品牌方直接注册资产），返回 ok=false
+func (c *Client) GetVerifyAssetBrand(ctx context.Context, txHash common.Hash) (brand common.Address, ok bool, err error) {
+	tx, _, err := c.client.TransactionByHash(ctx, txHash)
+	if observeRPC("eth_getTransactionByHash", err) != nil {
+		return common.Address{}, false, err
 	}
 
-	logs := make(chan types.Log)
-	sub, err := c.client.SubscribeFilterLogs(ctx, query, logs)
+	method := c.contractABI.Methods["verifyAsset"]
+	input := tx.Data()
+	if len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return common.Address{}, false, nil
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
 	if err != nil {
-		return fmt.Errorf("failed to subscribe to logs: %w", err)
-	}
-
-	go func() {
-		defer close(logs)
-		defer sub.Unsubscribe()
-
-		for {
-			select {
-			case err := <-sub.Err():
-				if err != nil {
-					fmt.Printf("Subscription error: %v\n", err)
-				}
-				return
-			case log := <-logs:
-				// 验证事件签名（Topics[0] 是事件签名的哈希）
-				eventSig := c.contractABI.Events["AssetRegistered"].ID
-				if len(log.Topics) == 0 || log.Topics[0] != eventSig {
-					continue
-				}
-
-				event := new(AssetRegisteredEvent)
-
-				// 解析 indexed 参数（从 Topics 中）
-				// Topics[0] = 事件签名
-				// Topics[1] = assetId (uint256, indexed)
-				// Topics[2] = owner (address, indexed)
-				if len(log.Topics) > 1 {
-					// Topics[1] = assetId (uint256)
-					event.AssetId = new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64()
-				}
-				if len(log.Topics) > 2 {
-					// Topics[2] = owner (address)
-					event.Owner = common.BytesToAddress(log.Topics[2].Bytes())
-				}
-
-				// 解析非 indexed 参数（从 Data 中）
-				// Data 只包含 name (string, non-indexed)
-				if len(log.Data) > 0 {
-					// 使用 UnpackIntoMap 解析事件数据
-					eventMap := make(map[string]interface{})
-					err := c.contractABI.UnpackIntoMap(eventMap, "AssetRegistered", log.Data)
-					if err == nil {
-						if name, ok := eventMap["name"].(string); ok {
-							event.Name = name
-						}
-					} else {
-						// 备用方法：直接解析字符串
-						unpacked, err := c.contractABI.Unpack("AssetRegistered", log.Data)
-						if err == nil && len(unpacked) > 0 {
-							if nameStr, ok := unpacked[0].(string); ok {
-								event.Name = nameStr
-							}
-						} else {
-							fmt.Printf("Failed to unpack event name: %v\n", err)
-							continue
-						}
-					}
-				}
-
-				event.BlockNumber = log.BlockNumber
-				event.TxHash = log.TxHash.Hex()
-
-				eventChan <- event
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-
-	return nil
-}
-
-// AssetRegisteredEvent 事件结构
-type AssetRegisteredEvent struct {
-	AssetId      uint64
+		return common.Address{}, false, fmt.Errorf("failed to unpack verifyAsset input: %w", err)
+	}
+	if len(args) < 3 {
+		return common.Address{}, false, nil
+	}
+	brand, ok = args[2].(common.Address)
+	return brand, ok, nil
+}
+
+// GetBlockHash 获取指定高度的区块哈希
+func (c *Client) GetBlockHash(ctx context.Context, blockNumber uint64) (common.Hash, error) {
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if observeRPC("eth_getBlockByNumber", err) != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+// FilterLogs 按条件查询日志
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := c.client.FilterLogs(ctx, query)
+	return logs, observeRPC("eth_getLogs", err)
+}
+
+// AssetState 合约 assets(assetId) 查询返回的资产状态
+type AssetState struct {
+	AssetId      *big.Int
 	Owner        common.Address
 	Brand        common.Address
 	Name         string
 	SerialNumber string
-	BlockNumber  uint64
-	TxHash       string
+	MetadataURI  string
+	Status       uint8
+	CreatedAt    *big.Int
+	IsListed     bool
+	Price        *big.Int
 }
 
-// AssetTransferredEvent 资产转移事件结构
-type AssetTransferredEvent struct {
-	AssetId     uint64
-	From        common.Address
-	To          common.Address
-	BlockNumber uint64
-	TxHash      string
+// OrderState 合约 orders(orderId) 查询返回的订单状态
+type OrderState struct {
+	OrderId        *big.Int
+	AssetId        *big.Int
+	Seller         common.Address
+	Buyer          common.Address
+	Price          *big.Int
+	Status         uint8
+	CreatedAt      *big.Int
+	PaidAt         *big.Int
+	ShippedAt      *big.Int
+	DeliveredAt    *big.Int
+	CompletedAt    *big.Int
+	CanRefund      bool
+	RefundDeadline *big.Int
 }
 
-// AssetListedEvent 资产上架事件结构
-type AssetListedEvent struct {
-	AssetId     uint64
-	Seller      common.Address
-	Price       *big.Int
-	BlockNumber uint64
-	TxHash      string
+// BrandState 合约 brands(address) 查询返回的品牌状态
+type BrandState struct {
+	BrandAddress common.Address
+	BrandName    string
+	IsAuthorized bool
+	RegisteredAt *big.Int
 }
 
-// AssetUnlistedEvent 资产下架事件结构
-type AssetUnlistedEvent struct {
-	AssetId     uint64
-	BlockNumber uint64
-	TxHash      string
+// GetAssetState 读取资产在指定区块时的链上状态
+func (c *Client) GetAssetState(ctx context.Context, assetID uint64, blockNumber uint64) (*AssetState, error) {
+	out, err := c.contract.Assets(c.callOptsAt(ctx, blockNumber), new(big.Int).SetUint64(assetID))
+	if observeRPC("eth_call", err) != nil {
+		return nil, fmt.Errorf("failed to call assets: %w", err)
+	}
+	state := AssetState(out)
+	return &state, nil
 }
 
-// GetLatestBlock 获取最新区块号
-func (c *Client) GetLatestBlock(ctx context.Context) (uint64, error) {
-	header, err := c.client.HeaderByNumber(ctx, nil)
-	if err != nil {
-		return 0, err
+// GetOrderState 读取订单在指定区块时的链上状态
+func (c *Client) GetOrderState(ctx context.Context, orderID uint64, blockNumber uint64) (*OrderState, error) {
+	out, err := c.contract.Orders(c.callOptsAt(ctx, blockNumber), new(big.Int).SetUint64(orderID))
+	if observeRPC("eth_call", err) != nil {
+		return nil, fmt.Errorf("failed to call orders: %w", err)
 	}
-	return header.Number.Uint64(), nil
+	state := OrderState(out)
+	return &state, nil
+}
+
+// GetBrandState 读取品牌在指定区块时的链上状态
+func (c *Client) GetBrandState(ctx context.Context, brand common.Address, blockNumber uint64) (*BrandState, error) {
+	out, err := c.contract.Brands(c.callOptsAt(ctx, blockNumber), brand)
+	if observeRPC("eth_call", err) != nil {
+		return nil, fmt.Errorf("failed to call brands: %w", err)
+	}
+	state := BrandState(out)
+	return &state, nil
+}
+
+// callOptsAt 返回在指定区块高度执行只读调用的参数
+func (c *Client) callOptsAt(ctx context.Context, blockNumber uint64) *bind.CallOpts {
+	return &bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+	}
+}
+
+// observeRPC 记录一次 RPC 调用，节点返回“未找到”不计为失败，原样返回 err
+func observeRPC(method string, err error) error {
+	metrics.RPCRequests.WithLabelValues(method).Inc()
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		metrics.RPCErrors.WithLabelValues(method).Inc()
+	}
+	return err
 }
@@ -0,0 +1,47 @@
+//go:build ignore
+
+// gen.go 根据 abi/AssetRegistryV3.json 生成合约的 Go 绑定（asset_registry_v3.go）
+// 与 abigen --abi abi/AssetRegistryV3.json --pkg chain --type AssetRegistryV3 的输出一致，
+// 直接调用 go-ethereum 的 bind 包，不依赖单独安装的 abigen 命令
+//
+// 使用方式：go generate ./internal/chain
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+const (
+	abiFile  = "abi/AssetRegistryV3.json"
+	outFile  = "asset_registry_v3.go"
+	typeName = "AssetRegistryV3"
+	pkgName  = "chain"
+)
+
+func main() {
+	abiJSON, err := os.ReadFile(abiFile)
+	if err != nil {
+		log.Fatalf("failed to read ABI: %v", err)
+	}
+
+	code, err := bind.Bind(
+		[]string{typeName},
+		[]string{string(abiJSON)},
+		[]string{""}, // 只生成调用和事件绑定，不包含部署代码
+		nil,
+		pkgName,
+		bind.LangGo,
+		nil,
+		nil,
+	)
+	if err != nil {
+		log.Fatalf("failed to generate bindings: %v", err)
+	}
+
+	if err := os.WriteFile(outFile, []byte(code), 0o644); err != nil {
+		log.Fatalf("failed to write bindings: %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+package chain
+
+// 合约 ABI 来自 Hardhat 编译产物，更新合约后执行 make abi 重新导出并生成绑定
+//go:generate go run gen.go
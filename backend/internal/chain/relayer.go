@@ -0,0 +1,302 @@
+package chain
+
+import (
+	"chain-vault-backend/internal/config"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// 中继交易的状态
+const (
+	TxPending   = "pending"   // 已提交，等待上链
+	TxConfirmed = "confirmed" // 已上链且执行成功
+	TxFailed    = "failed"    // 已上链但执行失败
+	TxDropped   = "dropped"   // 超时仍未上链
+)
+
+const (
+	receiptPollInterval = 3 * time.Second
+	receiptTimeout      = 10 * time.Minute
+	txRetention         = 24 * time.Hour // 已结束的交易在内存中保留的时间
+)
+
+var (
+	// ErrRelayerNotConfigured 未配置签名密钥
+	ErrRelayerNotConfigured = errors.New("relayer key not configured")
+	// ErrEstimateGas gas 估算失败，通常是合约调用会被 revert（权限不足、参数不合法等）
+	ErrEstimateGas = errors.New("gas estimation failed")
+)
+
+// RelayedTx 中继提交的交易及其上链状态
+type RelayedTx struct {
+	Hash        string     `json:"txHash"`
+	Method      string     `json:"method"`
+	From        string     `json:"from"`
+	Nonce       uint64     `json:"nonce"`
+	GasLimit    uint64     `json:"gasLimit"`
+	Status      string     `json:"status"`
+	BlockNumber uint64     `json:"blockNumber,omitempty"`
+	GasUsed     uint64     `json:"gasUsed,omitempty"`
+	SubmittedAt time.Time  `json:"submittedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Relayer 使用后端持有的管理员密钥签名并提交合约交易
+// 本地维护 nonce 保证并发提交时 nonce 连续，提交后在后台轮询交易回执
+type Relayer struct {
+	client     *Client
+	transactor *AssetRegistryV3TransactorRaw
+	key        *ecdsa.PrivateKey
+	from       common.Address
+	chainID    *big.Int
+	gasBuffer  uint64
+
+	// 提交交易时持有，保证 nonce 分配和发送是串行的
+	mu          sync.Mutex
+	nonce       uint64
+	nonceLoaded bool
+
+	txMu sync.RWMutex
+	txs  map[common.Hash]*RelayedTx
+}
+
+// NewRelayer 根据配置加载签名密钥并连接节点
+func NewRelayer(cfg *config.Config) (*Relayer, error) {
+	key, err := loadRelayerKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	chainID, err := client.client.ChainID(ctx)
+	if observeRPC("eth_chainId", err) != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	return &Relayer{
+		client:     client,
+		transactor: &AssetRegistryV3TransactorRaw{Contract: &client.contract.AssetRegistryV3Transactor},
+		key:        key,
+		from:       crypto.PubkeyToAddress(key.PublicKey),
+		chainID:    chainID,
+		gasBuffer:  cfg.Relayer.GasBufferPercent,
+		txs:        make(map[common.Hash]*RelayedTx),
+	}, nil
+}
+
+// loadRelayerKey 优先从 keystore 文件解密私钥，否则使用十六进制私钥
+func loadRelayerKey(cfg *config.Config) (*ecdsa.PrivateKey, error) {
+	switch {
+	case cfg.Relayer.Keystore != "":
+		data, err := os.ReadFile(cfg.Relayer.Keystore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %w", err)
+		}
+		key, err := keystore.DecryptKey(data, cfg.Relayer.KeystorePassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+		}
+		return key.PrivateKey, nil
+	case cfg.Relayer.PrivateKey != "":
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.Relayer.PrivateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid relayer private key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, ErrRelayerNotConfigured
+	}
+}
+
+// Address 返回中继的签名地址
+func (r *Relayer) Address() common.Address {
+	return r.from
+}
+
+// AuthorizeBrand 提交 authorizeBrand 交易（需要管理员密钥）
+func (r *Relayer) AuthorizeBrand(ctx context.Context, brand common.Address, authorized bool) (*RelayedTx, error) {
+	return r.submit(ctx, "authorizeBrand", brand, authorized)
+}
+
+// VerifyAsset 提交 verifyAsset 交易（需要管理员或已授权品牌的密钥）
+// status 只能是 Verified(2) 或 Rejected(3)，brand 为零地址时不修改资产的品牌
+func (r *Relayer) VerifyAsset(ctx context.Context, assetID uint64, status uint8, brand common.Address) (*RelayedTx, error) {
+	return r.submit(ctx, "verifyAsset", new(big.Int).SetUint64(assetID), status, brand)
+}
+
+// SetPlatformFee 提交 setPlatformFee 交易，合约限制手续费不超过 10%
+func (r *Relayer) SetPlatformFee(ctx context.Context, feePercent uint64) (*RelayedTx, error) {
+	return r.submit(ctx, "setPlatformFee", new(big.Int).SetUint64(feePercent))
+}
+
+// WithdrawPlatformFees 提交 withdrawPlatformFees 交易，合约余额转入管理员地址
+func (r *Relayer) WithdrawPlatformFees(ctx context.Context) (*RelayedTx, error) {
+	return r.submit(ctx, "withdrawPlatformFees")
+}
+
+// GetTx 查询中继提交过的交易状态
+func (r *Relayer) GetTx(hash common.Hash) (*RelayedTx, bool) {
+	r.txMu.RLock()
+	defer r.txMu.RUnlock()
+	tx, ok := r.txs[hash]
+	if !ok {
+		return nil, false
+	}
+	cp := *tx
+	return &cp, true
+}
+
+// submit 估算 gas、分配 nonce、签名并发送交易，然后在后台跟踪回执
+func (r *Relayer) submit(ctx context.Context, method string, args ...interface{}) (*RelayedTx, error) {
+	input, err := r.client.contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	// 先估算 gas：调用会被 revert 时在这里返回，不占用 nonce
+	gas, err := r.client.client.EstimateGas(ctx, ethereum.CallMsg{
+		From: r.from,
+		To:   &r.client.contractAddr,
+		Data: input,
+	})
+	if observeRPC("eth_estimateGas", err) != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrEstimateGas, method, err)
+	}
+	gasLimit := gas + gas*r.gasBuffer/100
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.nonceLoaded {
+		nonce, err := r.client.client.PendingNonceAt(ctx, r.from)
+		if observeRPC("eth_getTransactionCount", err) != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		r.nonce = nonce
+		r.nonceLoaded = true
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(r.key, r.chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	opts.Nonce = new(big.Int).SetUint64(r.nonce)
+	opts.GasLimit = gasLimit
+
+	tx, err := r.transactor.Transact(opts, method, args...)
+	if observeRPC("eth_sendRawTransaction", err) != nil {
+		// 发送失败时节点可能已经收到交易，下次提交前重新从节点读取 nonce
+		r.nonceLoaded = false
+		return nil, fmt.Errorf("failed to send %s: %w", method, err)
+	}
+	r.nonce++
+
+	relayed := &RelayedTx{
+		Hash:        tx.Hash().Hex(),
+		Method:      method,
+		From:        r.from.Hex(),
+		Nonce:       tx.Nonce(),
+		GasLimit:    tx.Gas(),
+		Status:      TxPending,
+		SubmittedAt: time.Now(),
+	}
+	r.txMu.Lock()
+	r.pruneLocked()
+	r.txs[tx.Hash()] = relayed
+	cp := *relayed
+	r.txMu.Unlock()
+
+	slog.InfoContext(ctx, "relayed transaction", "method", method, "tx", tx.Hash().Hex(), "nonce", tx.Nonce(), "gas_limit", tx.Gas())
+
+	// 回执在后台跟踪，沿用请求的日志属性但不随请求结束而取消
+	go r.track(context.WithoutCancel(ctx), tx.Hash())
+	return &cp, nil
+}
+
+// track 轮询交易回执直到上链或超时
+func (r *Relayer) track(parent context.Context, hash common.Hash) {
+	ctx, cancel := context.WithTimeout(parent, receiptTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.WarnContext(ctx, "relayed transaction not mined in time", "tx", hash.Hex(), "timeout", receiptTimeout)
+			r.finish(hash, func(tx *RelayedTx) {
+				tx.Status = TxDropped
+			})
+			// 交易可能被节点丢弃，之后的 nonce 需要重新读取
+			r.mu.Lock()
+			r.nonceLoaded = false
+			r.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		receipt, err := r.client.client.TransactionReceipt(ctx, hash)
+		if errors.Is(observeRPC("eth_getTransactionReceipt", err), ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "failed to get receipt for relayed transaction", "tx", hash.Hex(), "error", err)
+			continue
+		}
+
+		r.finish(hash, func(tx *RelayedTx) {
+			tx.Status = TxConfirmed
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				tx.Status = TxFailed
+			}
+			tx.BlockNumber = receipt.BlockNumber.Uint64()
+			tx.GasUsed = receipt.GasUsed
+		})
+		slog.InfoContext(ctx, "relayed transaction mined", "tx", hash.Hex(), "block", receipt.BlockNumber.Uint64(), "status", receipt.Status)
+		return
+	}
+}
+
+// finish 更新交易的最终状态
+func (r *Relayer) finish(hash common.Hash, update func(tx *RelayedTx)) {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+	if tx, ok := r.txs[hash]; ok {
+		update(tx)
+		now := time.Now()
+		tx.FinishedAt = &now
+	}
+}
+
+// pruneLocked 清理已结束且超过保留时间的交易，调用方需持有 txMu
+func (r *Relayer) pruneLocked() {
+	cutoff := time.Now().Add(-txRetention)
+	for hash, tx := range r.txs {
+		if tx.FinishedAt != nil && tx.FinishedAt.Before(cutoff) {
+			delete(r.txs, hash)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxInfo 交易及其回执的链上信息
+type TxInfo struct {
+	Hash        string         `json:"txHash"`
+	From        string         `json:"from"`
+	To          string         `json:"to,omitempty"`
+	Pending     bool           `json:"pending"`
+	Success     bool           `json:"success"`
+	BlockNumber uint64         `json:"blockNumber,omitempty"`
+	BlockHash   string         `json:"blockHash,omitempty"`
+	GasUsed     uint64         `json:"gasUsed,omitempty"`
+	Events      []DecodedEvent `json:"events"`
+}
+
+// DecodedEvent 按合约 ABI 解析后的事件
+type DecodedEvent struct {
+	Name     string                 `json:"name"`
+	LogIndex uint                   `json:"logIndex"`
+	Args     map[string]interface{} `json:"args"`
+}
+
+// GetTxInfo 查询交易及回执，并解析其中本合约发出的事件
+// 节点不认识该交易时返回 ethereum.NotFound
+func (c *Client) GetTxInfo(ctx context.Context, hash common.Hash) (*TxInfo, error) {
+	tx, pending, err := c.client.TransactionByHash(ctx, hash)
+	if observeRPC("eth_getTransactionByHash", err) != nil {
+		return nil, err
+	}
+
+	info := &TxInfo{
+		Hash:    hash.Hex(),
+		Pending: pending,
+		Events:  []DecodedEvent{},
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		info.From = from.Hex()
+	}
+	if tx.To() != nil {
+		info.To = tx.To().Hex()
+	}
+	if pending {
+		return info, nil
+	}
+
+	receipt, err := c.client.TransactionReceipt(ctx, hash)
+	if observeRPC("eth_getTransactionReceipt", err) != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	info.Success = receipt.Status == types.ReceiptStatusSuccessful
+	info.BlockNumber = receipt.BlockNumber.Uint64()
+	info.BlockHash = receipt.BlockHash.Hex()
+	info.GasUsed = receipt.GasUsed
+
+	for _, log := range receipt.Logs {
+		if log.Address != c.contractAddr {
+			continue
+		}
+		event, err := c.DecodeLog(*log)
+		if err != nil {
+			continue
+		}
+		info.Events = append(info.Events, *event)
+	}
+	return info, nil
+}
+
+// GetTxSender 查询交易的发送方
+func (c *Client) GetTxSender(ctx context.Context, hash common.Hash) (common.Address, error) {
+	tx, _, err := c.client.TransactionByHash(ctx, hash)
+	if observeRPC("eth_getTransactionByHash", err) != nil {
+		return common.Address{}, err
+	}
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+// DecodeLog 按合约 ABI 解析一条日志，包括 indexed 参数
+func (c *Client) DecodeLog(log types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+	abiEvent, err := c.contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	if len(log.Data) > 0 {
+		if err := c.contractABI.UnpackIntoMap(args, abiEvent.Name, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range abiEvent.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	// 大整数和地址转为字符串，避免 JSON 中丢失精度
+	for name, value := range args {
+		switch v := value.(type) {
+		case *big.Int:
+			args[name] = v.String()
+		case common.Address:
+			args[name] = v.Hex()
+		}
+	}
+
+	return &DecodedEvent{
+		Name:     abiEvent.Name,
+		LogIndex: log.Index,
+		Args:     args,
+	}, nil
+}
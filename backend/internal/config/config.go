@@ -1,40 +1,328 @@
+// Package config 服务配置
+//
+// 配置按以下顺序叠加，后者覆盖前者：
+//  1. 默认值（Default）
+//  2. 配置文件：CONFIG_FILE 指定的 YAML（.yaml/.yml）或 TOML（.toml）文件
+//  3. 环境变量（含 .env 文件），变量名见 env.go
+//
+// Load 在叠加后校验全部配置，有问题时返回 *ValidationError，列出每一项的原因。
 package config
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	DatabaseURL    string
-	EthRPCURL      string
-	ContractAddress string
-	StartBlock     uint64
+	Server     ServerConfig     `yaml:"server" toml:"server"`
+	Database   DatabaseConfig   `yaml:"database" toml:"database"`
+	Chain      ChainConfig      `yaml:"chain" toml:"chain"`
+	Relayer    RelayerConfig    `yaml:"relayer" toml:"relayer"`
+	Auth       AuthConfig       `yaml:"auth" toml:"auth"`
+	Images     ImageConfig      `yaml:"images" toml:"images"`
+	IPFS       IPFSConfig       `yaml:"ipfs" toml:"ipfs"`
+	Prices     PriceConfig      `yaml:"prices" toml:"prices"`
+	Orders     OrderConfig      `yaml:"orders" toml:"orders"`
+	Search     SearchConfig     `yaml:"search" toml:"search"`
+	Webhooks   WebhookConfig    `yaml:"webhooks" toml:"webhooks"`
+	Pagination PaginationConfig `yaml:"pagination" toml:"pagination"`
+	Log        LogConfig        `yaml:"log" toml:"log"`
+}
+
+// ServerConfig HTTP 服务
+type ServerConfig struct {
+	Addr        string   `yaml:"addr" toml:"addr"`                 // 监听地址，如 :8080
+	CORSOrigins []string `yaml:"cors_origins" toml:"cors_origins"` // 允许跨域访问的来源，* 表示全部
+}
+
+// DatabaseConfig 数据库连接
+type DatabaseConfig struct {
+	URL string `yaml:"url" toml:"url"` // 包含 @tcp/@udp/@unix 的 DSN 使用 MySQL，否则视为 SQLite 文件路径
+}
+
+// ChainConfig 节点、合约和事件同步
+type ChainConfig struct {
+	RPCURL          string   `yaml:"rpc_url" toml:"rpc_url"`
+	ContractAddress string   `yaml:"contract_address" toml:"contract_address"` // 为空时不启动事件监听器
+	StartBlock      uint64   `yaml:"start_block" toml:"start_block"`           // 首次同步的起始区块
+	Confirmations   uint64   `yaml:"confirmations" toml:"confirmations"`       // 区块达到该确认数才会被处理
+	PollInterval    Duration `yaml:"poll_interval" toml:"poll_interval"`       // 轮询新区块的间隔
+
+	// 历史日志拉取
+	LogBatchSize        uint64   `yaml:"log_batch_size" toml:"log_batch_size"`               // 每段查询的区块数
+	LogFetchConcurrency int      `yaml:"log_fetch_concurrency" toml:"log_fetch_concurrency"` // 同时进行的日志查询数
+	LogFetchRetries     int      `yaml:"log_fetch_retries" toml:"log_fetch_retries"`         // 单次查询失败后的重试次数
+	LogFetchInterval    Duration `yaml:"log_fetch_interval" toml:"log_fetch_interval"`       // 相邻 RPC 请求的最小间隔，0 表示不限速
+}
+
+// RelayerConfig 交易中继（管理员操作由后端签名上链）
+type RelayerConfig struct {
+	PrivateKey       string `yaml:"private_key" toml:"private_key"` // 十六进制私钥，与 keystore 二选一
+	Keystore         string `yaml:"keystore" toml:"keystore"`       // keystore 文件路径
+	KeystorePassword string `yaml:"keystore_password" toml:"keystore_password"`
+	GasBufferPercent uint64 `yaml:"gas_buffer_percent" toml:"gas_buffer_percent"` // 在估算的 gas 上增加的百分比
 }
 
-func Load() *Config {
+// AuthConfig 钱包签名登录（Sign-In with Ethereum）
+type AuthConfig struct {
+	Domain         string   `yaml:"domain" toml:"domain"`                   // 登录消息中的 domain，需与前端的 host 一致
+	ChainID        uint64   `yaml:"chain_id" toml:"chain_id"`               // 登录消息中的链 ID，0 表示不校验
+	SessionTTL     Duration `yaml:"session_ttl" toml:"session_ttl"`         // 会话有效期
+	AdminAddresses []string `yaml:"admin_addresses" toml:"admin_addresses"` // 管理员地址，可调用授权品牌、验证资产等管理接口
+}
+
+// ImageConfig 图片存储和处理
+type ImageConfig struct {
+	Backend     string `yaml:"backend" toml:"backend"`             // 新图片写入的存储：local 或 ipfs
+	Dir         string `yaml:"dir" toml:"dir"`                     // 本地存储目录
+	MaxMB       int    `yaml:"max_mb" toml:"max_mb"`               // 单张图片的最大大小
+	MaxPixels   int    `yaml:"max_pixels" toml:"max_pixels"`       // 单张图片的最大像素数
+	MaxPerAsset int    `yaml:"max_per_asset" toml:"max_per_asset"` // 单个资产最多的图片数
+}
+
+// MaxBytes 单张图片的最大字节数
+func (c ImageConfig) MaxBytes() int {
+	return c.MaxMB << 20
+}
+
+// IPFSConfig IPFS 节点
+type IPFSConfig struct {
+	APIURL  string   `yaml:"api_url" toml:"api_url"`
+	Timeout Duration `yaml:"timeout" toml:"timeout"` // 单次请求的超时时间
+}
+
+// PriceConfig 价格源（可选）：为价格附加法币价值
+type PriceConfig struct {
+	FeedFile  string `yaml:"feed_file" toml:"feed_file"`   // JSON 价格文件，如 {"USD": "3200.5"}，优先于 ETHPrices
+	ETHPrices string `yaml:"eth_prices" toml:"eth_prices"` // 固定价格，如 USD=3200.5,CNY=23000
+	Currency  string `yaml:"currency" toml:"currency"`     // 默认折算的币种，为空时只在请求带 currency 参数时折算
+}
+
+// OrderConfig 订单，退货期需与合约保持一致
+type OrderConfig struct {
+	PaidRefundWindow      Duration `yaml:"paid_refund_window" toml:"paid_refund_window"`           // 支付后的退货期
+	DeliveredRefundWindow Duration `yaml:"delivered_refund_window" toml:"delivered_refund_window"` // 收货后的退货期
+}
+
+// SearchConfig 资产搜索的元数据索引
+type SearchConfig struct {
+	IndexInterval       Duration `yaml:"index_interval" toml:"index_interval"`               // 读取新资产元数据的间隔
+	MetadataMaxAttempts int      `yaml:"metadata_max_attempts" toml:"metadata_max_attempts"` // 读取元数据失败超过该次数后不再重试
+}
+
+// WebhookConfig Webhook 投递
+type WebhookConfig struct {
+	MaxAttempts      int      `yaml:"max_attempts" toml:"max_attempts"`           // 失败的最大次数，之后转为死信
+	Timeout          Duration `yaml:"timeout" toml:"timeout"`                     // 单次请求的超时时间
+	DispatchInterval Duration `yaml:"dispatch_interval" toml:"dispatch_interval"` // 检查到期投递的间隔
+	// AllowPrivateTargets 允许回调地址为回环、私有、链路本地地址，只用于本地开发
+	AllowPrivateTargets bool `yaml:"allow_private_targets" toml:"allow_private_targets"`
+}
+
+// PaginationConfig 列表接口的分页
+type PaginationConfig struct {
+	DefaultLimit int `yaml:"default_limit" toml:"default_limit"` // 未指定 limit 时每页的条数
+	MaxLimit     int `yaml:"max_limit" toml:"max_limit"`         // 每页最多的条数
+}
+
+// LogConfig 日志
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level"`   // debug / info / warn / error，debug 时输出 SQL
+	Format string `yaml:"format" toml:"format"` // text 或 json
+}
+
+// Default 默认配置，适用于本地开发（SQLite + Hardhat 节点）
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr: ":8080",
+			// 开发环境允许所有来源，生产环境应限制为前端域名
+			CORSOrigins: []string{"*"},
+		},
+		Database: DatabaseConfig{
+			// 默认使用 SQLite 数据库，无需安装 MySQL
+			URL: "chainvault.db",
+		},
+		Chain: ChainConfig{
+			RPCURL: "http://127.0.0.1:8545",
+			// 本地 Hardhat 节点按需出块，默认不需要确认数
+			PollInterval: Duration{3 * time.Second},
+			// 多数 RPC 服务商限制单次查询 2k~10k 个区块
+			LogBatchSize:        2000,
+			LogFetchConcurrency: 4,
+			LogFetchRetries:     5,
+		},
+		Relayer: RelayerConfig{
+			GasBufferPercent: 20,
+		},
+		Auth: AuthConfig{
+			// 前端开发服务器默认运行在 localhost:5173
+			Domain:     "localhost:5173",
+			SessionTTL: Duration{24 * time.Hour},
+		},
+		Images: ImageConfig{
+			Backend:     "local",
+			Dir:         "data/blobs",
+			MaxMB:       10,
+			MaxPixels:   40_000_000,
+			MaxPerAsset: 10,
+		},
+		IPFS: IPFSConfig{
+			// 确保本地运行了 IPFS daemon
+			APIURL:  "http://localhost:5001/api/v0",
+			Timeout: Duration{60 * time.Second},
+		},
+		Orders: OrderConfig{
+			PaidRefundWindow:      Duration{7 * 24 * time.Hour},
+			DeliveredRefundWindow: Duration{3 * 24 * time.Hour},
+		},
+		Search: SearchConfig{
+			IndexInterval:       Duration{30 * time.Second},
+			MetadataMaxAttempts: 5,
+		},
+		Webhooks: WebhookConfig{
+			// 默认 10 次：30 秒起按指数退避，约 4 小时后转为死信
+			MaxAttempts:      10,
+			Timeout:          Duration{10 * time.Second},
+			DispatchInterval: Duration{5 * time.Second},
+		},
+		Pagination: PaginationConfig{
+			DefaultLimit: 20,
+			MaxLimit:     100,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+	}
+}
+
+// Load 读取并校验配置，path 为空时使用 CONFIG_FILE 环境变量，两者都为空时只使用默认值和环境变量
+func Load(path string) (*Config, error) {
+	cfg, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read 叠加默认值、配置文件和环境变量，不做校验
+// 环境变量格式错误时返回 *ValidationError
+func Read(path string) (*Config, error) {
 	// 加载 .env 文件
 	loadEnvFile(".env")
-	
-	return &Config{
-		// 默认使用 SQLite 数据库，无需安装 MySQL
-		DatabaseURL:    getEnv("DATABASE_URL", "chainvault.db"),
-		EthRPCURL:      getEnv("ETH_RPC_URL", "http://127.0.0.1:8545"),
-		ContractAddress: getEnv("CONTRACT_ADDRESS", ""),
-		StartBlock:     0, // 从 0 开始监听，实际部署后应该从部署区块开始
+
+	cfg := Default()
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if problems := cfg.applyEnv(); len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// readFile 按扩展名解析 YAML 或 TOML 配置文件，文件中未出现的配置项保持原值，未知的配置项视为错误
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		// 空文件返回 io.EOF
+		if err := dec.Decode(c); err != nil && len(bytes.TrimSpace(data)) > 0 {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(c); err != nil {
+			var strict *toml.StrictMissingError
+			if errors.As(err, &strict) {
+				keys := make([]string, len(strict.Errors))
+				for i, e := range strict.Errors {
+					keys[i] = strings.Join(e.Key(), ".")
+				}
+				return fmt.Errorf("failed to parse config file %s: unknown keys %s", path, strings.Join(keys, ", "))
+			}
+			var decodeErr *toml.DecodeError
+			if errors.As(err, &decodeErr) {
+				row, col := decodeErr.Position()
+				return fmt.Errorf("failed to parse config file %s: line %d column %d: %w", path, row, col, err)
+			}
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file %s: expected .yaml, .yml or .toml", path)
+	}
+	return nil
+}
+
+// Encode 以 YAML 或 TOML 格式输出配置
+func (c *Config) Encode(format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(c); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "toml":
+		return toml.Marshal(c)
+	}
+	return nil, fmt.Errorf("unsupported format %q: expected yaml or toml", format)
+}
+
+// Duration 配置文件中写作 "3s"、"24h" 等字符串
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	value, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, expected a value such as 30s, 5m or 24h", text)
 	}
+	d.Duration = value
+	return nil
 }
 
 func loadEnvFile(filename string) {
 	// 尝试多个路径
 	paths := []string{
-		filename,                    // 当前目录
-		"./" + filename,             // 当前目录（显式）
-		"../" + filename,            // 上一级目录
-		"backend/" + filename,       // backend 目录
+		filename,              // 当前目录
+		"./" + filename,       // 当前目录（显式）
+		"../" + filename,      // 上一级目录
+		"backend/" + filename, // backend 目录
 	}
-	
+
 	var file *os.File
 	var err error
 	for _, path := range paths {
@@ -43,7 +331,7 @@ func loadEnvFile(filename string) {
 			break
 		}
 	}
-	
+
 	if err != nil {
 		return // 所有路径都找不到文件时忽略错误
 	}
@@ -61,6 +349,12 @@ func loadEnvFile(filename string) {
 		if len(parts) == 2 {
 			key := strings.TrimSpace(parts[0])
 			value := strings.TrimSpace(parts[1])
+			// 去掉未加引号的值后面的注释，如 LOG_BATCH_SIZE=2000  # 每段查询的区块数
+			if !strings.HasPrefix(value, "\"") && !strings.HasPrefix(value, "'") {
+				if i := strings.Index(value, " #"); i >= 0 {
+					value = strings.TrimSpace(value[:i])
+				}
+			}
 			// 移除引号
 			value = strings.Trim(value, "\"'")
 			// 只设置未存在的环境变量
@@ -70,11 +364,3 @@ func loadEnvFile(filename string) {
 		}
 	}
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
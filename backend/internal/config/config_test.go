@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// isolate 在临时目录中运行，清空所有配置相关的环境变量，避免开发机上的 .env 和环境变量影响结果
+func isolate(t *testing.T) string {
+	t.Helper()
+	for _, v := range Default().envVars() {
+		t.Setenv(v.name, "")
+	}
+	t.Setenv("CONFIG_FILE", "")
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default configuration is invalid: %v", err)
+	}
+}
+
+func TestReadLayering(t *testing.T) {
+	const yamlFile = `
+server:
+  addr: ":9090"
+chain:
+  confirmations: 12
+  poll_interval: 10s
+auth:
+  admin_addresses: ["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"]
+webhooks:
+  allow_private_targets: true
+`
+	const tomlFile = `
+[server]
+addr = ":9090"
+
+[chain]
+confirmations = 12
+poll_interval = "10s"
+
+[auth]
+admin_addresses = ["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"]
+
+[webhooks]
+allow_private_targets = true
+`
+	tests := []struct {
+		name  string
+		file  string
+		body  string
+		env   map[string]string
+		check func(t *testing.T, cfg *Config)
+	}{
+		{"defaults only", "", "", nil, func(t *testing.T, cfg *Config) {
+			if !reflect.DeepEqual(cfg, Default()) {
+				t.Errorf("config = %+v, want defaults", cfg)
+			}
+		}},
+		{"yaml file", "config.yaml", yamlFile, nil, checkFileValues},
+		{"toml file", "config.toml", tomlFile, nil, checkFileValues},
+		{"env overrides file", "config.yml", yamlFile, map[string]string{
+			"SERVER_ADDR":                   ":7070",
+			"CONFIRMATIONS":                 "3",
+			"POLL_INTERVAL_SECONDS":         "1",
+			"ADMIN_ADDRESSES":               " 0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359 , ",
+			"WEBHOOK_ALLOW_PRIVATE_TARGETS": "false",
+			"LOG_FETCH_INTERVAL_MS":         "250",
+		}, func(t *testing.T, cfg *Config) {
+			if cfg.Server.Addr != ":7070" || cfg.Chain.Confirmations != 3 || cfg.Chain.PollInterval.Duration != time.Second {
+				t.Errorf("env did not override the file: %+v %+v", cfg.Server, cfg.Chain)
+			}
+			if !reflect.DeepEqual(cfg.Auth.AdminAddresses, []string{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"}) {
+				t.Errorf("admin addresses = %q", cfg.Auth.AdminAddresses)
+			}
+			if cfg.Webhooks.AllowPrivateTargets || cfg.Chain.LogFetchInterval.Duration != 250*time.Millisecond {
+				t.Errorf("webhooks = %+v, log fetch interval = %s", cfg.Webhooks, cfg.Chain.LogFetchInterval)
+			}
+		}},
+		{"empty env keeps file value", "config.yaml", yamlFile, map[string]string{"SERVER_ADDR": "  "}, checkFileValues},
+		{"empty yaml file", "config.yaml", "\n", nil, func(t *testing.T, cfg *Config) {
+			if !reflect.DeepEqual(cfg, Default()) {
+				t.Errorf("config = %+v, want defaults", cfg)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			path := ""
+			if tt.file != "" {
+				path = writeFile(t, dir, tt.file, tt.body)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			cfg, err := Read(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+// checkFileValues 检查 TestReadLayering 中配置文件的值已生效，其余保持默认值
+func checkFileValues(t *testing.T, cfg *Config) {
+	t.Helper()
+	want := Default()
+	want.Server.Addr = ":9090"
+	want.Chain.Confirmations = 12
+	want.Chain.PollInterval = Duration{10 * time.Second}
+	want.Auth.AdminAddresses = []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}
+	want.Webhooks.AllowPrivateTargets = true
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestReadUsesConfigFileEnv(t *testing.T) {
+	dir := isolate(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, dir, "app.toml", "[log]\nlevel = \"debug\"\n"))
+	cfg, err := Read("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("log level = %q, want debug from CONFIG_FILE", cfg.Log.Level)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		body    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"missing file", "", "", nil, "failed to read config file"},
+		{"unsupported extension", "config.json", "{}", nil, "unsupported config file"},
+		{"unknown yaml key", "config.yaml", "server:\n  adr: \":80\"\n", nil, "field adr not found"},
+		{"unknown toml key", "config.toml", "[server]\nadr = \":80\"\n", nil, "unknown keys server.adr"},
+		{"bad yaml duration", "config.yaml", "chain:\n  poll_interval: 3\n", nil, "invalid duration"},
+		{"bad toml syntax", "config.toml", "[server\n", nil, "line 1"},
+		{"bad env values", "config.yaml", "", map[string]string{
+			"CONFIRMATIONS":                 "-1",
+			"LOG_FETCH_CONCURRENCY":         "four",
+			"WEBHOOK_ALLOW_PRIVATE_TARGETS": "yes",
+		}, "CONFIRMATIONS (chain.confirmations)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			path := filepath.Join(dir, "missing.yaml")
+			if tt.file != "" {
+				path = writeFile(t, dir, tt.file, tt.body)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			_, err := Read(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if tt.env != nil {
+				var validation *ValidationError
+				if !errors.As(err, &validation) || len(validation.Problems) != len(tt.env) {
+					t.Errorf("error = %#v, want a ValidationError with %d problems", err, len(tt.env))
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	cfg := Default()
+	cfg.Chain.ContractAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	cfg.Chain.LogFetchInterval = Duration{200 * time.Millisecond}
+	cfg.Auth.AdminAddresses = []string{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"}
+
+	for _, format := range []string{"yaml", "toml"} {
+		t.Run(format, func(t *testing.T) {
+			data, err := cfg.Encode(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := writeFile(t, t.TempDir(), "config."+format, string(data))
+			decoded := &Config{}
+			if err := decoded.readFile(path); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, cfg) {
+				t.Errorf("round trip changed the config:\n%s", data)
+			}
+		})
+	}
+	if _, err := cfg.Encode("json"); err == nil {
+		t.Error("Encode(json) succeeded, want error")
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir := isolate(t)
+	t.Setenv("CV_TEST_PRESET", "from environment")
+	for _, name := range []string{"CV_TEST_PLAIN", "CV_TEST_QUOTED", "CV_TEST_COMMENT", "CV_TEST_HASH"} {
+		t.Setenv(name, "")
+	}
+	writeFile(t, dir, ".env", `
+# comment
+CV_TEST_PLAIN=plain
+CV_TEST_QUOTED = "quoted # not a comment"
+CV_TEST_COMMENT=2000  # trailing comment
+CV_TEST_HASH=abc#def
+CV_TEST_PRESET=from file
+not a variable
+`)
+	loadEnvFile(".env")
+
+	tests := map[string]string{
+		"CV_TEST_PLAIN":   "plain",
+		"CV_TEST_QUOTED":  "quoted # not a comment",
+		"CV_TEST_COMMENT": "2000",
+		"CV_TEST_HASH":    "abc#def",
+		"CV_TEST_PRESET":  "from environment",
+	}
+	for name, want := range tests {
+		if got := os.Getenv(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envVar 一个环境变量及其对应的配置项
+type envVar struct {
+	name string                   // 环境变量名
+	key  string                   // 配置文件中的键，用于错误信息
+	set  func(value string) error // 解析并写入配置
+}
+
+// envVars 支持的环境变量，环境变量优先于配置文件
+// 时长类变量沿用带单位后缀的整数（如 AUTH_SESSION_TTL_HOURS=24），配置文件中写作 "24h"
+func (c *Config) envVars() []envVar {
+	return []envVar{
+		{"SERVER_ADDR", "server.addr", setString(&c.Server.Addr)},
+		{"CORS_ALLOWED_ORIGINS", "server.cors_origins", setList(&c.Server.CORSOrigins)},
+
+		{"DATABASE_URL", "database.url", setString(&c.Database.URL)},
+
+		{"ETH_RPC_URL", "chain.rpc_url", setString(&c.Chain.RPCURL)},
+		{"CONTRACT_ADDRESS", "chain.contract_address", setString(&c.Chain.ContractAddress)},
+		{"START_BLOCK", "chain.start_block", setUint64(&c.Chain.StartBlock)},
+		{"CONFIRMATIONS", "chain.confirmations", setUint64(&c.Chain.Confirmations)},
+		{"POLL_INTERVAL_SECONDS", "chain.poll_interval", setDuration(&c.Chain.PollInterval, time.Second)},
+		{"LOG_BATCH_SIZE", "chain.log_batch_size", setUint64(&c.Chain.LogBatchSize)},
+		{"LOG_FETCH_CONCURRENCY", "chain.log_fetch_concurrency", setInt(&c.Chain.LogFetchConcurrency)},
+		{"LOG_FETCH_RETRIES", "chain.log_fetch_retries", setInt(&c.Chain.LogFetchRetries)},
+		{"LOG_FETCH_INTERVAL_MS", "chain.log_fetch_interval", setDuration(&c.Chain.LogFetchInterval, time.Millisecond)},
+
+		{"RELAYER_PRIVATE_KEY", "relayer.private_key", setString(&c.Relayer.PrivateKey)},
+		{"RELAYER_KEYSTORE", "relayer.keystore", setString(&c.Relayer.Keystore)},
+		{"RELAYER_KEYSTORE_PASSWORD", "relayer.keystore_password", setString(&c.Relayer.KeystorePassword)},
+		{"RELAYER_GAS_BUFFER_PERCENT", "relayer.gas_buffer_percent", setUint64(&c.Relayer.GasBufferPercent)},
+
+		{"SIWE_DOMAIN", "auth.domain", setString(&c.Auth.Domain)},
+		{"SIWE_CHAIN_ID", "auth.chain_id", setUint64(&c.Auth.ChainID)},
+		{"AUTH_SESSION_TTL_HOURS", "auth.session_ttl", setDuration(&c.Auth.SessionTTL, time.Hour)},
+		{"ADMIN_ADDRESSES", "auth.admin_addresses", setList(&c.Auth.AdminAddresses)},
+
+		{"BLOB_BACKEND", "images.backend", setString(&c.Images.Backend)},
+		{"BLOB_DIR", "images.dir", setString(&c.Images.Dir)},
+		{"IMAGE_MAX_MB", "images.max_mb", setInt(&c.Images.MaxMB)},
+		{"IMAGE_MAX_PIXELS", "images.max_pixels", setInt(&c.Images.MaxPixels)},
+		{"IMAGE_MAX_PER_ASSET", "images.max_per_asset", setInt(&c.Images.MaxPerAsset)},
+
+		{"IPFS_API_URL", "ipfs.api_url", setString(&c.IPFS.APIURL)},
+		{"IPFS_TIMEOUT_SECONDS", "ipfs.timeout", setDuration(&c.IPFS.Timeout, time.Second)},
+
+		{"PRICE_FEED_FILE", "prices.feed_file", setString(&c.Prices.FeedFile)},
+		{"ETH_PRICES", "prices.eth_prices", setString(&c.Prices.ETHPrices)},
+		{"PRICE_CURRENCY", "prices.currency", setString(&c.Prices.Currency)},
+
+		{"REFUND_WINDOW_PAID_HOURS", "orders.paid_refund_window", setDuration(&c.Orders.PaidRefundWindow, time.Hour)},
+		{"REFUND_WINDOW_DELIVERED_HOURS", "orders.delivered_refund_window", setDuration(&c.Orders.DeliveredRefundWindow, time.Hour)},
+
+		{"SEARCH_INDEX_INTERVAL_SECONDS", "search.index_interval", setDuration(&c.Search.IndexInterval, time.Second)},
+		{"METADATA_MAX_ATTEMPTS", "search.metadata_max_attempts", setInt(&c.Search.MetadataMaxAttempts)},
+
+		{"WEBHOOK_MAX_ATTEMPTS", "webhooks.max_attempts", setInt(&c.Webhooks.MaxAttempts)},
+		{"WEBHOOK_TIMEOUT_SECONDS", "webhooks.timeout", setDuration(&c.Webhooks.Timeout, time.Second)},
+		{"WEBHOOK_DISPATCH_INTERVAL_SECONDS", "webhooks.dispatch_interval", setDuration(&c.Webhooks.DispatchInterval, time.Second)},
+		{"WEBHOOK_ALLOW_PRIVATE_TARGETS", "webhooks.allow_private_targets", setBool(&c.Webhooks.AllowPrivateTargets)},
+
+		{"PAGE_DEFAULT_LIMIT", "pagination.default_limit", setInt(&c.Pagination.DefaultLimit)},
+		{"PAGE_MAX_LIMIT", "pagination.max_limit", setInt(&c.Pagination.MaxLimit)},
+
+		{"LOG_LEVEL", "log.level", setString(&c.Log.Level)},
+		{"LOG_FORMAT", "log.format", setString(&c.Log.Format)},
+	}
+}
+
+// applyEnv 用已设置的环境变量覆盖配置，返回格式错误的变量
+// 空值视为未设置
+func (c *Config) applyEnv() []string {
+	var problems []string
+	for _, v := range c.envVars() {
+		value := strings.TrimSpace(os.Getenv(v.name))
+		if value == "" {
+			continue
+		}
+		if err := v.set(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", v.name, v.key, err))
+		}
+	}
+	return problems
+}
+
+func setString(dst *string) func(string) error {
+	return func(value string) error {
+		*dst = value
+		return nil
+	}
+}
+
+// setList 逗号分隔的列表，忽略空项
+func setList(dst *[]string) func(string) error {
+	return func(value string) error {
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		*dst = values
+		return nil
+	}
+}
+
+func setInt(dst *int) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*dst = parsed
+		return nil
+	}
+}
+
+func setUint64(dst *uint64) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a non-negative integer", value)
+		}
+		*dst = parsed
+		return nil
+	}
+}
+
+// setBool true/false、1/0
+func setBool(dst *bool) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*dst = parsed
+		return nil
+	}
+}
+
+// setDuration 以 unit 为单位的整数
+func setDuration(dst *Duration, unit time.Duration) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		dst.Duration = time.Duration(parsed) * unit
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEnvVarsAreUnique(t *testing.T) {
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, v := range Default().envVars() {
+		if names[v.name] || keys[v.key] {
+			t.Errorf("duplicate environment variable %s (%s)", v.name, v.key)
+		}
+		names[v.name], keys[v.key] = true, true
+	}
+}
+
+func TestSetList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"a", []string{"a"}},
+		{" a , b,,c ", []string{"a", "b", "c"}},
+		{",", nil},
+	}
+	for _, tt := range tests {
+		got := []string{"previous"}
+		if err := setList(&got)(tt.value); err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("setList(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetNumbers(t *testing.T) {
+	tests := []struct {
+		value   string
+		asInt   int
+		intOK   bool
+		asUint  uint64
+		uintOK  bool
+		asBool  bool
+		boolOK  bool
+		seconds time.Duration
+	}{
+		{"0", 0, true, 0, true, false, true, 0},
+		{"1", 1, true, 1, true, true, true, time.Second},
+		{"42", 42, true, 42, true, false, false, 42 * time.Second},
+		{"-5", -5, true, 0, false, false, false, -5 * time.Second},
+		{"true", 0, false, 0, false, true, true, 0},
+		{"FALSE", 0, false, 0, false, false, true, 0},
+		{"1.5", 0, false, 0, false, false, false, 0},
+		{"18446744073709551615", 0, false, 18446744073709551615, true, false, false, 0},
+	}
+	for _, tt := range tests {
+		var i int
+		if err := setInt(&i)(tt.value); (err == nil) != tt.intOK || (tt.intOK && i != tt.asInt) {
+			t.Errorf("setInt(%q) = %d, %v", tt.value, i, err)
+		}
+		var u uint64
+		if err := setUint64(&u)(tt.value); (err == nil) != tt.uintOK || (tt.uintOK && u != tt.asUint) {
+			t.Errorf("setUint64(%q) = %d, %v", tt.value, u, err)
+		}
+		var b bool
+		if err := setBool(&b)(tt.value); (err == nil) != tt.boolOK || (tt.boolOK && b != tt.asBool) {
+			t.Errorf("setBool(%q) = %v, %v", tt.value, b, err)
+		}
+		var d Duration
+		if err := setDuration(&d, time.Second)(tt.value); (err == nil) != tt.intOK || (tt.intOK && d.Duration != tt.seconds) {
+			t.Errorf("setDuration(%q, 1s) = %s, %v", tt.value, d, err)
+		}
+	}
+
+	var d Duration
+	if err := setDuration(&d, time.Hour)("24"); err != nil || d.Duration != 24*time.Hour {
+		t.Errorf("setDuration(24, 1h) = %s, %v", d, err)
+	}
+}
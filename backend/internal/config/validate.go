@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ValidationError 配置无效，Problems 逐项列出原因
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+var privateKeyPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+
+// Validate 校验全部配置项，有问题时返回 *ValidationError
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		check(false, "server.addr", "%q is not a host:port address", c.Server.Addr)
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		check(origin == "*" || isOrigin(origin), "server.cors_origins",
+			"%q must be * or an origin such as https://app.example.com", origin)
+	}
+
+	check(c.Database.URL != "", "database.url", "must not be empty")
+
+	check(isRPCURL(c.Chain.RPCURL), "chain.rpc_url",
+		"%q must be an http(s) or ws(s) URL or an IPC endpoint path", c.Chain.RPCURL)
+	if c.Chain.ContractAddress != "" {
+		if !common.IsHexAddress(c.Chain.ContractAddress) {
+			check(false, "chain.contract_address", "%q is not a valid address", c.Chain.ContractAddress)
+		} else {
+			check(common.HexToAddress(c.Chain.ContractAddress) != (common.Address{}), "chain.contract_address",
+				"must not be the zero address")
+		}
+	}
+	check(c.Chain.PollInterval.Duration > 0, "chain.poll_interval", "must be positive")
+	check(c.Chain.LogBatchSize > 0, "chain.log_batch_size", "must be positive")
+	check(c.Chain.LogFetchConcurrency > 0, "chain.log_fetch_concurrency", "must be positive")
+	check(c.Chain.LogFetchRetries >= 0, "chain.log_fetch_retries", "must not be negative")
+	check(c.Chain.LogFetchInterval.Duration >= 0, "chain.log_fetch_interval", "must not be negative")
+
+	if c.Relayer.PrivateKey != "" {
+		check(privateKeyPattern.MatchString(c.Relayer.PrivateKey), "relayer.private_key",
+			"must be 64 hexadecimal characters")
+	}
+
+	check(c.Auth.Domain != "", "auth.domain", "must not be empty")
+	check(c.Auth.SessionTTL.Duration > 0, "auth.session_ttl", "must be positive")
+	for _, address := range c.Auth.AdminAddresses {
+		check(common.IsHexAddress(address), "auth.admin_addresses", "%q is not a valid address", address)
+	}
+
+	check(c.Images.Backend == "local" || c.Images.Backend == "ipfs", "images.backend",
+		"%q must be local or ipfs", c.Images.Backend)
+	check(c.Images.Dir != "", "images.dir", "must not be empty")
+	check(c.Images.MaxMB > 0, "images.max_mb", "must be positive")
+	check(c.Images.MaxPixels > 0, "images.max_pixels", "must be positive")
+	check(c.Images.MaxPerAsset > 0, "images.max_per_asset", "must be positive")
+
+	check(isHTTPURL(c.IPFS.APIURL), "ipfs.api_url", "%q must be an http(s) URL", c.IPFS.APIURL)
+	check(c.IPFS.Timeout.Duration > 0, "ipfs.timeout", "must be positive")
+
+	check(c.Orders.PaidRefundWindow.Duration > 0, "orders.paid_refund_window", "must be positive")
+	check(c.Orders.DeliveredRefundWindow.Duration > 0, "orders.delivered_refund_window", "must be positive")
+
+	check(c.Search.IndexInterval.Duration > 0, "search.index_interval", "must be positive")
+	check(c.Search.MetadataMaxAttempts > 0, "search.metadata_max_attempts", "must be positive")
+
+	check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts", "must be positive")
+	check(c.Webhooks.Timeout.Duration > 0, "webhooks.timeout", "must be positive")
+	check(c.Webhooks.DispatchInterval.Duration > 0, "webhooks.dispatch_interval", "must be positive")
+
+	check(c.Pagination.DefaultLimit > 0, "pagination.default_limit", "must be positive")
+	check(c.Pagination.MaxLimit >= c.Pagination.DefaultLimit, "pagination.max_limit",
+		"must not be less than default_limit (%d)", c.Pagination.DefaultLimit)
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level",
+		"%q must be debug, info, warn or error", c.Log.Level)
+	switch strings.ToLower(c.Log.Format) {
+	case "text", "json":
+	default:
+		check(false, "log.format", "%q must be text or json", c.Log.Format)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isRPCURL go-ethereum 的 rpc.Dial 支持的地址：http(s)、ws(s) 或 IPC 文件路径
+func isRPCURL(value string) bool {
+	if strings.HasSuffix(value, ".ipc") {
+		return true
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+		return true
+	}
+	return false
+}
+
+// isOrigin 浏览器 Origin 请求头的格式：scheme://host[:port]，不含路径
+func isOrigin(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && isHTTPURL(value) && u.User == nil && u.Path == "" && u.RawQuery == "" && u.Fragment == ""
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string // 问题所属的配置项，为空表示有效
+	}{
+		{"defaults", func(c *Config) {}, ""},
+		{"explicit origins", func(c *Config) { c.Server.CORSOrigins = []string{"https://app.example.com", "http://localhost:5173"} }, ""},
+		{"origin with path", func(c *Config) { c.Server.CORSOrigins = []string{"https://app.example.com/"} }, "server.cors_origins"},
+		{"addr without port", func(c *Config) { c.Server.Addr = "localhost" }, "server.addr"},
+		{"empty database", func(c *Config) { c.Database.URL = "" }, "database.url"},
+		{"ipc endpoint", func(c *Config) { c.Chain.RPCURL = "/var/run/geth.ipc" }, ""},
+		{"websocket rpc", func(c *Config) { c.Chain.RPCURL = "wss://node.example.com" }, ""},
+		{"rpc without scheme", func(c *Config) { c.Chain.RPCURL = "127.0.0.1:8545" }, "chain.rpc_url"},
+		{"contract address", func(c *Config) { c.Chain.ContractAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" }, ""},
+		{"bad contract address", func(c *Config) { c.Chain.ContractAddress = "0x1234" }, "chain.contract_address"},
+		{"zero contract address", func(c *Config) { c.Chain.ContractAddress = "0x0000000000000000000000000000000000000000" }, "chain.contract_address"},
+		{"zero poll interval", func(c *Config) { c.Chain.PollInterval = Duration{} }, "chain.poll_interval"},
+		{"zero batch size", func(c *Config) { c.Chain.LogBatchSize = 0 }, "chain.log_batch_size"},
+		{"negative retries", func(c *Config) { c.Chain.LogFetchRetries = -1 }, "chain.log_fetch_retries"},
+		{"negative fetch interval", func(c *Config) { c.Chain.LogFetchInterval = Duration{-time.Second} }, "chain.log_fetch_interval"},
+		{"private key", func(c *Config) { c.Relayer.PrivateKey = "0x" + strings.Repeat("a", 64) }, ""},
+		{"short private key", func(c *Config) { c.Relayer.PrivateKey = "abc" }, "relayer.private_key"},
+		{"bad admin address", func(c *Config) { c.Auth.AdminAddresses = []string{"admin"} }, "auth.admin_addresses"},
+		{"empty domain", func(c *Config) { c.Auth.Domain = "" }, "auth.domain"},
+		{"unknown image backend", func(c *Config) { c.Images.Backend = "s3" }, "images.backend"},
+		{"zero image size", func(c *Config) { c.Images.MaxMB = 0 }, "images.max_mb"},
+		{"ipfs not http", func(c *Config) { c.IPFS.APIURL = "ipfs://localhost" }, "ipfs.api_url"},
+		{"zero refund window", func(c *Config) { c.Orders.PaidRefundWindow = Duration{} }, "orders.paid_refund_window"},
+		{"zero webhook attempts", func(c *Config) { c.Webhooks.MaxAttempts = 0 }, "webhooks.max_attempts"},
+		{"max limit below default", func(c *Config) { c.Pagination.MaxLimit = 10 }, "pagination.max_limit"},
+		{"unknown log level", func(c *Config) { c.Log.Level = "verbose" }, "log.level"},
+		{"uppercase log format", func(c *Config) { c.Log.Format = "JSON" }, ""},
+		{"unknown log format", func(c *Config) { c.Log.Format = "xml" }, "log.format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var validation *ValidationError
+			if !errors.As(err, &validation) || len(validation.Problems) != 1 || !strings.HasPrefix(validation.Problems[0], tt.want+": ") {
+				t.Errorf("Validate() = %v, want a single problem for %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Addr = ""
+	cfg.Database.URL = ""
+	cfg.Log.Format = "xml"
+
+	var validation *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &validation) || len(validation.Problems) != 3 {
+		t.Fatalf("Validate() = %v, want 3 problems", err)
+	}
+	if msg := validation.Error(); !strings.HasPrefix(msg, "invalid configuration: server.addr") || !strings.Contains(msg, "; database.url") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
@@ -1,20 +1,22 @@
 package database
 
 import (
-	"chain-vault-backend/internal/model"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"strings"
 
+	"chain-vault-backend/internal/logging"
+
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
+// Connect 连接数据库，包含 @tcp/@udp/@unix 的 DSN 使用 MySQL，否则视为 SQLite 文件路径
+// 表结构由 cmd/migrate 管理，这里不做迁移
 func Connect(databaseURL string) error {
 	var err error
 	
@@ -27,24 +29,27 @@ func Connect(databaseURL string) error {
 	}
 
 	DB, err = gorm.Open(dialector, &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logging.NewGormLogger(),
 	})
 	
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// 自动迁移（包括新添加的 Images 字段）
-	if err := DB.AutoMigrate(
-		&model.Asset{},
-		&model.Brand{},
-		&model.Order{},
-		&model.AssetOwnerHistory{},
-	); err != nil {
-		return fmt.Errorf("failed to migrate database: %w", err)
+	// 迁移 005 和资产搜索依赖 SQLite 的 FTS5 模块，go-sqlite3 默认不编译该模块：
+	// 缺少时迁移会失败、API 无法启动，这里直接报错并提示编译标签
+	if DB.Dialector.Name() == "sqlite" {
+		var enabled int
+		if err := DB.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled).Error; err != nil {
+			return fmt.Errorf("failed to check SQLite FTS5 support: %w", err)
+		}
+		if enabled == 0 {
+			return fmt.Errorf("SQLite driver was built without FTS5, which asset search and migration 005 require; " +
+				"rebuild with -tags sqlite_fts5 (the Makefile targets already set it)")
+		}
 	}
 
-	log.Println("Database connected and migrated successfully")
+	slog.Info("database connected", "dialect", DB.Dialector.Name())
 	return nil
 }
 
@@ -0,0 +1,147 @@
+// Package events 进程内的事件总线，把监听器写入数据库的链上变化推送给 SSE 和 WebSocket 订阅者
+//
+// 每个事件对应一条已处理的合约日志，ID 为 chain_events 表的主键，随处理顺序递增且重启后不变，
+// 客户端断线后带上最后收到的 ID 重连，从数据库补发之后的事件。
+// 订阅者的缓冲区满时总线关闭该订阅，由客户端重连后补发，避免慢客户端阻塞监听器。
+package events
+
+import (
+	"sync"
+
+	"chain-vault-backend/internal/model"
+)
+
+// 非链上日志的事件类型，ID 为 0，不能用于续传
+const (
+	// TypeReorg 链重组，ancestor 之后的数据已按链上状态重建，客户端应重新拉取列表
+	TypeReorg = "ChainReorg"
+)
+
+// subscriberBuffer 每个订阅者缓冲的事件数
+const subscriberBuffer = 256
+
+// Event 推送给客户端的事件
+// Type 为合约事件名（如 AssetTransferred、OrderPaid、BrandAuthorized），Data 为相关资产、订单或品牌的当前状态
+type Event struct {
+	ID           uint64          `json:"id"`
+	Type         string          `json:"type"`
+	AssetID      uint64          `json:"assetId,omitempty"`
+	OrderID      uint64          `json:"orderId,omitempty"`
+	Brand        model.Address   `json:"brand,omitempty"`
+	Owners       []model.Address `json:"owners,omitempty"`       // 资产所有者，转移事件包含原所有者和新所有者
+	Participants []model.Address `json:"participants,omitempty"` // 订单的卖家和买家
+	TxHash       string          `json:"txHash,omitempty"`
+	BlockNum     uint64          `json:"blockNum"`
+	Data         interface{}     `json:"data,omitempty"`
+}
+
+// Filter 订阅条件：Types 限定事件类型；AssetIDs、Owners、Participants 满足任意一项即可，都为空时不限制
+type Filter struct {
+	Types        []string
+	AssetIDs     []uint64
+	Owners       []model.Address
+	Participants []model.Address
+}
+
+// Match 事件是否满足订阅条件，链重组事件总是推送
+func (f Filter) Match(ev Event) bool {
+	if ev.Type == TypeReorg {
+		return true
+	}
+	if len(f.Types) > 0 && !contains(f.Types, ev.Type) {
+		return false
+	}
+	if len(f.AssetIDs) == 0 && len(f.Owners) == 0 && len(f.Participants) == 0 {
+		return true
+	}
+	if ev.AssetID != 0 && contains(f.AssetIDs, ev.AssetID) {
+		return true
+	}
+	for _, owner := range ev.Owners {
+		if contains(f.Owners, owner) {
+			return true
+		}
+	}
+	for _, participant := range ev.Participants {
+		if contains(f.Participants, participant) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains[T comparable](items []T, v T) bool {
+	for _, item := range items {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription 一个订阅，C 关闭表示订阅已结束（取消或缓冲区溢出）
+type Subscription struct {
+	C <-chan Event
+
+	bus    *Bus
+	ch     chan Event
+	filter Filter
+}
+
+// Close 取消订阅
+func (s *Subscription) Close() {
+	s.bus.remove(s)
+}
+
+// Bus 事件总线
+type Bus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+var defaultBus = NewBus()
+
+// Default 进程内共用的事件总线，监听器发布，推送接口订阅
+func Default() *Bus {
+	return defaultBus
+}
+
+// Subscribe 按条件订阅之后发布的事件
+func (b *Bus) Subscribe(filter Filter) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, bus: b, ch: ch, filter: filter}
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// Publish 把事件发给所有匹配的订阅者，不会阻塞；缓冲区已满的订阅者被关闭
+func (b *Bus) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if !sub.filter.Match(ev) {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
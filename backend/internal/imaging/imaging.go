@@ -0,0 +1,225 @@
+// Package imaging 处理上传的商品图片：校验格式与大小、去除 GPS 信息、生成缩略图和感知哈希
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/chai2010/webp"
+	xdraw "golang.org/x/image/draw"
+)
+
+var (
+	// ErrUnsupported 不是可解码的 JPEG、PNG 或 WebP 图片
+	ErrUnsupported = errors.New("unsupported image")
+	// ErrTooLarge 文件或像素数超过限制
+	ErrTooLarge = errors.New("image too large")
+)
+
+// 支持的图片类型
+const (
+	MimeJPEG = "image/jpeg"
+	MimePNG  = "image/png"
+	MimeWebP = "image/webp"
+)
+
+// 生成的图片规格，长边不超过 MaxSize，统一编码为 WebP
+var Variants = []VariantSpec{
+	{Name: "thumbnail", MaxSize: 320},
+	{Name: "medium", MaxSize: 1024},
+}
+
+// variantQuality WebP 有损压缩质量
+const variantQuality = 80
+
+// VariantSpec 缩放规格
+type VariantSpec struct {
+	Name    string
+	MaxSize int
+}
+
+// Options 处理限制
+type Options struct {
+	MaxBytes  int // 单张图片的最大字节数
+	MaxPixels int // 单张图片的最大像素数，防止解压炸弹
+}
+
+// DefaultOptions 默认限制：10 MB、4000 万像素
+func DefaultOptions() Options {
+	return Options{
+		MaxBytes:  10 << 20,
+		MaxPixels: 40_000_000,
+	}
+}
+
+// Image 去除 GPS 信息后的原图
+type Image struct {
+	Data     []byte
+	MimeType string
+	Width    int // 按 EXIF 方向旋转后的尺寸
+	Height   int
+
+	decoded     image.Image
+	orientation int
+}
+
+// Variant 缩放后的图片
+type Variant struct {
+	Name     string
+	Data     []byte
+	MimeType string
+	Width    int
+	Height   int
+}
+
+// Result 完整处理结果
+type Result struct {
+	Image
+	Variants []Variant
+	PHash    uint64
+}
+
+// Sanitize 校验图片并去除 GPS 信息，不生成缩放图
+func Sanitize(data []byte, opts Options) (*Image, error) {
+	if opts.MaxBytes > 0 && len(data) > opts.MaxBytes {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrTooLarge, len(data), opts.MaxBytes)
+	}
+	mimeType := sniff(data)
+	if mimeType == "" {
+		return nil, fmt.Errorf("%w: not a JPEG, PNG or WebP file", ErrUnsupported)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if opts.MaxPixels > 0 && config.Width*config.Height > opts.MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds the limit of %d pixels", ErrTooLarge, config.Width, config.Height, opts.MaxPixels)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	cleaned, orientation, err := stripMetadata(mimeType, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	width, height := config.Width, config.Height
+	if orientation >= 5 {
+		width, height = height, width
+	}
+	return &Image{
+		Data:        cleaned,
+		MimeType:    mimeType,
+		Width:       width,
+		Height:      height,
+		decoded:     decoded,
+		orientation: orientation,
+	}, nil
+}
+
+// Process 校验图片、去除 GPS 信息，并生成缩放图和感知哈希
+func Process(data []byte, opts Options) (*Result, error) {
+	img, err := Sanitize(data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Image: *img}
+	var largest image.Image
+	for _, spec := range Variants {
+		scaled := resize(img.decoded, img.orientation, spec.MaxSize)
+		encoded, err := webp.EncodeRGBA(scaled, variantQuality)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s variant: %w", spec.Name, err)
+		}
+		bounds := scaled.Bounds()
+		result.Variants = append(result.Variants, Variant{
+			Name:     spec.Name,
+			Data:     encoded,
+			MimeType: MimeWebP,
+			Width:    bounds.Dx(),
+			Height:   bounds.Dy(),
+		})
+		largest = scaled
+	}
+	result.PHash = PHash(largest)
+	return result, nil
+}
+
+// sniff 按文件头识别图片类型
+func sniff(data []byte) string {
+	switch {
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return MimeJPEG
+	case len(data) >= 8 && string(data[:8]) == "\x89PNG\r\n\x1a\n":
+		return MimePNG
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return MimeWebP
+	default:
+		return ""
+	}
+}
+
+// resize 按 EXIF 方向旋转并缩放到长边不超过 maxSize，不放大
+func resize(src image.Image, orientation, maxSize int) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); longest > maxSize {
+		width = max(1, width*maxSize/longest)
+		height = max(1, height*maxSize/longest)
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(scaled, scaled.Bounds(), src, bounds.Min, draw.Src)
+	} else {
+		xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Src, nil)
+	}
+	return orient(scaled, orientation)
+}
+
+// orient 按 EXIF Orientation（1-8）变换图片，使其按正常方向显示
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90°
+				dx, dy = h-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转 90°
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
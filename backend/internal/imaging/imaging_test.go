@@ -0,0 +1,216 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/chai2010/webp"
+)
+
+// testImage 带渐变和色块的图片，各区域可区分
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 128, A: 255}
+			if x < width/4 && y < height/4 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t testing.TB, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t testing.TB, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeWebP(t testing.TB, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, &webp.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// rotatedJPEG 带 EXIF 方向 6（需顺时针旋转 90°）和 GPS 信息的 JPEG
+func rotatedJPEG(t testing.TB, width, height int) []byte {
+	t.Helper()
+	exif := append(append([]byte(nil), exifHeader...), buildTIFF(binary.LittleEndian, 6)...)
+	return withJPEGSegments(encodeJPEG(t, testImage(width, height)), exif)
+}
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte{0xFF, 0xD8, 0xFF, 0xE0}, MimeJPEG},
+		{[]byte("\x89PNG\r\n\x1a\n"), MimePNG},
+		{[]byte("RIFF\x00\x00\x00\x00WEBP"), MimeWebP},
+		{[]byte("RIFF\x00\x00\x00\x00WAVE"), ""},
+		{[]byte("GIF89a"), ""},
+		{[]byte{0xFF, 0xD8}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := sniff(tt.data); got != tt.want {
+			t.Errorf("sniff(%q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          []byte
+		mimeType      string
+		width, height int
+		variants      [][2]int
+	}{
+		{"png landscape", encodePNG(t, testImage(640, 320)), MimePNG, 640, 320, [][2]int{{320, 160}, {640, 320}}},
+		{"jpeg larger than medium", encodeJPEG(t, testImage(2048, 1024)), MimeJPEG, 2048, 1024, [][2]int{{320, 160}, {1024, 512}}},
+		{"webp smaller than thumbnail", encodeWebP(t, testImage(100, 60)), MimeWebP, 100, 60, [][2]int{{100, 60}, {100, 60}}},
+		{"jpeg rotated by exif", rotatedJPEG(t, 640, 320), MimeJPEG, 320, 640, [][2]int{{160, 320}, {320, 640}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Process(tt.data, DefaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.MimeType != tt.mimeType || result.Width != tt.width || result.Height != tt.height {
+				t.Errorf("image = %s %dx%d, want %s %dx%d", result.MimeType, result.Width, result.Height, tt.mimeType, tt.width, tt.height)
+			}
+			if bytes.Contains(result.Data, gpsSecret) {
+				t.Error("GPS data survived")
+			}
+			if len(result.Variants) != len(Variants) {
+				t.Fatalf("got %d variants, want %d", len(result.Variants), len(Variants))
+			}
+			for i, variant := range result.Variants {
+				if variant.Name != Variants[i].Name || variant.MimeType != MimeWebP {
+					t.Errorf("variant %d = %s %s", i, variant.Name, variant.MimeType)
+				}
+				if variant.Width != tt.variants[i][0] || variant.Height != tt.variants[i][1] {
+					t.Errorf("%s = %dx%d, want %dx%d", variant.Name, variant.Width, variant.Height, tt.variants[i][0], tt.variants[i][1])
+				}
+				decoded, err := webp.Decode(bytes.NewReader(variant.Data))
+				if err != nil {
+					t.Fatalf("%s does not decode: %v", variant.Name, err)
+				}
+				if b := decoded.Bounds(); b.Dx() != variant.Width || b.Dy() != variant.Height {
+					t.Errorf("%s decodes as %dx%d", variant.Name, b.Dx(), b.Dy())
+				}
+			}
+		})
+	}
+}
+
+func TestProcessRejects(t *testing.T) {
+	png := encodePNG(t, testImage(100, 100))
+	tests := []struct {
+		name    string
+		data    []byte
+		opts    Options
+		wantErr error
+	}{
+		{"too many bytes", png, Options{MaxBytes: len(png) - 1}, ErrTooLarge},
+		{"too many pixels", png, Options{MaxPixels: 100*100 - 1}, ErrTooLarge},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), DefaultOptions(), ErrUnsupported},
+		{"empty", nil, DefaultOptions(), ErrUnsupported},
+		{"png header only", png[:8], DefaultOptions(), ErrUnsupported},
+		{"truncated png", png[:len(png)/2], DefaultOptions(), ErrUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Process(tt.data, tt.opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 2x1 的图片，左红右绿；变换后检查红色像素的位置
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{R: 255, A: 255})
+	src.SetRGBA(1, 0, color.RGBA{G: 255, A: 255})
+
+	tests := []struct {
+		orientation   int
+		width, height int
+		redX, redY    int
+	}{
+		{1, 2, 1, 0, 0},
+		{2, 2, 1, 1, 0},
+		{3, 2, 1, 1, 0},
+		{4, 2, 1, 0, 0},
+		{5, 1, 2, 0, 0},
+		{6, 1, 2, 0, 0},
+		{7, 1, 2, 0, 1},
+		{8, 1, 2, 0, 1},
+		{9, 2, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		dst := orient(src, tt.orientation)
+		if b := dst.Bounds(); b.Dx() != tt.width || b.Dy() != tt.height {
+			t.Errorf("orientation %d: size %dx%d, want %dx%d", tt.orientation, b.Dx(), b.Dy(), tt.width, tt.height)
+			continue
+		}
+		if dst.RGBAAt(tt.redX, tt.redY).R != 255 {
+			t.Errorf("orientation %d: red pixel is not at (%d, %d)", tt.orientation, tt.redX, tt.redY)
+		}
+	}
+}
+
+// FuzzProcess 任意输入都不能导致 panic；处理成功时输出必须是同类型、可解码且不含 GPS 数据的图片
+func FuzzProcess(f *testing.F) {
+	f.Add(encodePNG(f, testImage(16, 8)))
+	f.Add(encodeJPEG(f, testImage(16, 8)))
+	f.Add(encodeWebP(f, testImage(16, 8)))
+	f.Add(rotatedJPEG(f, 16, 8))
+	f.Add(withPNGChunks(encodePNG(f, testImage(8, 8)), [2][]byte{[]byte("eXIf"), buildTIFF(binary.BigEndian, 8)}))
+
+	opts := Options{MaxBytes: 1 << 20, MaxPixels: 1 << 16}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		result, err := Process(data, opts)
+		if err != nil {
+			if !errors.Is(err, ErrUnsupported) && !errors.Is(err, ErrTooLarge) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+		if sniff(result.Data) != result.MimeType {
+			t.Fatalf("output is not %s", result.MimeType)
+		}
+		if _, _, err := image.DecodeConfig(bytes.NewReader(result.Data)); err != nil {
+			t.Fatalf("output does not decode: %v", err)
+		}
+		if len(result.Variants) != len(Variants) {
+			t.Fatalf("got %d variants", len(result.Variants))
+		}
+	})
+}
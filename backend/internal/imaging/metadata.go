@@ -0,0 +1,254 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// 去除照片中的位置信息，保护卖家隐私
+// EXIF 中的 GPS 子目录被清空，其他 EXIF 信息（方向、相机型号等）保留；XMP 可能包含位置，整段删除
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	xmpHeaders = [][]byte{
+		[]byte("http://ns.adobe.com/xap/1.0/\x00"),
+		[]byte("http://ns.adobe.com/xmp/extension/\x00"),
+	}
+	errTruncated = errors.New("truncated image data")
+)
+
+// stripMetadata 返回去除位置信息后的图片数据，以及 EXIF 中的方向（未设置时为 1）
+func stripMetadata(mimeType string, data []byte) ([]byte, int, error) {
+	switch mimeType {
+	case MimeJPEG:
+		return stripJPEG(data)
+	case MimePNG:
+		return stripPNG(data)
+	case MimeWebP:
+		return stripWebP(data)
+	default:
+		return data, 1, nil
+	}
+}
+
+// stripJPEG 处理 APP1 段：EXIF 段清空 GPS，XMP 段删除
+func stripJPEG(data []byte) ([]byte, int, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...) // SOI
+	orientation := 1
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return nil, 0, errors.New("invalid JPEG marker")
+		}
+		// 标记前可以有任意个 0xFF 填充
+		for pos+1 < len(data) && data[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+1 >= len(data) {
+			return nil, 0, errTruncated
+		}
+		marker := data[pos+1]
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, data[pos:pos+2]...) // 没有长度的标记
+			pos += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// SOS 之后是压缩数据，原样保留
+			out = append(out, data[pos:]...)
+			return out, orientation, nil
+		}
+		if pos+4 > len(data) {
+			return nil, 0, errTruncated
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+		if end > len(data) || end < pos+4 {
+			return nil, 0, errTruncated
+		}
+		segment := data[pos:end]
+		pos = end
+
+		if marker == 0xE1 {
+			payload := segment[4:]
+			if hasAnyPrefix(payload, xmpHeaders) {
+				continue
+			}
+			if bytes.HasPrefix(payload, exifHeader) {
+				segment = append([]byte(nil), segment...)
+				orientation = stripExifGPS(segment[4+len(exifHeader):])
+			}
+		}
+		out = append(out, segment...)
+	}
+	return out, orientation, nil
+}
+
+// stripPNG 处理 eXIf 块中的 GPS，删除包含 XMP 的 iTXt 块
+func stripPNG(data []byte) ([]byte, int, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:8]...)
+	orientation := 1
+
+	pos := 8
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return nil, 0, errTruncated
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, 0, errTruncated
+		}
+		chunkType := string(data[pos+4 : pos+8])
+		chunk := data[pos:end]
+		pos = end
+
+		switch chunkType {
+		case "iTXt":
+			if bytes.HasPrefix(chunk[8:], []byte("XML:com.adobe.xmp\x00")) {
+				continue
+			}
+		case "eXIf":
+			chunk = append([]byte(nil), chunk...)
+			orientation = stripExifGPS(chunk[8 : 8+length])
+			binary.BigEndian.PutUint32(chunk[8+length:], crc32.ChecksumIEEE(chunk[4:8+length]))
+		}
+		out = append(out, chunk...)
+	}
+	return out, orientation, nil
+}
+
+// stripWebP 处理 EXIF 块中的 GPS，删除 XMP 块并清除 VP8X 中对应的标志
+func stripWebP(data []byte) ([]byte, int, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+	orientation := 1
+
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, 0, errTruncated
+		}
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size + size%2 // 块按偶数字节对齐
+		if size < 0 || end > len(data) {
+			return nil, 0, errTruncated
+		}
+		fourCC := string(data[pos : pos+4])
+		chunk := data[pos:end]
+		pos = end
+
+		switch fourCC {
+		case "XMP ":
+			continue
+		case "EXIF":
+			chunk = append([]byte(nil), chunk...)
+			tiff := chunk[8 : 8+size]
+			// 部分编码器在 TIFF 数据前加了 JPEG 风格的 Exif 头
+			tiff = bytes.TrimPrefix(tiff, exifHeader)
+			orientation = stripExifGPS(tiff)
+		case "VP8X":
+			if size >= 1 {
+				chunk = append([]byte(nil), chunk...)
+				chunk[8] &^= 0x04 // XMP 标志
+			}
+		}
+		out = append(out, chunk...)
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, orientation, nil
+}
+
+// stripExifGPS 原地清空 TIFF 格式 EXIF 数据中的 GPS 子目录，返回 IFD0 中的方向
+// 数据不完整时尽量处理，不返回错误
+func stripExifGPS(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	orientation := 1
+	ifd0 := int(order.Uint32(tiff[4:]))
+	if ifd0 < 8 || ifd0+2 > len(tiff) {
+		return orientation
+	}
+	count := int(order.Uint16(tiff[ifd0:]))
+	for i := 0; i < count; i++ {
+		entry := ifd0 + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		switch order.Uint16(tiff[entry:]) {
+		case 0x0112: // Orientation，SHORT
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				orientation = value
+			}
+		case 0x8825: // GPS IFD 指针
+			clearIFD(tiff, order, int(order.Uint32(tiff[entry+8:])))
+		}
+	}
+	return orientation
+}
+
+// clearIFD 清零一个 IFD 的所有条目及其引用的数据，并将条目数置为 0
+func clearIFD(tiff []byte, order binary.ByteOrder, offset int) {
+	if offset < 8 || offset+2 > len(tiff) {
+		return
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		size := tiffTypeSize(order.Uint16(tiff[entry+2:])) * int(order.Uint32(tiff[entry+4:]))
+		if size > 4 {
+			if start := int(order.Uint32(tiff[entry+8:])); start >= 8 && start+size <= len(tiff) {
+				clear(tiff[start : start+size])
+			}
+		}
+		clear(tiff[entry : entry+12])
+	}
+	order.PutUint16(tiff[offset:], 0)
+	// 原来的下一个 IFD 偏移位于条目之后，条目数为 0 后读取的是已清零的第一个条目，即没有下一个 IFD
+	if next := offset + 2 + count*12; next+4 <= len(tiff) {
+		clear(tiff[next : next+4])
+	}
+}
+
+// tiffTypeSize TIFF 数据类型的字节数
+func tiffTypeSize(t uint16) int {
+	switch t {
+	case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+		return 1
+	case 3, 8: // SHORT, SSHORT
+		return 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		return 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		return 8
+	default:
+		return 0
+	}
+}
+
+func hasAnyPrefix(data []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"testing"
+)
+
+// gpsSecret 写入 GPS 纬度的数据，去除后不应再出现在输出中
+var gpsSecret = []byte{0xde, 0xc0, 0xad, 0x0b}
+
+// buildTIFF 构造 EXIF 使用的 TIFF 数据：IFD0 含方向和 GPS 指针，GPS IFD 含纬度参考和纬度
+func buildTIFF(order binary.ByteOrder, orientation uint16) []byte {
+	const (
+		ifd0    = 8
+		gpsIFD  = ifd0 + 2 + 2*12 + 4
+		gpsData = gpsIFD + 2 + 2*12 + 4
+	)
+	tiff := make([]byte, gpsData+24)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], ifd0)
+
+	entry := func(at int, tag, typ uint16, count, value uint32) {
+		order.PutUint16(tiff[at:], tag)
+		order.PutUint16(tiff[at+2:], typ)
+		order.PutUint32(tiff[at+4:], count)
+		order.PutUint32(tiff[at+8:], value)
+	}
+	order.PutUint16(tiff[ifd0:], 2)
+	entry(ifd0+2, 0x0112, 3, 1, 0)
+	order.PutUint16(tiff[ifd0+2+8:], orientation) // SHORT 值靠左存放
+	entry(ifd0+2+12, 0x8825, 4, 1, gpsIFD)
+
+	order.PutUint16(tiff[gpsIFD:], 2)
+	entry(gpsIFD+2, 0x0001, 2, 2, 0)
+	copy(tiff[gpsIFD+2+8:], "N\x00")
+	entry(gpsIFD+2+12, 0x0002, 5, 3, gpsData)
+	for i := gpsData; i < len(tiff); i += 4 {
+		copy(tiff[i:], gpsSecret)
+	}
+	return tiff
+}
+
+func TestStripExifGPS(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			tiff := buildTIFF(order, 6)
+			if got := stripExifGPS(tiff); got != 6 {
+				t.Errorf("orientation = %d, want 6", got)
+			}
+			if bytes.Contains(tiff, gpsSecret) || bytes.Contains(tiff, []byte("N\x00")) {
+				t.Error("GPS data was not cleared")
+			}
+			// GPS IFD 的条目数归零，IFD0 保持不变
+			if count := order.Uint16(tiff[38:]); count != 0 {
+				t.Errorf("GPS IFD entry count = %d, want 0", count)
+			}
+			if count := order.Uint16(tiff[8:]); count != 2 {
+				t.Errorf("IFD0 entry count = %d, want 2", count)
+			}
+		})
+	}
+}
+
+func TestStripExifGPSMalformed(t *testing.T) {
+	withIFD0 := func(offset uint32) []byte {
+		tiff := buildTIFF(binary.LittleEndian, 3)
+		binary.LittleEndian.PutUint32(tiff[4:], offset)
+		return tiff
+	}
+	withGPSPointer := func(offset uint32) []byte {
+		tiff := buildTIFF(binary.LittleEndian, 3)
+		binary.LittleEndian.PutUint32(tiff[8+2+12+8:], offset)
+		return tiff
+	}
+	withGPSDataOffset := func(offset uint32) []byte {
+		tiff := buildTIFF(binary.LittleEndian, 3)
+		binary.LittleEndian.PutUint32(tiff[38+2+12+8:], offset)
+		return tiff
+	}
+
+	tests := []struct {
+		name        string
+		tiff        []byte
+		orientation int
+	}{
+		{"empty", nil, 1},
+		{"short", []byte("II*\x00"), 1},
+		{"unknown byte order", append([]byte("XX"), buildTIFF(binary.LittleEndian, 3)[2:]...), 1},
+		{"IFD0 inside header", withIFD0(4), 1},
+		{"IFD0 past end", withIFD0(1 << 20), 1},
+		{"truncated entries", buildTIFF(binary.LittleEndian, 3)[:8+2+12], 3},
+		{"invalid orientation", buildTIFF(binary.LittleEndian, 9), 1},
+		{"GPS pointer past end", withGPSPointer(1 << 30), 3},
+		{"GPS pointer into header", withGPSPointer(2), 3},
+		{"GPS data past end", withGPSDataOffset(1 << 30), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripExifGPS(tt.tiff); got != tt.orientation {
+				t.Errorf("orientation = %d, want %d", got, tt.orientation)
+			}
+		})
+	}
+}
+
+// withJPEGSegments 在 SOI 之后插入 APP1 段
+func withJPEGSegments(jpeg []byte, payloads ...[]byte) []byte {
+	out := append([]byte(nil), jpeg[:2]...)
+	for _, payload := range payloads {
+		out = append(out, 0xFF, 0xE1)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+		out = append(out, payload...)
+	}
+	return append(out, jpeg[2:]...)
+}
+
+// withPNGChunks 在 IHDR 之后插入块
+func withPNGChunks(png []byte, chunks ...[2][]byte) []byte {
+	const ihdrEnd = 8 + 12 + 13
+	out := append([]byte(nil), png[:ihdrEnd]...)
+	for _, chunk := range chunks {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(chunk[1])))
+		start := len(out)
+		out = append(out, chunk[0]...)
+		out = append(out, chunk[1]...)
+		out = binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out[start:]))
+	}
+	return append(out, png[ihdrEnd:]...)
+}
+
+// withWebPChunks 把简单格式的 WebP 转为带 VP8X 头的扩展格式，并追加块
+func withWebPChunks(webp []byte, width, height int, flags byte, chunks ...[2][]byte) []byte {
+	vp8x := make([]byte, 10)
+	vp8x[0] = flags
+	vp8x[4], vp8x[5], vp8x[6] = byte(width-1), byte((width-1)>>8), byte((width-1)>>16)
+	vp8x[7], vp8x[8], vp8x[9] = byte(height-1), byte((height-1)>>8), byte((height-1)>>16)
+
+	out := append([]byte(nil), webp[:12]...)
+	appendChunk := func(fourCC string, data []byte) {
+		out = append(out, fourCC...)
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(data)))
+		out = append(out, data...)
+		if len(data)%2 == 1 {
+			out = append(out, 0)
+		}
+	}
+	appendChunk("VP8X", vp8x)
+	out = append(out, webp[12:]...)
+	for _, chunk := range chunks {
+		appendChunk(string(chunk[0]), chunk[1])
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out
+}
+
+func TestStripMetadata(t *testing.T) {
+	src := testImage(40, 20)
+	exif := append(append([]byte(nil), exifHeader...), buildTIFF(binary.BigEndian, 6)...)
+	xmp := append(append([]byte(nil), xmpHeaders[0]...), "<x:xmpmeta>lat 52.37</x:xmpmeta>"...)
+
+	tests := []struct {
+		name     string
+		mimeType string
+		data     []byte
+		decode   bool // 输出是否应仍能被标准库解码
+	}{
+		{"jpeg", MimeJPEG, withJPEGSegments(encodeJPEG(t, src), exif, xmp), true},
+		{"png", MimePNG, withPNGChunks(encodePNG(t, src),
+			[2][]byte{[]byte("eXIf"), buildTIFF(binary.BigEndian, 6)},
+			[2][]byte{[]byte("iTXt"), append([]byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"), "lat 52.37"...)},
+		), true},
+		{"webp", MimeWebP, withWebPChunks(encodeWebP(t, src), 40, 20, 0x08|0x04,
+			[2][]byte{[]byte("EXIF"), append(append([]byte(nil), exifHeader...), buildTIFF(binary.LittleEndian, 6)...)},
+			[2][]byte{[]byte("XMP "), []byte("<x:xmpmeta>lat 52.37</x:xmpmeta>!")},
+		), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !bytes.Contains(tt.data, gpsSecret) {
+				t.Fatal("test image has no GPS data")
+			}
+			out, orientation, err := stripMetadata(tt.mimeType, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if orientation != 6 {
+				t.Errorf("orientation = %d, want 6", orientation)
+			}
+			if bytes.Contains(out, gpsSecret) {
+				t.Error("GPS data survived")
+			}
+			if bytes.Contains(out, []byte("lat 52.37")) {
+				t.Error("XMP survived")
+			}
+			if sniff(out) != tt.mimeType {
+				t.Errorf("output is no longer %s", tt.mimeType)
+			}
+			if tt.mimeType == MimeWebP {
+				if size := binary.LittleEndian.Uint32(out[4:]); int(size) != len(out)-8 {
+					t.Errorf("RIFF size = %d, want %d", size, len(out)-8)
+				}
+				if flags := out[12+8]; flags&0x04 != 0 || flags&0x08 == 0 {
+					t.Errorf("VP8X flags = %#x, want EXIF without XMP", flags)
+				}
+			}
+			if tt.decode {
+				img, _, err := image.Decode(bytes.NewReader(out))
+				if err != nil {
+					t.Fatalf("stripped image does not decode: %v", err)
+				}
+				if b := img.Bounds(); b.Dx() != 40 || b.Dy() != 20 {
+					t.Errorf("stripped image is %dx%d, want 40x20", b.Dx(), b.Dy())
+				}
+			}
+		})
+	}
+}
+
+func TestStripMetadataTruncated(t *testing.T) {
+	src := testImage(8, 8)
+	jpeg := encodeJPEG(t, src)
+	png := encodePNG(t, src)
+	webp := encodeWebP(t, src)
+
+	tests := []struct {
+		name     string
+		mimeType string
+		data     []byte
+	}{
+		{"jpeg segment length past end", MimeJPEG, append(jpeg[:2:2], 0xFF, 0xE1, 0xFF, 0xFF, 0x00)},
+		{"jpeg marker without length", MimeJPEG, append(jpeg[:2:2], 0xFF, 0xE1, 0x00)},
+		{"jpeg garbage instead of marker", MimeJPEG, append(jpeg[:2:2], 0x00, 0x00)},
+		{"png chunk past end", MimePNG, png[:len(png)-4]},
+		{"png trailing bytes", MimePNG, append(png[:len(png):len(png)], 0x00)},
+		{"webp chunk past end", MimeWebP, webp[:len(webp)-2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := stripMetadata(tt.mimeType, tt.data); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// phashSize 计算 DCT 前缩放到的边长
+const phashSize = 32
+
+// PHash 计算 64 位感知哈希（DCT）
+// 图片缩放为 32x32 灰度图后取 DCT 左上角 8x8 的低频系数（不含直流分量），与中位数比较得到各位
+// 重新压缩、缩放、轻微调色后的同一张照片哈希值相近，可用 Distance 比较
+func PHash(img image.Image) uint64 {
+	gray := image.NewGray(image.Rect(0, 0, phashSize, phashSize))
+	xdraw.BiLinear.Scale(gray, gray.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var pixels [phashSize][phashSize]float64
+	for y := 0; y < phashSize; y++ {
+		for x := 0; x < phashSize; x++ {
+			pixels[y][x] = float64(gray.GrayAt(x, y).Y)
+		}
+	}
+	coeffs := dct2D(&pixels)
+
+	values := make([]float64, 0, 63)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			values = append(values, coeffs[y][x])
+		}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for i, v := range values {
+		if v > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// Distance 两个感知哈希的汉明距离，0 表示几乎相同，超过 10 通常是不同的图片
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// BandCount 感知哈希拆分的段数，每段 8 位
+// 汉明距离小于 BandCount 的两个哈希至少有一段完全相同，数据库可以先按段精确匹配取候选
+const BandCount = 8
+
+// Bands 将感知哈希按字节拆成 BandCount 段
+func Bands(hash uint64) [BandCount]int {
+	var bands [BandCount]int
+	for i := range bands {
+		bands[i] = int(hash >> (8 * i) & 0xff)
+	}
+	return bands
+}
+
+// FormatPHash 感知哈希的存储格式：16 位十六进制
+func FormatPHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// ParsePHash 解析 FormatPHash 的结果
+func ParsePHash(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("invalid perceptual hash %q", s)
+	}
+	hash, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid perceptual hash %q: %w", s, err)
+	}
+	return hash, nil
+}
+
+// dct2D 二维 DCT-II，只计算哈希用到的左上角 8x8 系数
+func dct2D(pixels *[phashSize][phashSize]float64) [8][8]float64 {
+	var cos [8][phashSize]float64
+	for u := 0; u < 8; u++ {
+		for x := 0; x < phashSize; x++ {
+			cos[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * phashSize))
+		}
+	}
+
+	// 先对每行变换，再对列变换
+	var rows [phashSize][8]float64
+	for y := 0; y < phashSize; y++ {
+		for u := 0; u < 8; u++ {
+			var sum float64
+			for x := 0; x < phashSize; x++ {
+				sum += pixels[y][x] * cos[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+	var result [8][8]float64
+	for v := 0; v < 8; v++ {
+		for u := 0; u < 8; u++ {
+			var sum float64
+			for y := 0; y < phashSize; y++ {
+				sum += rows[y][u] * cos[v][y]
+			}
+			result[v][u] = sum
+		}
+	}
+	return result
+}
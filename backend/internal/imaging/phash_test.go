@@ -0,0 +1,160 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand"
+	"testing"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// checkerImage 与 testImage 构图不同的棋盘格图片
+func checkerImage(width, height, cell int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (x/cell+y/cell)%2 == 0 {
+				img.SetRGBA(x, y, color.RGBA{R: 240, G: 240, B: 240, A: 255})
+			} else {
+				img.SetRGBA(x, y, color.RGBA{R: 20, G: 20, B: 60, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func scaled(img image.Image, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+func recompressed(t *testing.T, img image.Image, quality int) image.Image {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := jpeg.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func brightened(img image.Image, delta uint8) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			add := func(v uint32) uint8 { return uint8(min(255, v>>8+uint32(delta))) }
+			dst.SetRGBA(x, y, color.RGBA{R: add(r), G: add(g), B: add(bl), A: uint8(a >> 8)})
+		}
+	}
+	return dst
+}
+
+func TestPHashRobustness(t *testing.T) {
+	original := testImage(512, 384)
+	hash := PHash(original)
+
+	tests := []struct {
+		name    string
+		img     image.Image
+		similar bool
+	}{
+		{"identical", original, true},
+		{"downscaled", scaled(original, 200, 150), true},
+		{"stretched", scaled(original, 512, 512), true},
+		{"jpeg quality 30", recompressed(t, original, 30), true},
+		{"brightened", brightened(original, 20), true},
+		{"different picture", checkerImage(512, 384, 64), false},
+		{"mirrored", orient(original, 2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Distance(hash, PHash(tt.img))
+			if tt.similar && d > 6 {
+				t.Errorf("distance = %d, want at most 6", d)
+			}
+			if !tt.similar && d <= 10 {
+				t.Errorf("distance = %d, want more than 10", d)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{0, 0, 0},
+		{0, 1, 1},
+		{0xff, 0x0f, 4},
+		{0, ^uint64(0), 64},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%#x, %#x) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBands(t *testing.T) {
+	tests := []struct {
+		hash uint64
+		want [BandCount]int
+	}{
+		{0, [BandCount]int{}},
+		{0x0123456789abcdef, [BandCount]int{0xef, 0xcd, 0xab, 0x89, 0x67, 0x45, 0x23, 0x01}},
+		{^uint64(0), [BandCount]int{255, 255, 255, 255, 255, 255, 255, 255}},
+	}
+	for _, tt := range tests {
+		if got := Bands(tt.hash); got != tt.want {
+			t.Errorf("Bands(%#x) = %v, want %v", tt.hash, got, tt.want)
+		}
+	}
+}
+
+func TestBandsFindNearHashes(t *testing.T) {
+	// 翻转少于 BandCount 位后至少有一段不变，分段索引才能查到相近的图片
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		hash := rng.Uint64()
+		near := hash
+		for _, bit := range rng.Perm(64)[:rng.Intn(BandCount)] {
+			near ^= 1 << uint(bit)
+		}
+		a, b := Bands(hash), Bands(near)
+		shared := false
+		for band := range a {
+			shared = shared || a[band] == b[band]
+		}
+		if !shared {
+			t.Fatalf("%#x and %#x (distance %d) share no band", hash, near, Distance(hash, near))
+		}
+	}
+}
+
+func TestFormatParsePHash(t *testing.T) {
+	for _, hash := range []uint64{0, 1, 0x00ff00ff00ff00ff, ^uint64(0)} {
+		s := FormatPHash(hash)
+		if len(s) != 16 {
+			t.Errorf("FormatPHash(%#x) = %q, want 16 characters", hash, s)
+		}
+		if got, err := ParsePHash(s); err != nil || got != hash {
+			t.Errorf("ParsePHash(%q) = %#x, %v, want %#x", s, got, err, hash)
+		}
+	}
+
+	for _, s := range []string{"", "ff", "00000000000000000", "000000000000000g", "-00000000000000f"} {
+		if _, err := ParsePHash(s); err == nil {
+			t.Errorf("ParsePHash(%q) succeeded, want error", s)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package listener
+
+import (
+	"chain-vault-backend/internal/chain"
+	"chain-vault-backend/internal/config"
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// 重试退避的初始间隔与上限
+	fetchBackoffBase = 500 * time.Millisecond
+	fetchBackoffMax  = 30 * time.Second
+)
+
+// blockChunk 一段待处理的区块及其日志
+type blockChunk struct {
+	from   uint64
+	to     uint64
+	toHash common.Hash
+	logs   []types.Log
+}
+
+// logFetcher 分段拉取合约日志
+// 限制并发和请求频率，失败时指数退避重试，结果过多或区间过大时自动二分区间
+type logFetcher struct {
+	client      *chain.Client
+	batchSize   uint64
+	concurrency int
+	retries     int
+	limiter     <-chan time.Time // 为 nil 表示不限速
+}
+
+func newLogFetcher(client *chain.Client, cfg *config.Config) *logFetcher {
+	f := &logFetcher{
+		client:      client,
+		batchSize:   cfg.Chain.LogBatchSize,
+		concurrency: cfg.Chain.LogFetchConcurrency,
+		retries:     cfg.Chain.LogFetchRetries,
+	}
+	if f.batchSize == 0 {
+		f.batchSize = 2000
+	}
+	if f.concurrency <= 0 {
+		f.concurrency = 1
+	}
+	if interval := cfg.Chain.LogFetchInterval.Duration; interval > 0 {
+		f.limiter = time.NewTicker(interval).C
+	}
+	return f
+}
+
+// syncRange 分段同步 [fromBlock, toBlock]
+// 每轮并发拉取 concurrency 个分段，再按顺序逐段提交；返回下一个待处理的区块
+func (l *EventListener) syncRange(ctx context.Context, fromBlock, toBlock uint64) (nextBlock uint64, err error) {
+	f := l.fetcher
+	total := toBlock - fromBlock + 1
+	if total > f.batchSize {
+		slog.InfoContext(ctx, "backfilling blocks", "from_block", fromBlock, "to_block", toBlock,
+			"blocks", total, "batch_size", f.batchSize, "concurrency", f.concurrency)
+	}
+
+	nextBlock = fromBlock
+	next := fromBlock
+	for next <= toBlock {
+		// 本轮要拉取的分段
+		var chunks []*blockChunk
+		for i := 0; i < f.concurrency && next <= toBlock; i++ {
+			end := next + f.batchSize - 1
+			if end > toBlock || end < next {
+				end = toBlock
+			}
+			chunks = append(chunks, &blockChunk{from: next, to: end})
+			next = end + 1
+		}
+
+		errs := make([]error, len(chunks))
+		var wg sync.WaitGroup
+		for i, chunk := range chunks {
+			wg.Add(1)
+			go func(i int, chunk *blockChunk) {
+				defer wg.Done()
+				errs[i] = f.fetchChunk(ctx, chunk)
+			}(i, chunk)
+		}
+		wg.Wait()
+
+		// 按顺序提交，遇到第一个失败的分段即停止，之后的分段下次轮询重新拉取
+		for i, chunk := range chunks {
+			if errs[i] != nil {
+				return nextBlock, fmt.Errorf("failed to fetch blocks %d-%d: %w", chunk.from, chunk.to, errs[i])
+			}
+			if err := l.commitBlocks(ctx, chunk); err != nil {
+				return nextBlock, err
+			}
+			nextBlock = chunk.to + 1
+		}
+
+		if total > f.batchSize {
+			scanned := nextBlock - fromBlock
+			slog.InfoContext(ctx, "backfill progress", "scanned", scanned, "total", total,
+				"percent", fmt.Sprintf("%.1f", float64(scanned)*100/float64(total)), "block", nextBlock-1)
+		}
+	}
+
+	return nextBlock, nil
+}
+
+// fetchChunk 拉取一个分段的日志及末尾区块哈希
+// 查询前后末尾区块哈希一致，说明查询期间这段链没有发生重组
+func (f *logFetcher) fetchChunk(ctx context.Context, chunk *blockChunk) error {
+	toHash, err := f.blockHashWithRetry(ctx, chunk.to)
+	if err != nil {
+		return err
+	}
+
+	logs, err := f.filterLogs(ctx, chunk.from, chunk.to)
+	if err != nil {
+		return err
+	}
+
+	hash, err := f.blockHashWithRetry(ctx, chunk.to)
+	if err != nil {
+		return err
+	}
+	if hash != toHash {
+		return fmt.Errorf("block %d changed during scan (%s -> %s)", chunk.to, toHash.Hex(), hash.Hex())
+	}
+
+	chunk.toHash = toHash
+	chunk.logs = logs
+	return nil
+}
+
+// filterLogs 拉取 [from, to] 的日志
+// 节点提示结果过多或区间过大时二分区间分别拉取，其他错误按退避策略重试
+func (f *logFetcher) filterLogs(ctx context.Context, from, to uint64) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{f.client.GetContractAddress()},
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+		if err := f.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		logs, err := f.client.FilterLogs(ctx, query)
+		if err == nil {
+			return logs, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if isRangeTooLargeError(err) && to > from {
+			mid := from + (to-from)/2
+			slog.DebugContext(ctx, "log query range too large, splitting", "from_block", from, "to_block", to, "split_at", mid, "error", err)
+			left, err := f.filterLogs(ctx, from, mid)
+			if err != nil {
+				return nil, err
+			}
+			right, err := f.filterLogs(ctx, mid+1, to)
+			if err != nil {
+				return nil, err
+			}
+			return append(left, right...), nil
+		}
+
+		lastErr = err
+		slog.WarnContext(ctx, "log query failed", "from_block", from, "to_block", to, "attempt", attempt+1, "max_attempts", f.retries+1, "error", err)
+	}
+	return nil, lastErr
+}
+
+// blockHashWithRetry 获取区块哈希，失败时按退避策略重试
+func (f *logFetcher) blockHashWithRetry(ctx context.Context, blockNumber uint64) (common.Hash, error) {
+	var lastErr error
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, backoff(attempt)); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		if err := f.wait(ctx); err != nil {
+			return common.Hash{}, err
+		}
+
+		hash, err := f.client.GetBlockHash(ctx, blockNumber)
+		if err == nil {
+			return hash, nil
+		}
+		if ctx.Err() != nil {
+			return common.Hash{}, ctx.Err()
+		}
+		lastErr = err
+	}
+	return common.Hash{}, fmt.Errorf("failed to get block %d hash: %w", blockNumber, lastErr)
+}
+
+// wait 按限速间隔等待下一次请求
+func (f *logFetcher) wait(ctx context.Context) error {
+	if f.limiter == nil {
+		return nil
+	}
+	select {
+	case <-f.limiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backoff 计算第 attempt 次重试前的等待时间
+func backoff(attempt int) time.Duration {
+	d := fetchBackoffBase << uint(attempt-1)
+	if d > fetchBackoffMax || d <= 0 {
+		d = fetchBackoffMax
+	}
+	return d
+}
+
+// sleepContext 等待 d，ctx 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isRangeTooLargeError 判断是否为 RPC 节点的查询区间或结果数量限制错误
+// 各家节点的错误信息不同，这里按常见关键字匹配
+func isRangeTooLargeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, keyword := range []string{
+		"more than",   // "query returned more than 10000 results"
+		"too many",    // "too many results", "too many blocks"
+		"block range", // "block range is too wide", "exceed maximum block range"
+		"range too large",
+		"limit exceeded", // "query limit exceeded"
+		"response size",  // "Log response size exceeded"
+		"query timeout",
+	} {
+		if strings.Contains(msg, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"chain-vault-backend/internal/chain"
+	"chain-vault-backend/internal/config"
+)
+
+func TestIsRangeTooLargeError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"query returned more than 10000 results", true},
+		{"Too many results, try a smaller block range", true},
+		{"block range is too wide", true},
+		{"exceed maximum block range: 5000", true},
+		{"eth_getLogs range too large", true},
+		{"query limit exceeded", true},
+		{"Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range", true},
+		{"query timeout exceeded", true},
+		{"connection refused", false},
+		{"header not found", false},
+		{"context deadline exceeded", false},
+	}
+	for _, tt := range tests {
+		if got := isRangeTooLargeError(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("isRangeTooLargeError(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 500 * time.Millisecond},
+		{2, time.Second},
+		{3, 2 * time.Second},
+		{7, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+// fakeLogsNode 模拟只允许查询 maxRange 个区块的节点，每个区块返回一条日志
+type fakeLogsNode struct {
+	maxRange uint64
+
+	mu     sync.Mutex
+	ranges [][2]uint64 // 收到的查询区间
+}
+
+func (n *fakeLogsNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_getLogs" {
+		http.Error(w, "unsupported request", http.StatusBadRequest)
+		return
+	}
+	var filter struct {
+		FromBlock string `json:"fromBlock"`
+		ToBlock   string `json:"toBlock"`
+	}
+	json.Unmarshal(req.Params[0], &filter)
+	from, _ := strconv.ParseUint(strings.TrimPrefix(filter.FromBlock, "0x"), 16, 64)
+	to, _ := strconv.ParseUint(strings.TrimPrefix(filter.ToBlock, "0x"), 16, 64)
+
+	n.mu.Lock()
+	n.ranges = append(n.ranges, [2]uint64{from, to})
+	n.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if to-from+1 > n.maxRange {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32005,"message":"query returned more than 10000 results"}}`, req.ID)
+		return
+	}
+	logs := make([]map[string]interface{}, 0, to-from+1)
+	for block := from; block <= to; block++ {
+		logs = append(logs, map[string]interface{}{
+			"address":          "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			"topics":           []string{},
+			"data":             "0x",
+			"blockNumber":      fmt.Sprintf("0x%x", block),
+			"blockHash":        fmt.Sprintf("0x%064x", block),
+			"transactionHash":  fmt.Sprintf("0x%064x", block+1000),
+			"transactionIndex": "0x0",
+			"logIndex":         "0x0",
+			"removed":          false,
+		})
+	}
+	result, _ := json.Marshal(logs)
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result)
+}
+
+// newTestFetcher 连接到 node 的日志拉取器，不重试、不限速
+func newTestFetcher(t *testing.T, node *fakeLogsNode) *logFetcher {
+	t.Helper()
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	cfg := config.Default()
+	cfg.Chain.RPCURL = server.URL
+	cfg.Chain.ContractAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	client, err := chain.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &logFetcher{client: client, batchSize: 2000, concurrency: 1}
+}
+
+func TestFilterLogsSplitsRange(t *testing.T) {
+	tests := []struct {
+		from, to uint64
+		maxRange uint64
+	}{
+		{0, 20, 4},
+		{100, 100, 1},
+		{10, 17, 8},
+		{0, 63, 1},
+	}
+	for _, tt := range tests {
+		f := newTestFetcher(t, &fakeLogsNode{maxRange: tt.maxRange})
+		logs, err := f.filterLogs(context.Background(), tt.from, tt.to)
+		if err != nil {
+			t.Errorf("filterLogs(%d, %d) max %d: %v", tt.from, tt.to, tt.maxRange, err)
+			continue
+		}
+		if uint64(len(logs)) != tt.to-tt.from+1 {
+			t.Errorf("filterLogs(%d, %d) max %d: got %d logs, want %d", tt.from, tt.to, tt.maxRange, len(logs), tt.to-tt.from+1)
+			continue
+		}
+		for i, log := range logs {
+			if log.BlockNumber != tt.from+uint64(i) {
+				t.Errorf("filterLogs(%d, %d) max %d: log %d is from block %d, want %d", tt.from, tt.to, tt.maxRange, i, log.BlockNumber, tt.from+uint64(i))
+				break
+			}
+		}
+	}
+}
+
+func TestFilterLogsGivesUpOnSingleBlock(t *testing.T) {
+	// 单个区块仍然超限时无法再拆分，按普通错误重试后返回
+	node := &fakeLogsNode{maxRange: 0}
+	f := newTestFetcher(t, node)
+	if _, err := f.filterLogs(context.Background(), 5, 6); err == nil || !isRangeTooLargeError(err) {
+		t.Errorf("filterLogs error = %v, want the node's range error", err)
+	}
+	if len(node.ranges) != 2 {
+		t.Errorf("got %d requests %v, want 2 (5-6, then 5-5 fails)", len(node.ranges), node.ranges)
+	}
+}
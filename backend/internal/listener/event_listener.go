@@ -3,21 +3,41 @@ package listener
 import (
 	"chain-vault-backend/internal/chain"
 	"chain-vault-backend/internal/config"
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/logging"
+	"chain-vault-backend/internal/metrics"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
 	"chain-vault-backend/internal/service"
 	"context"
+	"errors"
 	"fmt"
-	logpkg "log"
+	"log/slog"
 	"math/big"
 	"time"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
 )
 
 type EventListener struct {
-	ethClient    *chain.Client
-	assetService *service.AssetService
-	cfg          *config.Config
+	ethClient         *chain.Client
+	assetService      *service.AssetService
+	orderService      *service.OrderService
+	brandService      *service.BrandService
+	historyService    *service.HistoryService
+	payloadService    *service.PayloadService
+	reputationService *service.ReputationService
+	syncService       *service.SyncService
+	streamService     *service.EventStreamService
+	webhookService    *service.WebhookService
+	health            *listenerHealth
+	fetcher           *logFetcher
+	cfg               *config.Config
+
+	// 区块时间缓存，避免同一区块内多个事件重复请求区块头
+	blockTimes map[uint64]time.Time
 }
 
 func NewEventListener(cfg *config.Config) (*EventListener, error) {
@@ -27,280 +47,807 @@ func NewEventListener(cfg *config.Config) (*EventListener, error) {
 	}
 
 	return &EventListener{
-		ethClient:    ethClient,
-		assetService: service.NewAssetService(),
-		cfg:          cfg,
+		ethClient:         ethClient,
+		assetService:      service.NewAssetService(),
+		orderService:      service.NewOrderService(),
+		brandService:      service.NewBrandService(),
+		historyService:    service.NewHistoryService(),
+		payloadService:    service.NewPayloadService(),
+		reputationService: service.NewReputationService(),
+		syncService:       service.NewSyncService(),
+		streamService:     service.NewEventStreamService(),
+		webhookService:    service.NewWebhookService(),
+		health:            &listenerHealth{},
+		fetcher:           newLogFetcher(ethClient, cfg),
+		cfg:               cfg,
+		blockTimes:        make(map[uint64]time.Time),
 	}, nil
 }
 
+// withTx 返回一个所有服务都绑定到同一数据库事务的监听器副本
+// 同一批区块中的事件与同步进度在同一个事务中提交
+func (l *EventListener) withTx(tx *gorm.DB) *EventListener {
+	cp := *l
+	cp.assetService = l.assetService.WithTx(tx)
+	cp.orderService = l.orderService.WithTx(tx)
+	cp.brandService = l.brandService.WithTx(tx)
+	cp.historyService = l.historyService.WithTx(tx)
+	cp.payloadService = l.payloadService.WithTx(tx)
+	cp.reputationService = l.reputationService.WithTx(tx)
+	cp.syncService = l.syncService.WithTx(tx)
+	cp.webhookService = l.webhookService.WithTx(tx)
+	return &cp
+}
+
 func (l *EventListener) Start(ctx context.Context) error {
-	logpkg.Println("Starting event listener...")
+	slog.InfoContext(ctx, "starting event listener", "contract", l.ethClient.GetContractAddress().Hex())
 
-	// 获取最新区块号
-	latestBlock, err := l.ethClient.GetLatestBlock(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get latest block: %w", err)
+	// 确认节点可用
+	if _, err := l.ethClient.GetLatestBlock(ctx); err != nil {
+		err = fmt.Errorf("failed to get latest block: %w", err)
+		l.recordStart(0, err)
+		return err
 	}
 
-	startBlock := l.cfg.StartBlock
-	if startBlock == 0 {
-		// 如果 START_BLOCK=0，从区块 0 开始扫描历史事件
-		startBlock = 0
-		logpkg.Printf("START_BLOCK=0, scanning from block 0 to catch historical events")
-		// 先扫描历史区块
-		go l.scanHistoricalBlocks(ctx, 0, latestBlock)
-	} else {
-		logpkg.Printf("Starting from configured block: %d", startBlock)
+	fromBlock, err := l.resumeBlock()
+	if err != nil {
+		err = fmt.Errorf("failed to load sync checkpoint: %w", err)
+		l.recordStart(0, err)
+		return err
 	}
+	var processed uint64
+	if fromBlock > 0 {
+		processed = fromBlock - 1
+	}
+	l.recordStart(processed, nil)
 
 	// 启动事件监听（使用轮询方式，因为 Hardhat 不支持 WebSocket 订阅）
-	go l.watchWithPolling(ctx, latestBlock)
+	go l.watchWithPolling(ctx, fromBlock)
+
+	// 早期版本没有保存元数据 URI，从合约补齐
+	go l.backfillMetadataURIs(ctx)
 
-	logpkg.Println("Event listener started successfully")
+	slog.InfoContext(ctx, "event listener started", "from_block", fromBlock)
 	return nil
 }
 
-// scanHistoricalBlocks 扫描历史区块中的事件
-func (l *EventListener) scanHistoricalBlocks(ctx context.Context, fromBlock, toBlock uint64) {
-	logpkg.Printf("Scanning historical blocks from %d to %d", fromBlock, toBlock)
-
-	// 使用 FilterLogs 查询历史事件
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{l.ethClient.GetContractAddress()},
-		FromBlock: new(big.Int).SetUint64(fromBlock),
-		ToBlock:   new(big.Int).SetUint64(toBlock),
-	}
-
-	logs, err := l.ethClient.GetClient().FilterLogs(ctx, query)
+// resumeBlock 计算本次启动的起始区块
+// 有同步进度时从进度的下一个区块继续，但不早于 START_BLOCK
+func (l *EventListener) resumeBlock() (uint64, error) {
+	contract := l.ethClient.GetContractAddress().Hex()
+	lastBlock, ok, err := l.syncService.GetCheckpoint(contract)
 	if err != nil {
-		logpkg.Printf("Failed to filter historical logs: %v", err)
-		return
+		return 0, err
 	}
 
-	logpkg.Printf("Found %d historical events", len(logs))
+	fromBlock := l.cfg.Chain.StartBlock
+	if ok && lastBlock+1 > fromBlock {
+		fromBlock = lastBlock + 1
+		slog.Info("resuming from checkpoint", "last_block", lastBlock)
+	} else {
+		slog.Info("no usable checkpoint, starting from configured block", "from_block", fromBlock)
+	}
+	return fromBlock, nil
+}
 
-	contractABI := l.ethClient.GetContractABI()
-	registeredEventSig := contractABI.Events["AssetRegistered"].ID
-	transferredEventSig := contractABI.Events["AssetTransferred"].ID
-	listedEventSig := contractABI.Events["AssetListed"].ID
-	unlistedEventSig := contractABI.Events["AssetUnlisted"].ID
-
-	for _, logEntry := range logs {
-		if len(logEntry.Topics) == 0 {
-			continue
+// commitBlocks 处理一段区块的事件，并与同步进度一起在一个事务中提交
+// 任一事件处理失败（数据库写入、RPC 调用等）时整个事务回滚，进度不会前进，
+// 下次轮询会重新扫描该范围；提交成功后才推送事件
+func (l *EventListener) commitBlocks(ctx context.Context, chunk *blockChunk) error {
+	// 区块时间只在本段内有效
+	l.blockTimes = make(map[uint64]time.Time)
+	ctx = logging.With(ctx, "from_block", chunk.from, "to_block", chunk.to)
+
+	contract := l.ethClient.GetContractAddress().Hex()
+	var recorded []model.ChainEvent
+	err := database.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txListener := l.withTx(tx)
+
+		recorded = recorded[:0]
+		blockHashes := map[uint64]common.Hash{chunk.to: chunk.toHash}
+		for _, logEntry := range chunk.logs {
+			// 每条日志的处理日志和 SQL 日志都带上所在区块和交易
+			logCtx := logging.With(ctx, "block", logEntry.BlockNumber, "tx", logEntry.TxHash.Hex(), "log_index", logEntry.Index)
+			record, err := l.withTx(tx.WithContext(logCtx)).handleLog(logCtx, logEntry)
+			if err != nil {
+				return err
+			}
+			if record != nil {
+				recorded = append(recorded, *record)
+			}
+			blockHashes[logEntry.BlockNumber] = logEntry.BlockHash
 		}
 
-		eventSig := logEntry.Topics[0]
+		// 写入依附于本批交易（以及之前已索引交易）的延迟数据
+		if _, err := txListener.payloadService.ApplyReady(); err != nil {
+			return err
+		}
 
-		// 处理 AssetRegistered 事件
-		if eventSig == registeredEventSig {
-			event := new(chain.AssetRegisteredEvent)
+		// Webhook 发件箱与事件一起提交，投递由后台任务完成
+		if err := txListener.webhookService.Enqueue(recorded); err != nil {
+			return err
+		}
 
-			// 解析事件
-			if len(logEntry.Topics) > 1 {
-				event.AssetId = new(big.Int).SetBytes(logEntry.Topics[1].Bytes()).Uint64()
-			}
-			if len(logEntry.Topics) > 2 {
-				event.Owner = common.BytesToAddress(logEntry.Topics[2].Bytes())
+		for blockNum, hash := range blockHashes {
+			if err := txListener.syncService.RecordBlockHash(contract, blockNum, hash.Hex()); err != nil {
+				return err
 			}
-			if len(logEntry.Topics) > 3 {
-				event.Brand = common.BytesToAddress(logEntry.Topics[3].Bytes())
+		}
+		if chunk.to > blockHashRetention {
+			if err := txListener.syncService.PruneBlocks(contract, chunk.to-blockHashRetention); err != nil {
+				return err
 			}
+		}
 
-			// 解析 name 和 serialNumber
-			if len(logEntry.Data) > 0 {
-				eventMap := make(map[string]interface{})
-				err := contractABI.UnpackIntoMap(eventMap, "AssetRegistered", logEntry.Data)
-				if err == nil {
-					if name, ok := eventMap["name"].(string); ok {
-						event.Name = name
-					}
-					if serialNumber, ok := eventMap["serialNumber"].(string); ok {
-						event.SerialNumber = serialNumber
-					}
-				} else {
-					unpacked, err := contractABI.Unpack("AssetRegistered", logEntry.Data)
-					if err == nil && len(unpacked) >= 2 {
-						if nameStr, ok := unpacked[0].(string); ok {
-							event.Name = nameStr
-						}
-						if serialNumberStr, ok := unpacked[1].(string); ok {
-							event.SerialNumber = serialNumberStr
-						}
-					}
-				}
-			}
+		return txListener.syncService.SaveCheckpoint(contract, chunk.to)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit blocks %d-%d: %w", chunk.from, chunk.to, err)
+	}
 
-			event.BlockNumber = logEntry.BlockNumber
-			event.TxHash = logEntry.TxHash.Hex()
+	if len(chunk.logs) > 0 {
+		slog.InfoContext(ctx, "blocks processed", "logs", len(chunk.logs), "events", len(recorded))
+	}
+	for _, record := range recorded {
+		metrics.EventsProcessed.WithLabelValues(record.EventName).Inc()
+	}
+	l.recordProcessed(chunk.to)
+	l.streamService.Publish(recorded)
+	return nil
+}
 
-			// 处理事件（检查重复并保存）
-			// 1. 检查 AssetID 是否已存在
-			existing, _ := l.assetService.GetAsset(event.AssetId)
-			if existing != nil {
-				logpkg.Printf("Asset %d already exists, skipping", event.AssetId)
-				continue
-			}
+// handleLog 根据事件签名将日志分发给对应的处理函数，并记录到事件日志表
+// 返回新记录的事件，跳过的日志返回 nil；返回错误时整段区块回滚，下次轮询重试
+// 事件指向的订单或资产不存在（repository.ErrNotFound）时只记录警告，不回滚
+func (l *EventListener) handleLog(ctx context.Context, logEntry types.Log) (*model.ChainEvent, error) {
+	if len(logEntry.Topics) == 0 {
+		return nil, nil
+	}
 
-			// 2. 检查 SerialNumber 是否已存在 (如果序列号不为空)
-			if event.SerialNumber != "" {
-				existingBySN, _ := l.assetService.GetAssetBySerialNumber(event.SerialNumber)
-				if existingBySN != nil {
-					logpkg.Printf("Asset with SerialNumber %s already exists (ID: %d), skipping duplicate registration for AssetID %d", 
-						event.SerialNumber, existingBySN.ID, event.AssetId)
-					continue
-				}
-			}
+	// 订阅接口会推送被重组移除的日志，这些日志不代表链上状态
+	if logEntry.Removed {
+		slog.DebugContext(ctx, "skipping removed log")
+		return nil, nil
+	}
+
+	contractABI := l.ethClient.GetContractABI()
+	abiEvent, err := contractABI.EventByID(logEntry.Topics[0])
+	if err != nil {
+		// 不关心的事件
+		return nil, nil
+	}
+	ctx = logging.With(ctx, "event", abiEvent.Name)
+
+	// 同一条日志只处理一次
+	processed, err := l.syncService.IsEventProcessed(logEntry.TxHash.Hex(), logEntry.Index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check whether log was processed: %w", err)
+	}
+	if processed {
+		return nil, nil
+	}
 
-			// 使用 CreateAssetV3 保存完整信息
-			if err := l.assetService.CreateAssetV3(
-				event.AssetId,
-				event.Owner.Hex(),
-				event.Brand.Hex(),
-				event.Name,
-				event.SerialNumber,
-				"", // metadataURI 暂时为空
-				event.TxHash,
-				event.BlockNumber,
-				0, // status 默认为 0 (未验证)
-			); err != nil {
-				logpkg.Printf("Failed to save historical asset %d: %v", event.AssetId, err)
-			} else {
-				logpkg.Printf("Historical asset %d saved successfully: %s (SN: %s)", event.AssetId, event.Name, event.SerialNumber)
+	var handle func(context.Context, types.Log) error
+	switch abiEvent.Name {
+	case "AssetRegistered":
+		handle = l.handleAssetRegistered
+	case "AssetTransferred":
+		handle = l.handleAssetTransferred
+	case "AssetListed":
+		handle = l.handleAssetListed
+	case "AssetUnlisted":
+		handle = l.handleAssetUnlisted
+	case "OrderCreated":
+		handle = l.handleOrderCreated
+	case "OrderPaid":
+		handle = l.handleOrderPaid
+	case "OrderShipped":
+		handle = l.handleOrderShipped
+	case "OrderDelivered":
+		handle = l.handleOrderDelivered
+	case "OrderCompleted":
+		handle = l.handleOrderCompleted
+	case "OrderRefunded":
+		handle = l.handleOrderRefunded
+	case "OrderCancelled":
+		handle = l.handleOrderCancelled
+	case "BrandRegistered":
+		handle = l.handleBrandRegistered
+	case "BrandAuthorized":
+		handle = l.handleBrandAuthorized
+	case "AssetVerified":
+		handle = l.handleAssetVerified
+	}
+	if handle != nil {
+		if err := handle(ctx, logEntry); err != nil {
+			if !errors.Is(err, repository.ErrNotFound) {
+				return nil, fmt.Errorf("failed to handle %s (tx %s, log %d): %w",
+					abiEvent.Name, logEntry.TxHash.Hex(), logEntry.Index, err)
 			}
+			// 订单或资产创建早于起始区块时数据库中没有对应记录，重试也无法补齐；
+			// 跳过处理但仍记录事件，否则同步进度会一直停在这段区块
+			slog.WarnContext(ctx, "record not found, skipping event", "error", err)
 		}
+	}
 
-		// 处理 AssetTransferred 事件
-		if eventSig == transferredEventSig {
-			event := new(chain.AssetTransferredEvent)
+	record := newChainEvent(abiEvent.Name, logEntry)
+	if err := l.syncService.RecordEvent(record); err != nil {
+		return nil, fmt.Errorf("failed to record event: %w", err)
+	}
+	return record, nil
+}
 
-			if len(logEntry.Topics) > 1 {
-				event.AssetId = new(big.Int).SetBytes(logEntry.Topics[1].Bytes()).Uint64()
-			}
-			if len(logEntry.Topics) > 2 {
-				event.From = common.BytesToAddress(logEntry.Topics[2].Bytes())
-			}
-			if len(logEntry.Topics) > 3 {
-				event.To = common.BytesToAddress(logEntry.Topics[3].Bytes())
-			}
+// newChainEvent 根据事件名从 indexed 参数中提取涉及的实体，生成事件日志记录
+func newChainEvent(eventName string, logEntry types.Log) *model.ChainEvent {
+	event := &model.ChainEvent{
+		ContractAddress: logEntry.Address.Hex(),
+		BlockNum:        logEntry.BlockNumber,
+		BlockHash:       logEntry.BlockHash.Hex(),
+		TxHash:          logEntry.TxHash.Hex(),
+		LogIndex:        logEntry.Index,
+		EventName:       eventName,
+	}
 
-			event.BlockNumber = logEntry.BlockNumber
-			event.TxHash = logEntry.TxHash.Hex()
-
-			if err := l.assetService.UpdateAssetOwner(
-				event.AssetId,
-				event.To.Hex(),
-				event.TxHash,
-				event.BlockNumber,
-			); err != nil {
-				logpkg.Printf("Failed to update asset %d owner: %v", event.AssetId, err)
-			} else {
-				logpkg.Printf("Asset %d transferred from %s to %s", event.AssetId, event.From.Hex(), event.To.Hex())
-			}
+	if len(logEntry.Topics) < 2 {
+		return event
+	}
+	topic := logEntry.Topics[1]
+
+	switch eventName {
+	case "AssetRegistered", "AssetTransferred", "AssetListed", "AssetUnlisted", "AssetVerified":
+		event.AssetID = new(big.Int).SetBytes(topic.Bytes()).Uint64()
+	case "OrderCreated":
+		event.OrderID = new(big.Int).SetBytes(topic.Bytes()).Uint64()
+		if len(logEntry.Topics) > 2 {
+			event.AssetID = new(big.Int).SetBytes(logEntry.Topics[2].Bytes()).Uint64()
 		}
+	case "OrderPaid", "OrderShipped", "OrderDelivered", "OrderCompleted", "OrderRefunded", "OrderCancelled":
+		event.OrderID = new(big.Int).SetBytes(topic.Bytes()).Uint64()
+	case "BrandRegistered", "BrandAuthorized":
+		event.Address = model.NewAddress(common.BytesToAddress(topic.Bytes()))
+	}
+	return event
+}
 
-		// 处理 AssetListed 事件
-		if eventSig == listedEventSig {
-			event := new(chain.AssetListedEvent)
+// handleAssetRegistered 处理 AssetRegistered 事件
+func (l *EventListener) handleAssetRegistered(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseAssetRegistered(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	assetID := event.AssetId.Uint64()
 
-			if len(logEntry.Topics) > 1 {
-				event.AssetId = new(big.Int).SetBytes(logEntry.Topics[1].Bytes()).Uint64()
-			}
-			if len(logEntry.Topics) > 2 {
-				event.Seller = common.BytesToAddress(logEntry.Topics[2].Bytes())
-			}
+	// 处理事件（检查重复并保存）
+	// 1. 检查 AssetID 是否已存在
+	existing, err := l.assetService.GetAsset(assetID)
+	if err != nil {
+		return fmt.Errorf("failed to load asset %d: %w", assetID, err)
+	}
+	if existing != nil {
+		slog.InfoContext(ctx, "asset already exists, skipping", "asset_id", assetID)
+		return nil
+	}
 
-			if len(logEntry.Data) >= 32 {
-				event.Price = new(big.Int).SetBytes(logEntry.Data[:32])
-			}
+	// 2. 检查 SerialNumber 是否已存在 (如果序列号不为空)
+	if event.SerialNumber != "" {
+		existingBySN, err := l.assetService.GetAssetBySerialNumber(event.SerialNumber)
+		if err != nil {
+			return fmt.Errorf("failed to look up serial number %q: %w", event.SerialNumber, err)
+		}
+		if existingBySN != nil {
+			slog.WarnContext(ctx, "serial number already registered, skipping duplicate asset",
+				"asset_id", assetID, "serial_number", event.SerialNumber, "existing_asset_id", existingBySN.ID)
+			return nil
+		}
+	}
 
-			event.BlockNumber = logEntry.BlockNumber
-			event.TxHash = logEntry.TxHash.Hex()
+	// 用户注册（brand 为零地址）的资产在合约中为待验证状态且不会发出验证事件；
+	// 品牌方注册的资产随后会收到 AssetVerified 事件
+	status := model.Unverified
+	if event.Brand == (common.Address{}) {
+		status = model.Pending
+	}
 
-			priceWei := event.Price.String()
-			if err := l.assetService.ListAsset(event.AssetId, priceWei); err != nil {
-				logpkg.Printf("Failed to list asset %d: %v", event.AssetId, err)
-			} else {
-				logpkg.Printf("Asset %d listed with price %s wei", event.AssetId, priceWei)
-			}
+	// 事件中没有元数据 URI，从合约读取注册时的资产状态；读取失败时留空，由 backfillMetadataURIs 补齐
+	metadataURI := ""
+	if state, err := l.ethClient.GetAssetState(ctx, assetID, logEntry.BlockNumber); err != nil {
+		slog.WarnContext(ctx, "failed to read metadata URI", "asset_id", assetID, "error", err)
+	} else {
+		metadataURI = state.MetadataURI
+	}
+
+	// 使用 CreateAssetV3 保存完整信息
+	if err := l.assetService.CreateAssetV3(
+		assetID,
+		model.NewAddress(event.Owner),
+		model.NewAddress(event.Brand),
+		event.Name,
+		event.SerialNumber,
+		metadataURI,
+		logEntry.TxHash.Hex(),
+		logEntry.BlockNumber,
+		status,
+	); err != nil {
+		return fmt.Errorf("failed to save asset %d: %w", assetID, err)
+	}
+	slog.InfoContext(ctx, "asset registered", "asset_id", assetID, "name", event.Name, "serial_number", event.SerialNumber)
+
+	return l.recordOwnership(ctx, logEntry, assetID, common.Address{}, event.Owner)
+}
+
+// handleAssetTransferred 处理 AssetTransferred 事件
+func (l *EventListener) handleAssetTransferred(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseAssetTransferred(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	assetID := event.AssetId.Uint64()
+
+	if err := l.assetService.UpdateAssetOwner(
+		assetID,
+		model.NewAddress(event.To),
+		logEntry.TxHash.Hex(),
+		logEntry.BlockNumber,
+	); err != nil {
+		return fmt.Errorf("failed to update owner of asset %d: %w", assetID, err)
+	}
+	slog.InfoContext(ctx, "asset transferred", "asset_id", assetID, "from", event.From.Hex(), "to", event.To.Hex())
+
+	return l.recordOwnership(ctx, logEntry, assetID, event.From, event.To)
+}
+
+// recordOwnership 写入一条所有权历史记录，时间取自事件所在区块
+func (l *EventListener) recordOwnership(ctx context.Context, logEntry types.Log, assetID uint64, from, to common.Address) error {
+	timestamp, err := l.getBlockTime(ctx, logEntry.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block time for ownership history: %w", err)
+	}
+
+	if err := l.historyService.CreateHistory(
+		assetID,
+		model.NewAddress(from),
+		model.NewAddress(to),
+		logEntry.TxHash.Hex(),
+		logEntry.BlockNumber,
+		logEntry.Index,
+		timestamp,
+	); err != nil {
+		return fmt.Errorf("failed to record ownership history of asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
+// handleAssetListed 处理 AssetListed 事件
+func (l *EventListener) handleAssetListed(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseAssetListed(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	assetID := event.AssetId.Uint64()
+
+	price := model.NewWei(event.Price)
+	if err := l.assetService.ListAsset(assetID, price); err != nil {
+		return fmt.Errorf("failed to list asset %d: %w", assetID, err)
+	}
+	slog.InfoContext(ctx, "asset listed", "asset_id", assetID, "price_wei", price.String())
+	return nil
+}
+
+// handleAssetUnlisted 处理 AssetUnlisted 事件
+func (l *EventListener) handleAssetUnlisted(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseAssetUnlisted(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	assetID := event.AssetId.Uint64()
+
+	if err := l.assetService.UnlistAsset(assetID); err != nil {
+		return fmt.Errorf("failed to unlist asset %d: %w", assetID, err)
+	}
+	slog.InfoContext(ctx, "asset unlisted", "asset_id", assetID)
+	return nil
+}
+
+// handleOrderCreated 处理 OrderCreated 事件
+func (l *EventListener) handleOrderCreated(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderCreated(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	orderID := event.OrderId.Uint64()
+	assetID := event.AssetId.Uint64()
+
+	existing, err := l.orderService.GetOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to load order %d: %w", orderID, err)
+	}
+	if existing != nil {
+		slog.InfoContext(ctx, "order already exists, skipping", "order_id", orderID)
+		return nil
+	}
+
+	blockTime, err := l.getBlockTime(ctx, logEntry.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block time for order %d: %w", orderID, err)
+	}
+
+	price := model.NewWei(event.Price)
+	if err := l.orderService.CreateOrder(
+		orderID,
+		assetID,
+		model.NewAddress(event.Seller),
+		model.NewAddress(event.Buyer),
+		price,
+		logEntry.TxHash.Hex(),
+		logEntry.BlockNumber,
+		model.OrderCreated,
+		blockTime,
+	); err != nil {
+		return fmt.Errorf("failed to save order %d: %w", orderID, err)
+	}
+	slog.InfoContext(ctx, "order created", "order_id", orderID, "asset_id", assetID,
+		"seller", event.Seller.Hex(), "buyer", event.Buyer.Hex(), "price_wei", price.String())
+
+	// 合约在创建订单时直接下架资产，不会发出 AssetUnlisted 事件
+	if err := l.assetService.SetListed(assetID, false); err != nil {
+		return fmt.Errorf("failed to unlist asset %d for order %d: %w", assetID, orderID, err)
+	}
+	return nil
+}
+
+// handleOrderPaid 处理 OrderPaid 事件
+func (l *EventListener) handleOrderPaid(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderPaid(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	return l.advanceOrder(ctx, event.OrderId.Uint64(), model.OrderPaid, logEntry.BlockNumber)
+}
+
+// handleOrderShipped 处理 OrderShipped 事件
+func (l *EventListener) handleOrderShipped(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderShipped(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	return l.advanceOrder(ctx, event.OrderId.Uint64(), model.OrderShipped, logEntry.BlockNumber)
+}
+
+// handleOrderDelivered 处理 OrderDelivered 事件
+func (l *EventListener) handleOrderDelivered(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderDelivered(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	return l.advanceOrder(ctx, event.OrderId.Uint64(), model.OrderDelivered, logEntry.BlockNumber)
+}
+
+// handleOrderCompleted 处理 OrderCompleted 事件
+func (l *EventListener) handleOrderCompleted(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderCompleted(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	orderID := event.OrderId.Uint64()
+
+	if err := l.advanceOrder(ctx, orderID, model.OrderCompleted, logEntry.BlockNumber); err != nil {
+		return err
+	}
+	return l.recordReputation(ctx, logEntry, orderID, model.ReputationOrderCompleted)
+}
+
+// handleOrderRefunded 处理 OrderRefunded 事件
+func (l *EventListener) handleOrderRefunded(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderRefunded(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	orderID := event.OrderId.Uint64()
+
+	if err := l.advanceOrder(ctx, orderID, model.OrderRefunded, logEntry.BlockNumber); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "order refunded", "order_id", orderID, "refund_wei", event.RefundAmount.String())
+	if err := l.relistOrderAsset(ctx, orderID); err != nil {
+		return err
+	}
+	return l.recordReputation(ctx, logEntry, orderID, model.ReputationOrderRefunded)
+}
+
+// handleOrderCancelled 处理 OrderCancelled 事件
+func (l *EventListener) handleOrderCancelled(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseOrderCancelled(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	orderID := event.OrderId.Uint64()
+
+	if err := l.advanceOrder(ctx, orderID, model.OrderCancelled, logEntry.BlockNumber); err != nil {
+		return err
+	}
+
+	// 合约在取消订单时会重新上架资产
+	if err := l.relistOrderAsset(ctx, orderID); err != nil {
+		return err
+	}
+	return l.recordReputation(ctx, logEntry, orderID, model.ReputationOrderCancelled)
+}
+
+// handleBrandRegistered 处理 BrandRegistered 事件
+func (l *EventListener) handleBrandRegistered(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseBrandRegistered(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	brandAddress := model.NewAddress(event.BrandAddress)
+
+	// 未授权的品牌可以再次调用 registerBrand，此时只更新名称
+	existing, err := l.brandService.GetBrand(brandAddress)
+	if err != nil {
+		return fmt.Errorf("failed to load brand %s: %w", brandAddress, err)
+	}
+	if existing != nil {
+		if err := l.brandService.UpdateBrandName(brandAddress, event.BrandName); err != nil {
+			return fmt.Errorf("failed to update name of brand %s: %w", brandAddress, err)
 		}
+		slog.InfoContext(ctx, "brand re-registered", "brand", brandAddress, "name", event.BrandName)
+		return nil
+	}
 
-		// 处理 AssetUnlisted 事件
-		if eventSig == unlistedEventSig {
-			event := new(chain.AssetUnlistedEvent)
+	blockTime, err := l.getBlockTime(ctx, logEntry.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block time for brand %s: %w", brandAddress, err)
+	}
 
-			if len(logEntry.Topics) > 1 {
-				event.AssetId = new(big.Int).SetBytes(logEntry.Topics[1].Bytes()).Uint64()
-			}
+	if err := l.brandService.CreateBrand(
+		brandAddress,
+		event.BrandName,
+		logEntry.TxHash.Hex(),
+		logEntry.BlockNumber,
+		blockTime,
+	); err != nil {
+		return fmt.Errorf("failed to save brand %s: %w", brandAddress, err)
+	}
+	slog.InfoContext(ctx, "brand registered", "brand", brandAddress, "name", event.BrandName)
+	return nil
+}
 
-			event.BlockNumber = logEntry.BlockNumber
-			event.TxHash = logEntry.TxHash.Hex()
+// handleBrandAuthorized 处理 BrandAuthorized 事件
+func (l *EventListener) handleBrandAuthorized(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseBrandAuthorized(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	brandAddress := model.NewAddress(event.BrandAddress)
 
-			if err := l.assetService.UnlistAsset(event.AssetId); err != nil {
-				logpkg.Printf("Failed to unlist asset %d: %v", event.AssetId, err)
-			} else {
-				logpkg.Printf("Asset %d unlisted", event.AssetId)
-			}
+	if err := l.brandService.UpdateAuthorization(brandAddress, event.IsAuthorized); err != nil {
+		return fmt.Errorf("failed to update authorization of brand %s: %w", brandAddress, err)
+	}
+	slog.InfoContext(ctx, "brand authorization updated", "brand", brandAddress, "authorized", event.IsAuthorized)
+	return nil
+}
+
+// handleAssetVerified 处理 AssetVerified 事件
+func (l *EventListener) handleAssetVerified(ctx context.Context, logEntry types.Log) error {
+	event, err := l.ethClient.Contract().ParseAssetVerified(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	assetID := event.AssetId.Uint64()
+	status := model.VerificationStatus(event.Status)
+
+	// verifyAsset 在验证通过且传入非零品牌地址时会改写资产的品牌，
+	// 该参数不在事件中，需要从交易输入中解析
+	var brand model.Address
+	if status == model.Verified {
+		brandAddr, ok, err := l.ethClient.GetVerifyAssetBrand(ctx, logEntry.TxHash)
+		if err != nil {
+			return fmt.Errorf("failed to decode verifyAsset input for asset %d: %w", assetID, err)
+		}
+		if ok && brandAddr != (common.Address{}) {
+			brand = model.NewAddress(brandAddr)
 		}
 	}
 
-	logpkg.Println("Historical block scanning completed")
+	if err := l.assetService.UpdateVerificationStatus(assetID, status, brand, model.NewAddress(event.Verifier)); err != nil {
+		return fmt.Errorf("failed to update verification of asset %d: %w", assetID, err)
+	}
+	slog.InfoContext(ctx, "asset verification updated", "asset_id", assetID, "status", int(status), "verifier", event.Verifier.Hex())
+	return nil
 }
 
-// watchWithPolling 使用轮询方式监听新事件（适用于不支持 WebSocket 订阅的节点）
-func (l *EventListener) watchWithPolling(ctx context.Context, startBlock uint64) {
-	pollInterval := 3 * time.Second // 每3秒轮询一次
-	lastProcessedBlock := startBlock
+// advanceOrder 使用区块时间推进订单状态
+func (l *EventListener) advanceOrder(ctx context.Context, orderID uint64, status model.OrderStatus, blockNumber uint64) error {
+	blockTime, err := l.getBlockTime(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block time for order %d: %w", orderID, err)
+	}
 
-	logpkg.Printf("Starting polling-based event watcher from block %d", startBlock)
+	if err := l.orderService.AdvanceOrderStatus(orderID, status, blockTime); err != nil {
+		return fmt.Errorf("failed to update status of order %d to %d: %w", orderID, int(status), err)
+	}
 
-	// 立即扫描一次，确保不遗漏任何事件
-	latestBlock, err := l.ethClient.GetLatestBlock(ctx)
-	if err == nil && latestBlock > lastProcessedBlock {
-		logpkg.Printf("Initial scan: scanning blocks from %d to %d", lastProcessedBlock+1, latestBlock)
-		l.scanHistoricalBlocks(ctx, lastProcessedBlock+1, latestBlock)
-		lastProcessedBlock = latestBlock
+	slog.InfoContext(ctx, "order status updated", "order_id", orderID, "status", int(status))
+	return nil
+}
+
+// recordReputation 将订单事件计入买卖双方的信誉，同一订单的同一类事件只计入一次
+func (l *EventListener) recordReputation(ctx context.Context, logEntry types.Log, orderID uint64, kind string) error {
+	order, err := l.orderService.GetOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to load order %d for reputation: %w", orderID, err)
+	}
+	if order == nil {
+		// 订单创建早于起始区块时数据库中没有该订单，重试也无法补齐
+		slog.WarnContext(ctx, "order not found, skipping reputation", "order_id", orderID)
+		return nil
 	}
 
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	event := &model.ReputationEvent{
+		OrderID:  orderID,
+		Kind:     kind,
+		Seller:   order.Seller,
+		Buyer:    order.Buyer,
+		TxHash:   logEntry.TxHash.Hex(),
+		BlockNum: logEntry.BlockNumber,
+	}
+	// 买卖双方都可以取消订单，取消记在交易发起方名下
+	if kind == model.ReputationOrderCancelled {
+		sender, err := l.ethClient.GetTxSender(ctx, logEntry.TxHash)
+		if err != nil {
+			return fmt.Errorf("failed to get sender of cancel transaction for order %d: %w", orderID, err)
+		}
+		event.Actor = model.NewAddress(sender)
+	}
+
+	applied, err := l.reputationService.ApplyOrderEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to update reputation for order %d (%s): %w", orderID, kind, err)
+	}
+	if applied {
+		slog.InfoContext(ctx, "reputation updated", "order_id", orderID, "kind", kind)
+	}
+	return nil
+}
+
+// relistOrderAsset 重新上架订单对应的资产（退款或取消后合约会恢复在售状态）
+func (l *EventListener) relistOrderAsset(ctx context.Context, orderID uint64) error {
+	order, err := l.orderService.GetOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to load order %d for relisting: %w", orderID, err)
+	}
+	if order == nil {
+		slog.WarnContext(ctx, "order not found, skipping relisting", "order_id", orderID)
+		return nil
+	}
+	if err := l.assetService.SetListed(order.AssetID, true); err != nil {
+		return fmt.Errorf("failed to relist asset %d for order %d: %w", order.AssetID, orderID, err)
+	}
+	return nil
+}
+
+// getBlockTime 获取区块时间（带缓存）
+func (l *EventListener) getBlockTime(ctx context.Context, blockNumber uint64) (time.Time, error) {
+	if t, ok := l.blockTimes[blockNumber]; ok {
+		return t, nil
+	}
+	t, err := l.ethClient.GetBlockTime(ctx, blockNumber)
+	if err != nil {
+		return time.Time{}, err
+	}
+	l.blockTimes[blockNumber] = t
+	return t, nil
+}
+
+// backfillMetadataURIs 为没有元数据 URI 的资产从合约读取 URI，元数据本身由 SearchService 在后台读取
+func (l *EventListener) backfillMetadataURIs(ctx context.Context) {
+	latestBlock, err := l.ethClient.GetLatestBlock(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to backfill metadata URIs", "error", err)
+		return
+	}
 
+	filled := 0
+	var afterID uint64
 	for {
-		select {
-		case <-ctx.Done():
-			logpkg.Println("Polling watcher stopped")
+		ids, err := l.assetService.FindAssetsWithoutMetadataURI(afterID, 100)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to backfill metadata URIs", "error", err)
 			return
-		case <-ticker.C:
-			// 获取最新区块号
-			latestBlock, err := l.ethClient.GetLatestBlock(ctx)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			afterID = id
+			state, err := l.ethClient.GetAssetState(ctx, id, latestBlock)
 			if err != nil {
-				logpkg.Printf("Failed to get latest block: %v", err)
+				if ctx.Err() != nil {
+					return
+				}
+				slog.WarnContext(ctx, "failed to read metadata URI", "asset_id", id, "error", err)
 				continue
 			}
-
-			// 如果有新区块，扫描新事件
-			if latestBlock > lastProcessedBlock {
-				logpkg.Printf("Scanning new blocks from %d to %d", lastProcessedBlock+1, latestBlock)
-				l.scanHistoricalBlocks(ctx, lastProcessedBlock+1, latestBlock)
-				lastProcessedBlock = latestBlock
+			if state.MetadataURI == "" {
+				continue
+			}
+			if err := l.assetService.SetMetadataURI(id, state.MetadataURI); err != nil {
+				slog.ErrorContext(ctx, "failed to save metadata URI", "asset_id", id, "error", err)
+				continue
 			}
+			filled++
 		}
 	}
+	if filled > 0 {
+		slog.InfoContext(ctx, "backfilled metadata URIs", "assets", filled)
+	}
 }
 
-func (l *EventListener) monitorConnection(ctx context.Context) {
-	ticker := time.NewTicker(30 * time.Second)
+// watchWithPolling 使用轮询方式监听新事件（适用于不支持 WebSocket 订阅的节点）
+// fromBlock 为下一个待处理的区块，历史区块和新区块使用同一个循环处理
+func (l *EventListener) watchWithPolling(ctx context.Context, fromBlock uint64) {
+	pollInterval := l.cfg.Chain.PollInterval.Duration // 默认每3秒轮询一次
+	nextBlock := fromBlock
+
+	slog.InfoContext(ctx, "starting polling watcher", "from_block", fromBlock, "interval", pollInterval)
+
+	// poll 扫描 nextBlock 到已确认的最新区块，成功后推进 nextBlock
+	poll := func() error {
+		latestBlock, err := l.ethClient.GetLatestBlock(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get latest block", "error", err)
+			return err
+		}
+		l.recordHead(latestBlock)
+
+		// 先检查已处理的区块是否被重组，有重组时回滚并从共同祖先之后重新扫描
+		ancestor, reorged, err := l.detectReorg(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check for chain reorganization", "error", err)
+			return err
+		}
+		if reorged {
+			if err := l.rollback(ctx, ancestor); err != nil {
+				slog.ErrorContext(ctx, "failed to roll back", "ancestor", ancestor, "error", err)
+				return err
+			}
+			nextBlock = ancestor + 1
+		}
+
+		// 只处理达到确认数的区块
+		if latestBlock < l.cfg.Chain.Confirmations {
+			return nil
+		}
+		confirmedBlock := latestBlock - l.cfg.Chain.Confirmations
+		if confirmedBlock < nextBlock {
+			return nil
+		}
+
+		// 分段扫描，已提交的分段即使后续失败也会保留进度
+		next, err := l.syncRange(ctx, nextBlock, confirmedBlock)
+		nextBlock = next
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to scan blocks", "from_block", nextBlock, "to_block", confirmedBlock, "error", err)
+		}
+		return err
+	}
+
+	// 立即扫描一次，补齐停机期间的区块
+	l.recordPoll(poll())
+
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			_, err := l.ethClient.GetLatestBlock(ctx)
-			if err != nil {
-				logpkg.Printf("Connection check failed: %v", err)
-			}
 		case <-ctx.Done():
+			slog.InfoContext(ctx, "polling watcher stopped")
 			return
+		case <-ticker.C:
+			l.recordPoll(poll())
 		}
 	}
 }
@@ -0,0 +1,206 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"chain-vault-backend/internal/config"
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB 将全局数据库替换为内存 SQLite，只迁移处理事件时用到的表
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(
+		&model.Brand{}, &model.Asset{}, &model.AssetImage{}, &model.Order{}, &model.AssetOwnerHistory{},
+		&model.PendingPayload{}, &model.ReputationEvent{}, &model.UserReputation{},
+		&model.SyncState{}, &model.ProcessedBlock{}, &model.ChainEvent{},
+		&model.WebhookSubscription{}, &model.WebhookDelivery{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return db
+}
+
+// fakeHeaderNode 模拟只支持 eth_getBlockByNumber 的节点，区块时间为区块号乘以 12 秒
+type fakeHeaderNode struct{}
+
+func (fakeHeaderNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_getBlockByNumber" {
+		http.Error(w, "unsupported request", http.StatusBadRequest)
+		return
+	}
+	var tag string
+	json.Unmarshal(req.Params[0], &tag)
+	number, _ := strconv.ParseUint(strings.TrimPrefix(tag, "0x"), 16, 64)
+
+	header, _ := json.Marshal(&types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Time:       number * 12,
+		Difficulty: new(big.Int),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, header)
+}
+
+func newTestListener(t *testing.T) *EventListener {
+	t.Helper()
+	server := httptest.NewServer(fakeHeaderNode{})
+	t.Cleanup(server.Close)
+
+	cfg := config.Default()
+	cfg.Chain.RPCURL = server.URL
+	cfg.Chain.ContractAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	l, err := NewEventListener(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+// eventLog 按合约 ABI 编码一条事件日志，args 依次为 indexed 参数和非 indexed 参数
+func eventLog(t *testing.T, l *EventListener, name string, block uint64, args ...interface{}) types.Log {
+	t.Helper()
+	event, ok := l.ethClient.GetContractABI().Events[name]
+	if !ok {
+		t.Fatalf("unknown event %s", name)
+	}
+
+	topics := []common.Hash{event.ID}
+	var data []interface{}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			data = append(data, args[i])
+			continue
+		}
+		switch v := args[i].(type) {
+		case *big.Int:
+			topics = append(topics, common.BigToHash(v))
+		case common.Address:
+			topics = append(topics, common.BytesToHash(v.Bytes()))
+		default:
+			t.Fatalf("unsupported indexed argument %T", v)
+		}
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return types.Log{
+		Address:     l.ethClient.GetContractAddress(),
+		Topics:      topics,
+		Data:        packed,
+		BlockNumber: block,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(block)),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(block + 1000)),
+	}
+}
+
+func TestCommitBlocksSkipsMissingRecords(t *testing.T) {
+	var (
+		seller   = common.HexToAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+		buyer    = common.HexToAddress("0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB")
+		verifier = common.HexToAddress("0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb")
+	)
+
+	// 订单和资产都创建于起始区块之前，数据库中没有对应记录
+	tests := []struct {
+		name string
+		log  func(l *EventListener) types.Log
+	}{
+		{"OrderPaid", func(l *EventListener) types.Log {
+			return eventLog(t, l, "OrderPaid", 11, big.NewInt(7), buyer)
+		}},
+		{"AssetTransferred", func(l *EventListener) types.Log {
+			return eventLog(t, l, "AssetTransferred", 11, big.NewInt(3), seller, buyer)
+		}},
+		{"AssetVerified", func(l *EventListener) types.Log {
+			return eventLog(t, l, "AssetVerified", 11, big.NewInt(3), uint8(model.Rejected), verifier)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			l := newTestListener(t)
+			logEntry := tt.log(l)
+
+			chunk := &blockChunk{from: 10, to: 12, toHash: common.HexToHash("0x12"), logs: []types.Log{logEntry}}
+			if err := l.commitBlocks(context.Background(), chunk); err != nil {
+				t.Fatalf("commitBlocks() = %v, want the event skipped", err)
+			}
+
+			lastBlock, ok, err := l.syncService.GetCheckpoint(l.ethClient.GetContractAddress().Hex())
+			if err != nil || !ok || lastBlock != 12 {
+				t.Errorf("checkpoint = %d, %v, %v, want 12", lastBlock, ok, err)
+			}
+			if processed, err := l.syncService.IsEventProcessed(logEntry.TxHash.Hex(), logEntry.Index); err != nil || !processed {
+				t.Errorf("IsEventProcessed = %v, %v, want the event recorded", processed, err)
+			}
+
+			// 跳过的事件不会留下半条记录
+			var histories, orders int64
+			db.Model(&model.AssetOwnerHistory{}).Count(&histories)
+			db.Model(&model.Order{}).Count(&orders)
+			if histories != 0 || orders != 0 {
+				t.Errorf("histories = %d, orders = %d, want none", histories, orders)
+			}
+		})
+	}
+}
+
+func TestCommitBlocksRollsBackOnDatabaseError(t *testing.T) {
+	db := openTestDB(t)
+	l := newTestListener(t)
+	if err := db.Migrator().DropTable(&model.Order{}); err != nil {
+		t.Fatal(err)
+	}
+
+	buyer := common.HexToAddress("0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB")
+	logEntry := eventLog(t, l, "OrderPaid", 11, big.NewInt(7), buyer)
+	chunk := &blockChunk{from: 10, to: 12, toHash: common.HexToHash("0x12"), logs: []types.Log{logEntry}}
+	if err := l.commitBlocks(context.Background(), chunk); err == nil {
+		t.Fatal("commitBlocks() succeeded without the orders table")
+	}
+
+	if _, ok, err := l.syncService.GetCheckpoint(l.ethClient.GetContractAddress().Hex()); err != nil || ok {
+		t.Errorf("checkpoint saved (ok = %v, err = %v), want rollback", ok, err)
+	}
+	if processed, _ := l.syncService.IsEventProcessed(logEntry.TxHash.Hex(), logEntry.Index); processed {
+		t.Error("event recorded, want rollback")
+	}
+}
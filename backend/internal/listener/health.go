@@ -0,0 +1,91 @@
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"chain-vault-backend/internal/metrics"
+)
+
+// healthStaleAfter 超过该时间既没有完成轮询也没有提交区块时，监听器视为不健康
+const healthStaleAfter = 2 * time.Minute
+
+// listenerHealth 监听器的运行状态，供就绪检查和指标使用
+// withTx 会复制监听器，因此以指针共享
+type listenerHealth struct {
+	mu           sync.Mutex
+	started      bool
+	startErr     error
+	lastProgress time.Time // 最近一次完成轮询或提交区块的时间
+	lastErr      error     // 最近一次轮询的错误，成功后清空
+	head         uint64
+	processed    uint64
+}
+
+// Healthy 监听器是否在正常同步，启动失败或长时间没有进展时返回原因
+func (l *EventListener) Healthy() error {
+	h := l.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.startErr != nil {
+		return fmt.Errorf("listener failed to start: %w", h.startErr)
+	}
+	if !h.started {
+		return errors.New("listener is not running")
+	}
+	if since := time.Since(h.lastProgress); since > healthStaleAfter {
+		if h.lastErr != nil {
+			return fmt.Errorf("no progress for %s: %w", since.Round(time.Second), h.lastErr)
+		}
+		return fmt.Errorf("no progress for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// recordStart 记录启动结果，processed 为已提交的最后一个区块
+func (l *EventListener) recordStart(processed uint64, err error) {
+	h := l.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.startErr = err
+	if err != nil {
+		return
+	}
+	h.started = true
+	h.lastProgress = time.Now()
+	h.processed = processed
+	metrics.SetListenerProgress(h.head, h.processed)
+}
+
+// recordHead 记录节点返回的最新区块
+func (l *EventListener) recordHead(head uint64) {
+	h := l.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.head = head
+	metrics.SetListenerProgress(h.head, h.processed)
+}
+
+// recordProcessed 记录已提交的最后一个区块（回滚后为共同祖先）
+func (l *EventListener) recordProcessed(processed uint64) {
+	h := l.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.processed = processed
+	h.lastProgress = time.Now()
+	metrics.SetListenerProgress(h.head, h.processed)
+}
+
+// recordPoll 记录一次轮询的结果
+func (l *EventListener) recordPoll(err error) {
+	h := l.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastErr = err
+	if err == nil {
+		h.lastProgress = time.Now()
+	}
+}
@@ -0,0 +1,314 @@
+package listener
+
+import (
+	"chain-vault-backend/internal/chain"
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/logging"
+	"chain-vault-backend/internal/metrics"
+	"chain-vault-backend/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"gorm.io/gorm"
+)
+
+// blockHashRetention 区块哈希记录的保留范围（区块数），也是可自动回滚的最大重组深度
+const blockHashRetention = 256
+
+// detectReorg 检查已记录的区块哈希是否仍在主链上
+// 发生重组时返回最近一个仍在主链上的已处理区块（共同祖先）
+func (l *EventListener) detectReorg(ctx context.Context) (ancestor uint64, reorged bool, err error) {
+	contract := l.ethClient.GetContractAddress().Hex()
+	blocks, err := l.syncService.GetRecentBlocks(contract, blockHashRetention)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(blocks) == 0 {
+		return 0, false, nil
+	}
+
+	for i, block := range blocks {
+		hash, err := l.ethClient.GetBlockHash(ctx, block.BlockNum)
+		if errors.Is(err, ethereum.NotFound) {
+			// 重组后的新链可能暂时比旧链短
+			continue
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if hash.Hex() != block.BlockHash {
+			continue
+		}
+		if i == 0 {
+			return 0, false, nil
+		}
+		slog.WarnContext(ctx, "chain reorganization detected", "block", blocks[0].BlockNum, "ancestor", block.BlockNum)
+		return block.BlockNum, true, nil
+	}
+
+	// 所有记录都已不在主链上，回退到最早记录之前
+	oldest := blocks[len(blocks)-1].BlockNum
+	if oldest > 0 {
+		ancestor = oldest - 1
+	}
+	slog.WarnContext(ctx, "chain reorganization deeper than recorded blocks", "recorded_blocks", len(blocks), "ancestor", ancestor)
+	return ancestor, true, nil
+}
+
+// rollback 撤销 ancestor 之后的区块所产生的数据
+// 孤块中新建的资产、订单、品牌直接删除；之前已存在但被孤块修改过的记录按 ancestor 时的链上状态恢复，
+// 节点不提供历史状态时按最新状态恢复（见 readStates 的调用处）
+func (l *EventListener) rollback(ctx context.Context, ancestor uint64) error {
+	contract := l.ethClient.GetContractAddress().Hex()
+	events, err := l.syncService.GetEventsAfter(contract, ancestor)
+	if err != nil {
+		return err
+	}
+
+	createdAssets := make(map[uint64]bool)
+	createdOrders := make(map[uint64]bool)
+	touchedAssets := make(map[uint64]bool)
+	touchedOrders := make(map[uint64]bool)
+	touchedBrands := make(map[model.Address]bool)
+
+	for _, event := range events {
+		switch event.EventName {
+		case "AssetRegistered":
+			createdAssets[event.AssetID] = true
+		case "OrderCreated":
+			createdOrders[event.OrderID] = true
+			touchedAssets[event.AssetID] = true // 创建订单会下架资产
+		default:
+			if event.AssetID != 0 {
+				touchedAssets[event.AssetID] = true
+			}
+			if event.OrderID != 0 {
+				touchedOrders[event.OrderID] = true
+			}
+		}
+		if event.Address != "" {
+			touchedBrands[event.Address] = true
+		}
+	}
+
+	// 退款、取消会重新上架资产，这些订单对应的资产也需要恢复
+	for orderID := range touchedOrders {
+		if createdOrders[orderID] {
+			continue
+		}
+		order, err := l.orderService.GetOrder(orderID)
+		if err != nil {
+			return err
+		}
+		if order != nil {
+			touchedAssets[order.AssetID] = true
+		}
+	}
+
+	// 先读取 ancestor 时的链上状态，再在一个事务中写回数据库
+	// 读取历史状态需要归档节点；普通节点已裁剪该区块的状态时改为读取最新状态：
+	// 回滚后从 ancestor+1 重新扫描，新链上的事件会把记录更新到一致的状态，重新扫描完成前的中间状态可能不准确
+	states, err := l.readStates(ctx, ancestor, touchedAssets, createdAssets, touchedOrders, createdOrders, touchedBrands)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		latest, latestErr := l.ethClient.GetLatestBlock(ctx)
+		if latestErr != nil {
+			return fmt.Errorf("%w (and failed to read latest block: %v)", err, latestErr)
+		}
+		slog.WarnContext(ctx, "historical state unavailable, restoring from latest state; use an archive node for exact rollback",
+			"ancestor", ancestor, "latest", latest, "error", err)
+		states, err = l.readStates(ctx, latest, touchedAssets, createdAssets, touchedOrders, createdOrders, touchedBrands)
+		if err != nil {
+			return err
+		}
+	}
+	assetStates, orderStates, brandStates := states.assets, states.orders, states.brands
+
+	ctx = logging.With(ctx, "ancestor", ancestor)
+	err = database.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txListener := l.withTx(tx)
+
+		if err := txListener.orderService.DeleteOrders(keys(createdOrders)); err != nil {
+			return err
+		}
+		if err := txListener.assetService.DeleteAssets(keys(createdAssets)); err != nil {
+			return err
+		}
+
+		for orderID, state := range orderStates {
+			if err := txListener.orderService.RestoreOrderState(
+				orderID,
+				model.OrderStatus(state.Status),
+				unixTimePtr(state.PaidAt),
+				unixTimePtr(state.ShippedAt),
+				unixTimePtr(state.DeliveredAt),
+				unixTimePtr(state.CompletedAt),
+				unixTimePtr(state.RefundDeadline),
+				state.CanRefund,
+			); err != nil {
+				return err
+			}
+		}
+
+		for assetID, state := range assetStates {
+			asset, err := txListener.assetService.GetAsset(assetID)
+			if err != nil {
+				return err
+			}
+			if asset == nil {
+				continue
+			}
+
+			// 所有权相关的交易哈希和区块号恢复为 ancestor 之前最近一次注册或转移
+			txHash, blockNum := asset.TxHash, asset.BlockNum
+			ownership, err := txListener.syncService.GetLatestOwnershipEvent(contract, assetID, ancestor)
+			if err != nil {
+				return err
+			}
+			if ownership != nil {
+				txHash, blockNum = ownership.TxHash, ownership.BlockNum
+			}
+
+			if err := txListener.assetService.RestoreAssetState(
+				assetID,
+				model.NewAddress(state.Owner),
+				model.NewAddress(state.Brand),
+				model.VerificationStatus(state.Status),
+				state.IsListed,
+				model.NewWei(state.Price),
+				txHash,
+				blockNum,
+			); err != nil {
+				return err
+			}
+		}
+
+		var orphanBrands []model.Address
+		for address, state := range brandStates {
+			registered, err := txListener.syncService.HasBrandRegisteredBefore(contract, address, ancestor)
+			if err != nil {
+				return err
+			}
+			if !registered {
+				orphanBrands = append(orphanBrands, address)
+				continue
+			}
+			if err := txListener.brandService.RestoreBrandState(address, state.BrandName, state.IsAuthorized); err != nil {
+				return err
+			}
+		}
+		if err := txListener.brandService.DeleteBrands(orphanBrands); err != nil {
+			return err
+		}
+
+		if err := txListener.historyService.DeleteHistoryAfter(ancestor); err != nil {
+			return err
+		}
+
+		// 孤块中的订单事件计入的信誉需要撤销，重新扫描时会按新链计入
+		if err := txListener.reputationService.RevertEventsAfter(ancestor); err != nil {
+			return err
+		}
+
+		// 孤块交易上的延迟数据恢复为待处理，交易重新打包并索引后再次写入
+		txHashes := make([]string, 0, len(events))
+		for _, event := range events {
+			txHashes = append(txHashes, event.TxHash)
+		}
+		if err := txListener.payloadService.ResetPayloads(txHashes); err != nil {
+			return err
+		}
+
+		// 孤块事件还未投递的 Webhook 不再发送，已投递的保留记录
+		if err := txListener.webhookService.DiscardPending(events); err != nil {
+			return err
+		}
+
+		return txListener.syncService.Rewind(contract, ancestor)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.WarnContext(ctx, "rolled back chain reorganization", "events_undone", len(events),
+		"assets_removed", len(createdAssets), "orders_removed", len(createdOrders),
+		"assets_restored", len(assetStates), "orders_restored", len(orderStates), "brands_restored", len(brandStates))
+	metrics.ListenerReorgs.Inc()
+	l.recordProcessed(ancestor)
+	l.streamService.PublishReorg(ancestor)
+	return nil
+}
+
+// chainStates 回滚时读取的链上状态
+type chainStates struct {
+	assets map[uint64]*chain.AssetState
+	orders map[uint64]*chain.OrderState
+	brands map[model.Address]*chain.BrandState
+}
+
+// readStates 读取被孤块修改过的资产、订单、品牌在指定区块的链上状态，孤块中新建的资产和订单不读取
+func (l *EventListener) readStates(ctx context.Context, blockNum uint64,
+	touchedAssets, createdAssets, touchedOrders, createdOrders map[uint64]bool,
+	touchedBrands map[model.Address]bool) (*chainStates, error) {
+	states := &chainStates{
+		assets: make(map[uint64]*chain.AssetState),
+		orders: make(map[uint64]*chain.OrderState),
+		brands: make(map[model.Address]*chain.BrandState),
+	}
+
+	for assetID := range touchedAssets {
+		if createdAssets[assetID] {
+			continue
+		}
+		state, err := l.ethClient.GetAssetState(ctx, assetID, blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read asset %d at block %d: %w", assetID, blockNum, err)
+		}
+		states.assets[assetID] = state
+	}
+
+	for orderID := range touchedOrders {
+		if createdOrders[orderID] {
+			continue
+		}
+		state, err := l.ethClient.GetOrderState(ctx, orderID, blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read order %d at block %d: %w", orderID, blockNum, err)
+		}
+		states.orders[orderID] = state
+	}
+
+	for address := range touchedBrands {
+		state, err := l.ethClient.GetBrandState(ctx, address.Common(), blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read brand %s at block %d: %w", address, blockNum, err)
+		}
+		states.brands[address] = state
+	}
+	return states, nil
+}
+
+// keys 返回集合中的所有键
+func keys(set map[uint64]bool) []uint64 {
+	result := make([]uint64, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	return result
+}
+
+// unixTimePtr 将合约中的秒级时间戳转换为时间，0 表示尚未发生
+func unixTimePtr(ts *big.Int) *time.Time {
+	if ts == nil || ts.Sign() == 0 {
+		return nil
+	}
+	t := time.Unix(ts.Int64(), 0)
+	return &t
+}
@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	// slowQueryThreshold 超过该耗时的 SQL 以 WARN 级别记录
+	slowQueryThreshold = 200 * time.Millisecond
+	// maxLiteralLength 超过该长度的字符串参数（如 base64 图片、签名载荷）会被截断
+	maxLiteralLength = 96
+	// literalPrefixLength 截断后保留的前缀长度
+	literalPrefixLength = 32
+	// maxSQLLength 截断参数后整条 SQL 仍超过该长度时截断尾部（批量插入）
+	maxSQLLength = 4096
+)
+
+// gormLogger 把 GORM 的日志写入 slog
+// SQL 语句以 DEBUG 级别记录（db.Debug() 时为 INFO），慢查询为 WARN，执行失败为 ERROR，
+// 记录未找到不视为错误；过长的参数会被截断
+type gormLogger struct {
+	level gormlogger.LogLevel
+}
+
+// NewGormLogger 创建 GORM 日志适配器
+func NewGormLogger() gormlogger.Interface {
+	return gormLogger{level: gormlogger.Warn}
+}
+
+func (l gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return gormLogger{level: level}
+}
+
+func (l gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		slog.ErrorContext(ctx, "SQL failed", "error", err, "sql", TruncateSQL(sql), "rows", rows, "elapsed_ms", elapsed.Milliseconds())
+	case elapsed > slowQueryThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		slog.WarnContext(ctx, "slow SQL", "sql", TruncateSQL(sql), "rows", rows, "elapsed_ms", elapsed.Milliseconds())
+	default:
+		level := slog.LevelDebug
+		if l.level >= gormlogger.Info {
+			level = slog.LevelInfo
+		}
+		if !slog.Default().Enabled(ctx, level) {
+			return
+		}
+		sql, rows := fc()
+		slog.Log(ctx, level, "SQL", "sql", TruncateSQL(sql), "rows", rows, "elapsed_ms", elapsed.Milliseconds())
+	}
+}
+
+// TruncateSQL 截断 SQL 中过长的字符串字面量，只保留前缀和原长度
+func TruncateSQL(sql string) string {
+	var b strings.Builder
+	b.Grow(min(len(sql), maxSQLLength))
+	for i := 0; i < len(sql); {
+		quote := sql[i]
+		if quote != '\'' && quote != '"' {
+			b.WriteByte(quote)
+			i++
+			continue
+		}
+
+		// 找到字面量的结束引号，跳过反斜杠转义和连续两个引号的转义
+		end := i + 1
+		for end < len(sql) {
+			if sql[end] == '\\' {
+				end += 2
+				continue
+			}
+			if sql[end] == quote {
+				if end+1 < len(sql) && sql[end+1] == quote {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		if end > len(sql) {
+			end = len(sql)
+		}
+
+		literal := sql[i+1 : end]
+		b.WriteByte(quote)
+		if len(literal) > maxLiteralLength {
+			b.WriteString(truncateUTF8(literal, literalPrefixLength))
+			fmt.Fprintf(&b, "…(%d bytes)", len(literal))
+		} else {
+			b.WriteString(literal)
+		}
+		if end < len(sql) {
+			b.WriteByte(quote)
+		}
+		i = end + 1
+	}
+
+	out := b.String()
+	if len(out) > maxSQLLength {
+		out = truncateUTF8(out, maxSQLLength) + fmt.Sprintf("…(%d bytes)", len(out))
+	}
+	return out
+}
+
+// truncateUTF8 截取不超过 n 字节的前缀，不拆开多字节字符
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return s[:n]
+}
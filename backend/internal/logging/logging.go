@@ -0,0 +1,75 @@
+// Package logging 基于 log/slog 的结构化日志
+//
+// Setup 按配置设置默认 logger，标准库 log 的输出也会转到 slog（INFO 级别）。
+// With 把属性附加到 context 上（如请求 ID、区块范围、交易哈希），
+// 之后用该 context 记录的日志（slog.InfoContext 等，包括 GORM 的 SQL 日志）都会带上这些属性。
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type attrsKey struct{}
+
+// With 返回附加了日志属性的 context，args 与 slog.Logger.With 相同（键值对或 slog.Attr）
+func With(ctx context.Context, args ...any) context.Context {
+	prev, _ := ctx.Value(attrsKey{}).([]any)
+	merged := make([]any, 0, len(prev)+len(args))
+	merged = append(merged, prev...)
+	merged = append(merged, args...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// Setup 设置默认 logger，level 为 debug / info / warn / error，format 为 text 或 json
+func Setup(level, format string) error {
+	handler, err := NewHandler(os.Stderr, level, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// NewHandler 创建写入 w 的日志处理器，记录时附加 context 上的属性
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "text":
+		return contextHandler{slog.NewTextHandler(w, opts)}, nil
+	case "json":
+		return contextHandler{slog.NewJSONHandler(w, opts)}, nil
+	}
+	return nil, fmt.Errorf("invalid log format %q: expected text or json", format)
+}
+
+// contextHandler 记录前附加 With 保存在 context 上的属性
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if args, ok := ctx.Value(attrsKey{}).([]any); ok {
+			r.Add(args...)
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
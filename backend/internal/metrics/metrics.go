@@ -0,0 +1,138 @@
+// Package metrics Prometheus 指标，由 GET /metrics 导出
+//
+// 指标注册在默认注册表中，同时包含 Go 运行时和进程指标。
+// 各模块在发生对应操作时直接更新这里的指标，不需要额外初始化。
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "chainvault"
+
+var (
+	// HTTPRequestDuration 按路由统计的请求耗时
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// ListenerHeadBlock 节点返回的最新区块
+	ListenerHeadBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "listener_head_block",
+		Help:      "Latest block number reported by the RPC node.",
+	})
+
+	// ListenerProcessedBlock 已提交的最后一个区块
+	ListenerProcessedBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "listener_processed_block",
+		Help:      "Last block whose events have been committed to the database.",
+	})
+
+	// ListenerHeadLag 最新区块与已处理区块之差，包含确认数
+	ListenerHeadLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "listener_head_lag_blocks",
+		Help:      "Chain head minus the last processed block, including the configured confirmations.",
+	})
+
+	// EventsProcessed 已提交的合约事件数
+	EventsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "listener_events_processed_total",
+		Help:      "Contract events committed by the listener, by event name.",
+	}, []string{"event"})
+
+	// ListenerReorgs 检测到的链重组次数
+	ListenerReorgs = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "listener_reorgs_total",
+		Help:      "Chain reorganizations rolled back by the listener.",
+	})
+
+	// RPCRequests 以太坊节点的 RPC 调用次数
+	RPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "JSON-RPC calls made to the Ethereum node, by method.",
+	}, []string{"method"})
+
+	// RPCErrors 以太坊节点的 RPC 调用失败次数
+	RPCErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Failed JSON-RPC calls to the Ethereum node, by method.",
+	}, []string{"method"})
+
+	// IPFSRequestDuration IPFS 节点的请求耗时
+	IPFSRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ipfs_request_duration_seconds",
+		Help:      "IPFS API call latency by operation and outcome.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation", "outcome"})
+)
+
+// RegisterDB 导出数据库连接池的统计信息（go_sql_* 指标，db_name 为 chainvault）
+func RegisterDB(db *sql.DB) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, namespace))
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		return nil
+	}
+	return err
+}
+
+// Handler GET /metrics
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// Middleware 记录每个请求的耗时，route 为路由模板（如 /assets/:id），未匹配的请求记为 unmatched
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// SetListenerProgress 更新最新区块、已处理区块和两者之差
+func SetListenerProgress(head, processed uint64) {
+	ListenerHeadBlock.Set(float64(head))
+	ListenerProcessedBlock.Set(float64(processed))
+	lag := uint64(0)
+	if head > processed {
+		lag = head - processed
+	}
+	ListenerHeadLag.Set(float64(lag))
+}
+
+// ObserveIPFS 记录一次 IPFS 调用，在调用方中 defer 使用：defer metrics.ObserveIPFS("add", time.Now(), &err)
+func ObserveIPFS(operation string, start time.Time, err *error) {
+	outcome := "success"
+	if *err != nil {
+		outcome = "error"
+	}
+	IPFSRequestDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
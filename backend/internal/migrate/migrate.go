@@ -0,0 +1,383 @@
+// Package migrate 按版本执行数据库迁移，并在 schema_migrations 表中记录已执行的版本
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Dialects 支持的数据库方言，与 database.Connect 选择的驱动一致
+var Dialects = []string{"mysql", "sqlite"}
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Func 迁移中无法用 SQL 完成的步骤，例如需要在程序中计算的数据转换
+type Func func(tx *gorm.DB) error
+
+// Migration 一个版本的迁移
+type Migration struct {
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+	Up      string `json:"-"`
+	Down    string `json:"-"`
+	UpFunc  Func   `json:"-"` // 在 Up 之后、同一事务中执行
+}
+
+// SchemaMigration 已执行的迁移记录
+type SchemaMigration struct {
+	Version   uint64    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(191);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status 迁移的执行状态
+type Status struct {
+	Migration
+	AppliedAt *time.Time `json:"appliedAt"`
+	Missing   bool       `json:"missing"` // 数据库中已执行，但迁移文件已不存在
+}
+
+// Migrator 针对一个数据库连接执行迁移
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New 按数据库连接的方言从 fsys 的同名目录中加载迁移
+// funcs 按版本附加 Go 步骤，对应版本仍需有 SQL 文件（可以只有注释）
+func New(db *gorm.DB, fsys fs.FS, funcs map[uint64]Func) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	migrations, err := Load(fsys, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	for version, fn := range funcs {
+		i := sort.Search(len(migrations), func(i int) bool {
+			return migrations[i].Version >= version
+		})
+		if i == len(migrations) || migrations[i].Version != version {
+			return nil, fmt.Errorf("migration func %d has no migration files", version)
+		}
+		migrations[i].UpFunc = fn
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Load 读取 fsys 中 dialect 目录下的迁移文件，按版本升序返回
+func Load(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %q: %w", dialect, err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, _ := strconv.ParseUint(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Dialect 返回当前连接的方言
+func (m *Migrator) Dialect() string {
+	return m.dialect
+}
+
+func (m *Migrator) ensureTable() error {
+	return m.db.AutoMigrate(&SchemaMigration{})
+}
+
+func (m *Migrator) applied() (map[uint64]SchemaMigration, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+	var rows []SchemaMigration
+	if err := m.db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[uint64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Status 返回所有迁移的执行状态，按版本升序
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	known := make(map[uint64]bool)
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := Status{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for version, row := range applied {
+		if !known[version] {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{
+				Migration: Migration{Version: version, Name: row.Name},
+				AppliedAt: &appliedAt,
+				Missing:   true,
+			})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// Pending 返回尚未执行的迁移
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up 按版本顺序执行所有未执行的迁移，返回本次执行的迁移
+// 每个迁移与其版本记录在同一个事务中提交；MySQL 的 DDL 会隐式提交，失败时需要手动检查
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := execScript(tx, migration.Up); err != nil {
+				return err
+			}
+			if migration.UpFunc != nil {
+				if err := migration.UpFunc(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down 按版本倒序回滚最近执行的 steps 个迁移，返回本次回滚的迁移
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return done, fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+		}
+
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := execScript(tx, migration.Down); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// execScript 逐条执行 SQL 脚本
+// 直接使用底层连接执行，避免 GORM 把语句中的 ? 当作占位符
+func execScript(tx *gorm.DB, script string) error {
+	for _, stmt := range splitStatements(script) {
+		if _, err := tx.Statement.ConnPool.ExecContext(context.Background(), stmt); err != nil {
+			return fmt.Errorf("%w\n%s", err, stmt)
+		}
+	}
+	return nil
+}
+
+// splitStatements 按分号拆分 SQL 脚本，跳过注释和引号内的分号
+// CREATE TRIGGER 语句的 BEGIN ... END 之间的分号不拆分
+func splitStatements(script string) []string {
+	var stmts []string
+	var cur strings.Builder
+	var quote byte
+	flush := func() {
+		if stmt := strings.TrimSpace(cur.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		cur.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case quote != 0:
+			cur.WriteByte(ch)
+			if ch == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				cur.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			cur.WriteByte(ch)
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			cur.WriteByte('\n')
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			cur.WriteByte(' ')
+		case ch == ';':
+			if inTriggerBody(cur.String()) {
+				cur.WriteByte(ch)
+			} else {
+				flush()
+			}
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	flush()
+	return stmts
+}
+
+// inTriggerBody 当前语句是 CREATE TRIGGER 且尚未以 END 结束
+func inTriggerBody(stmt string) bool {
+	fields := strings.Fields(strings.ToUpper(stmt))
+	if len(fields) < 2 || fields[0] != "CREATE" {
+		return false
+	}
+	isTrigger := fields[1] == "TRIGGER" ||
+		(len(fields) > 2 && (fields[1] == "TEMP" || fields[1] == "TEMPORARY") && fields[2] == "TRIGGER")
+	return isTrigger && fields[len(fields)-1] != "END"
+}
+
+// Create 在 dir 下每个方言目录中创建下一个版本的空迁移文件，返回创建的文件路径
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
+	}
+
+	var next uint64 = 1
+	for _, dialect := range Dialects {
+		migrations, err := Load(os.DirFS(dir), dialect)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		for _, m := range migrations {
+			if m.Version >= next {
+				next = m.Version + 1
+			}
+		}
+	}
+
+	var files []string
+	for _, dialect := range Dialects {
+		if err := os.MkdirAll(filepath.Join(dir, dialect), 0o755); err != nil {
+			return nil, err
+		}
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, dialect, fmt.Sprintf("%03d_%s.%s.sql", next, name, direction))
+			content := fmt.Sprintf("-- %s (%s, %s)\n", name, dialect, direction)
+			if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,327 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{"empty", "  \n-- only a comment\n", nil},
+		{"two statements", "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);", []string{
+			"CREATE TABLE a (id INT)",
+			"CREATE TABLE b (id INT)",
+		}},
+		{"missing final semicolon", "SELECT 1;\nSELECT 2", []string{"SELECT 1", "SELECT 2"}},
+		{"semicolons in quotes", `INSERT INTO t VALUES ('a;b', "c;d", ` + "`e;f`" + `);`, []string{
+			`INSERT INTO t VALUES ('a;b', "c;d", ` + "`e;f`" + `)`,
+		}},
+		{"escaped quote", `INSERT INTO t VALUES ('it\'s; fine');SELECT 1`, []string{
+			`INSERT INTO t VALUES ('it\'s; fine')`,
+			"SELECT 1",
+		}},
+		{"doubled quote", "INSERT INTO t VALUES ('it''s; fine');", []string{
+			"INSERT INTO t VALUES ('it''s; fine')",
+		}},
+		{"line comment", "SELECT 1; -- trailing; comment\nSELECT 2;", []string{"SELECT 1", "SELECT 2"}},
+		{"block comment", "SELECT /* a; b */ 1;", []string{"SELECT   1"}},
+		{"unterminated block comment", "SELECT 1; /* never closed;", []string{"SELECT 1"}},
+		{"trigger body", "CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  INSERT INTO b VALUES (1);\n  DELETE FROM c;\nEND;\nSELECT 1;", []string{
+			"CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  INSERT INTO b VALUES (1);\n  DELETE FROM c;\nEND",
+			"SELECT 1",
+		}},
+		{"temp trigger", "create temp trigger t after delete on a begin delete from b; end; select 1", []string{
+			"create temp trigger t after delete on a begin delete from b; end",
+			"select 1",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []uint64
+		wantErr  string
+	}{
+		{"sorted by version", fstest.MapFS{
+			"sqlite/010_later.up.sql":  {Data: []byte("SELECT 10;")},
+			"sqlite/002_second.up.sql": {Data: []byte("SELECT 2;")},
+			"sqlite/README.md":         {Data: []byte("ignored")},
+			"mysql/001_other.up.sql":   {Data: []byte("SELECT 1;")},
+		}, []uint64{2, 10}, ""},
+		{"missing dialect", fstest.MapFS{
+			"mysql/001_init.up.sql": {Data: []byte("SELECT 1;")},
+		}, nil, "no migrations for dialect"},
+		{"bad file name", fstest.MapFS{
+			"sqlite/1-init.up.sql": {Data: []byte("SELECT 1;")},
+		}, nil, "invalid migration file name"},
+		{"conflicting names", fstest.MapFS{
+			"sqlite/001_init.up.sql":    {Data: []byte("SELECT 1;")},
+			"sqlite/001_other.down.sql": {Data: []byte("SELECT 1;")},
+		}, nil, "conflicting names"},
+		{"down without up", fstest.MapFS{
+			"sqlite/001_init.down.sql": {Data: []byte("SELECT 1;")},
+		}, nil, "has no up file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files, "sqlite")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var versions []uint64
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+			if !reflect.DeepEqual(versions, tt.versions) {
+				t.Errorf("versions = %v, want %v", versions, tt.versions)
+			}
+		})
+	}
+}
+
+// openTestDB 内存 SQLite，单连接保证所有查询落在同一个库
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+var testMigrations = fstest.MapFS{
+	"sqlite/001_items.up.sql": {Data: []byte(`
+		CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+		CREATE TABLE item_log (item_id INTEGER NOT NULL);
+		-- 触发器内的分号不能拆开
+		CREATE TRIGGER items_log AFTER INSERT ON items BEGIN
+			INSERT INTO item_log (item_id) VALUES (new.id);
+		END;`)},
+	"sqlite/001_items.down.sql": {Data: []byte("DROP TRIGGER items_log; DROP TABLE item_log; DROP TABLE items;")},
+	"sqlite/002_seed.up.sql":    {Data: []byte("INSERT INTO items (name) VALUES ('who?');")},
+	"sqlite/002_seed.down.sql":  {Data: []byte("DELETE FROM items;")},
+}
+
+func versionsOf(migrations []Migration) []uint64 {
+	var versions []uint64
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	return versions
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	db := openTestDB(t)
+	var funcRan int
+	m, err := New(db, testMigrations, map[uint64]Func{
+		2: func(tx *gorm.DB) error {
+			funcRan++
+			return tx.Exec("UPDATE items SET name = ?", "seeded").Error
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := m.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versionsOf(done); !reflect.DeepEqual(got, []uint64{1, 2}) {
+		t.Fatalf("Up applied %v, want [1 2]", got)
+	}
+	var name string
+	db.Raw("SELECT name FROM items").Scan(&name)
+	var logged int64
+	db.Raw("SELECT COUNT(*) FROM item_log").Scan(&logged)
+	if name != "seeded" || logged != 1 || funcRan != 1 {
+		t.Errorf("after Up: name = %q, trigger rows = %d, func ran %d times", name, logged, funcRan)
+	}
+
+	// 再次执行不应重复迁移
+	if done, err := m.Up(); err != nil || len(done) != 0 {
+		t.Errorf("second Up = %v, %v, want nothing to do", versionsOf(done), err)
+	}
+
+	done, err = m.Down(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versionsOf(done); !reflect.DeepEqual(got, []uint64{2}) {
+		t.Errorf("Down(1) rolled back %v, want [2]", got)
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versionsOf(pending); !reflect.DeepEqual(got, []uint64{2}) {
+		t.Errorf("pending after Down(1) = %v, want [2]", got)
+	}
+
+	if _, err := m.Down(10); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasTable("items") {
+		t.Error("items table still exists after rolling everything back")
+	}
+}
+
+func TestMigratorUpRollsBackFailedMigration(t *testing.T) {
+	db := openTestDB(t)
+	files := fstest.MapFS{
+		"sqlite/001_ok.up.sql":     {Data: []byte("CREATE TABLE ok (id INTEGER);")},
+		"sqlite/002_broken.up.sql": {Data: []byte("CREATE TABLE half (id INTEGER); INSERT INTO missing VALUES (1);")},
+	}
+	m, err := New(db, files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := m.Up()
+	if err == nil || !strings.Contains(err.Error(), "2_broken") {
+		t.Fatalf("Up error = %v, want failure in 2_broken", err)
+	}
+	if got := versionsOf(done); !reflect.DeepEqual(got, []uint64{1}) {
+		t.Errorf("Up applied %v, want [1]", got)
+	}
+	if db.Migrator().HasTable("half") {
+		t.Error("statements of the failed migration were not rolled back")
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].AppliedAt == nil || statuses[1].AppliedAt != nil {
+		t.Errorf("statuses = %+v, want only 001 applied", statuses)
+	}
+}
+
+func TestMigratorStatusReportsMissingFiles(t *testing.T) {
+	db := openTestDB(t)
+	m, err := New(db, testMigrations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 文件被删除后，已执行的版本仍应出现在状态中
+	older := fstest.MapFS{
+		"sqlite/001_items.up.sql": testMigrations["sqlite/001_items.up.sql"],
+	}
+	m, err = New(db, older, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].Missing || !statuses[1].Missing || statuses[1].Name != "seed" {
+		t.Errorf("statuses = %+v, want 002_seed marked missing", statuses)
+	}
+}
+
+func TestNewRejectsFuncWithoutFiles(t *testing.T) {
+	_, err := New(openTestDB(t), testMigrations, map[uint64]Func{3: func(*gorm.DB) error { return nil }})
+	if err == nil || !strings.Contains(err.Error(), "migration func 3") {
+		t.Errorf("error = %v, want missing files for func 3", err)
+	}
+}
+
+func TestDownRequiresDownFile(t *testing.T) {
+	db := openTestDB(t)
+	m, err := New(db, fstest.MapFS{"sqlite/001_init.up.sql": {Data: []byte("CREATE TABLE a (id INTEGER);")}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Down(1); err == nil || !strings.Contains(err.Error(), "has no down file") {
+		t.Errorf("Down error = %v, want missing down file", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range Dialects {
+		if err := os.MkdirAll(filepath.Join(dir, dialect), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 两个方言的最大版本不同时，取较大者加一
+	os.WriteFile(filepath.Join(dir, "mysql", "004_old.up.sql"), []byte("SELECT 1;"), 0o644)
+	os.WriteFile(filepath.Join(dir, "sqlite", "002_old.up.sql"), []byte("SELECT 1;"), 0o644)
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"Add Orders Index!", "005_add_orders_index", false},
+		{"  ", "", true},
+		{"---", "", true},
+	}
+	for _, tt := range tests {
+		files, err := Create(dir, tt.name)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Create(%q) created %v, want error", tt.name, files)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Create(%q): %v", tt.name, err)
+		}
+		if len(files) != 2*len(Dialects) {
+			t.Fatalf("Create(%q) created %v", tt.name, files)
+		}
+		for _, file := range files {
+			if !strings.HasPrefix(filepath.Base(file), tt.want+".") {
+				t.Errorf("Create(%q) created %s, want prefix %s", tt.name, file, tt.want)
+			}
+		}
+	}
+
+	migrations, err := Load(os.DirFS(dir), "sqlite")
+	if err != nil {
+		t.Fatalf("created files do not load: %v", err)
+	}
+	if last := migrations[len(migrations)-1]; last.Version != 5 || last.Name != "add_orders_index" {
+		t.Errorf("last migration = %d_%s, want 5_add_orders_index", last.Version, last.Name)
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidAddress 不是 0x 开头的 20 字节十六进制地址
+var ErrInvalidAddress = errors.New("invalid address")
+
+// Address 以太坊地址，统一为 EIP-55 校验和格式
+// 监听器写入的是 common.Address.Hex()，调用方传入的常为小写；写入数据库和查询参数都经过规范化，
+// 按地址查询可以直接用等值比较并走索引
+type Address string
+
+// ParseAddress 解析 0x 开头的十六进制地址，大小写不限，返回校验和格式
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") || !common.IsHexAddress(s) {
+		return "", fmt.Errorf("%w %q: expected 0x followed by 40 hex digits", ErrInvalidAddress, s)
+	}
+	return Address(common.HexToAddress(s).Hex()), nil
+}
+
+// NewAddress 由 common.Address 创建地址
+func NewAddress(addr common.Address) Address {
+	return Address(addr.Hex())
+}
+
+// Common 转换为 common.Address，无效地址返回零地址
+func (a Address) Common() common.Address {
+	return common.HexToAddress(string(a))
+}
+
+// String 地址字符串，已规范化的地址为校验和格式
+func (a Address) String() string {
+	return string(a)
+}
+
+// Canonical 返回校验和格式，空地址和无法解析的地址原样返回
+func (a Address) Canonical() Address {
+	if canonical, err := ParseAddress(string(a)); err == nil {
+		return canonical
+	}
+	return a
+}
+
+// Equal 不区分大小写比较两个地址
+func (a Address) Equal(other Address) bool {
+	return strings.EqualFold(string(a), string(other))
+}
+
+// Value 写入数据库时转为校验和格式，空地址写入空字符串，其他无效值返回错误
+func (a Address) Value() (driver.Value, error) {
+	if a == "" {
+		return "", nil
+	}
+	canonical, err := ParseAddress(string(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(canonical), nil
+}
+
+// Scan 读取时转为校验和格式，兼容迁移前的小写数据
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = ""
+	case []byte:
+		*a = Address(v).Canonical()
+	case string:
+		*a = Address(v).Canonical()
+	default:
+		return fmt.Errorf("cannot scan %T into Address", src)
+	}
+	return nil
+}
+
+// UnmarshalJSON 校验并规范化请求中的地址，空字符串表示未提供
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*a = ""
+		return nil
+	}
+	parsed, err := ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
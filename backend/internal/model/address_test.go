@@ -0,0 +1,144 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Address
+		wantErr bool
+	}{
+		{checksummed, checksummed, false},
+		{strings.ToLower(checksummed), checksummed, false},
+		{"0x" + strings.ToUpper(checksummed[2:]), checksummed, false},
+		{"0X" + checksummed[2:], checksummed, false},
+		{"  " + checksummed + "\n", checksummed, false},
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000", false},
+		{"", "", true},
+		{"0x", "", true},
+		{checksummed[2:], "", true},
+		{checksummed[:41], "", true},
+		{checksummed + "0", "", true},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeZ", "", true},
+		{"1x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", true},
+		{"vitalik.eth", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("ParseAddress(%q) = %q, %v, want ErrInvalidAddress", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseAddress(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestAddressCanonicalAndEqual(t *testing.T) {
+	lower := Address(strings.ToLower(checksummed))
+	tests := []struct {
+		in   Address
+		want Address
+	}{
+		{lower, checksummed},
+		{checksummed, checksummed},
+		{"", ""},
+		{"not an address", "not an address"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Canonical(); got != tt.want {
+			t.Errorf("%q.Canonical() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if !lower.Equal(checksummed) || lower.Equal("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359") {
+		t.Error("Equal must compare addresses case-insensitively")
+	}
+}
+
+func TestAddressValue(t *testing.T) {
+	tests := []struct {
+		in      Address
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{Address(strings.ToLower(checksummed)), checksummed, false},
+		{checksummed, checksummed, false},
+		{"0x1234", "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.Value()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q.Value() = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q.Value() = %v, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestAddressScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    Address
+		wantErr bool
+	}{
+		{nil, "", false},
+		{strings.ToLower(checksummed), checksummed, false},
+		{[]byte(strings.ToLower(checksummed)), checksummed, false},
+		{"", "", false},
+		{int64(1), "", true},
+	}
+	for _, tt := range tests {
+		a := Address("previous")
+		err := a.Scan(tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %q, want error", tt.src, a)
+			}
+			continue
+		}
+		if err != nil || a != tt.want {
+			t.Errorf("Scan(%#v) = %q, %v, want %q", tt.src, a, err, tt.want)
+		}
+	}
+}
+
+func TestAddressUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Address
+		wantErr bool
+	}{
+		{`"` + strings.ToLower(checksummed) + `"`, checksummed, false},
+		{`""`, "", false},
+		{`"0x1234"`, "", true},
+		{`42`, "", true},
+	}
+	for _, tt := range tests {
+		var a Address
+		err := json.Unmarshal([]byte(tt.in), &a)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %q, want error", tt.in, a)
+			}
+			continue
+		}
+		if err != nil || a != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, %v, want %q", tt.in, a, err, tt.want)
+		}
+	}
+}
@@ -33,7 +33,7 @@ const (
 // Brand 品牌
 type Brand struct {
 	ID            uint64    `json:"id" gorm:"primaryKey"`
-	BrandAddress  string    `json:"brandAddress" gorm:"type:varchar(191);uniqueIndex;not null"`
+	BrandAddress  Address   `json:"brandAddress" gorm:"type:varchar(191);uniqueIndex;not null"`
 	BrandName     string    `json:"brandName" gorm:"type:varchar(191);not null"`
 	IsAuthorized  bool      `json:"isAuthorized" gorm:"default:false"`
 	RegisteredAt  time.Time `json:"registeredAt" gorm:"not null"`
@@ -45,15 +45,20 @@ type Brand struct {
 // Asset 资产
 type Asset struct {
 	ID             uint64             `json:"id" gorm:"primaryKey"`
-	Owner          string             `json:"owner" gorm:"type:varchar(191);index;not null"`
-	Brand          string             `json:"brand" gorm:"type:varchar(191);index"` // 品牌方地址
+	Owner          Address            `json:"owner" gorm:"type:varchar(191);index;not null"`
+	Brand          Address            `json:"brand" gorm:"type:varchar(191);index"` // 品牌方地址
 	Name           string             `json:"name" gorm:"type:varchar(500);not null"`
 	SerialNumber   string             `json:"serialNumber" gorm:"type:varchar(191);uniqueIndex;not null"`
 	MetadataURI    string             `json:"metadataURI" gorm:"type:text"`
-	Images         string             `json:"images" gorm:"type:text"` // JSON 数组，存储 base64 图片
+	Images         []string           `json:"images" gorm:"-"`                                // 图片地址，由 ImageRecords 生成
+	Thumbnails     []string           `json:"thumbnails" gorm:"-"`                            // 缩略图地址，与 Images 一一对应
+	ImageRecords   []AssetImage       `json:"-" gorm:"foreignKey:AssetID;references:ID"` // 按 position 排序
 	Status         VerificationStatus `json:"status" gorm:"default:0"`
+	Verifier       Address            `json:"verifier" gorm:"type:varchar(191)"` // 最近一次验证的操作人地址
 	IsListed       bool               `json:"isListed" gorm:"default:false"`
-	Price          string             `json:"price" gorm:"type:varchar(191);default:0"` // wei as string
+	Price          Wei                `json:"price"`                                          // 挂单价格（wei）
+	PriceEth       string             `json:"priceEth" gorm:"-"`                              // 以 ETH 为单位的价格
+	PriceFiat      *FiatValue         `json:"priceFiat,omitempty" gorm:"-"`                   // 按价格源折算的法币价值，需配置价格源
 	CreatedAt      time.Time          `json:"createdAt" gorm:"not null"`
 	TxHash         string             `json:"txHash" gorm:"type:varchar(191);index;not null"`
 	BlockNum       uint64             `json:"blockNum" gorm:"index;not null"`
@@ -64,9 +69,11 @@ type Asset struct {
 type Order struct {
 	ID             uint64      `json:"id" gorm:"primaryKey"`
 	AssetID        uint64      `json:"assetId" gorm:"index;not null"`
-	Seller         string      `json:"seller" gorm:"type:varchar(191);index;not null"`
-	Buyer          string      `json:"buyer" gorm:"type:varchar(191);index;not null"`
-	Price          string      `json:"price" gorm:"type:varchar(191);not null"` // wei as string
+	Seller         Address     `json:"seller" gorm:"type:varchar(191);index;not null"`
+	Buyer          Address     `json:"buyer" gorm:"type:varchar(191);index;not null"`
+	Price          Wei         `json:"price" gorm:"not null"`          // 成交价格（wei）
+	PriceEth       string      `json:"priceEth" gorm:"-"`              // 以 ETH 为单位的价格
+	PriceFiat      *FiatValue  `json:"priceFiat,omitempty" gorm:"-"`   // 按价格源折算的法币价值，需配置价格源
 	Status         OrderStatus `json:"status" gorm:"default:0"`
 	OrderCreatedAt time.Time   `json:"orderCreatedAt" gorm:"not null"`
 	PaidAt         *time.Time  `json:"paidAt"`
@@ -80,14 +87,22 @@ type Order struct {
 	gorm.Model
 }
 
+// AfterFind 格式化 ETH 价格
+func (o *Order) AfterFind(tx *gorm.DB) error {
+	o.PriceEth = o.Price.Ether()
+	return nil
+}
+
 // AssetOwnerHistory 资产所有权历史
+// 每条记录对应一次注册或转移，注册时 FromAddress 为零地址
 type AssetOwnerHistory struct {
-	ID        uint64    `json:"id" gorm:"primaryKey"`
-	AssetID   uint64    `json:"assetId" gorm:"index;not null"`
-	Owner     string    `json:"owner" gorm:"type:varchar(191);index;not null"`
-	Timestamp time.Time `json:"timestamp" gorm:"not null"`
-	TxHash    string    `json:"txHash" gorm:"type:varchar(191);index;not null"`
-	BlockNum  uint64    `json:"blockNum" gorm:"index;not null"`
+	ID          uint64    `json:"id" gorm:"primaryKey"`
+	AssetID     uint64    `json:"assetId" gorm:"index;not null"`
+	FromAddress Address   `json:"from" gorm:"type:varchar(191);index"`
+	Owner       Address   `json:"owner" gorm:"type:varchar(191);index;not null"`
+	Timestamp   time.Time `json:"timestamp" gorm:"not null"` // 事件所在区块的时间
+	TxHash      string    `json:"txHash" gorm:"type:varchar(191);index;not null"`
+	BlockNum    uint64    `json:"blockNum" gorm:"index;not null"`
+	LogIndex    uint      `json:"logIndex"`
 	gorm.Model
 }
-
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// AuthNonce 钱包登录使用的一次性随机数
+type AuthNonce struct {
+	ID        uint64     `json:"-" gorm:"primaryKey"`
+	Nonce     string     `json:"nonce" gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"index"`
+	UsedAt    *time.Time `json:"-"`
+
+	CreatedAt time.Time `json:"-"`
+}
+
+// AuthSession 签名登录后签发的会话
+// 只保存 token 的 SHA-256，token 本身只在登录时返回给客户端
+type AuthSession struct {
+	ID        uint64     `json:"-" gorm:"primaryKey"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	Address   Address    `json:"address" gorm:"type:varchar(42);index;not null"`
+	ChainID   uint64     `json:"chainId"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"index"`
+	RevokedAt *time.Time `json:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"-"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// blob 存储后端
+const (
+	StorageLocal = "local" // 本地文件系统
+	StorageIPFS  = "ipfs"  // IPFS 节点
+)
+
+// 图片缩放版本，规格见 imaging.Variants
+const (
+	VariantThumbnail = "thumbnail" // 长边 320px，用于列表
+	VariantMedium    = "medium"    // 长边 1024px，用于详情
+)
+
+// AssetImage 资产图片
+// 图片内容按 SHA-256 存储在 blob 存储中，表中只保存哈希、类型、尺寸和顺序
+type AssetImage struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	AssetID   uint64    `json:"assetId" gorm:"uniqueIndex:idx_asset_image_position;not null"`
+	Position  int       `json:"position" gorm:"uniqueIndex:idx_asset_image_position;not null"` // 从 0 开始
+	Hash      string    `json:"hash" gorm:"type:varchar(64);index;not null"`                   // 内容的 SHA-256（十六进制）
+	MimeType  string    `json:"mimeType" gorm:"type:varchar(64);not null"`
+	Width     int       `json:"width" gorm:"default:0"`
+	Height    int       `json:"height" gorm:"default:0"`
+	Size      int64     `json:"size" gorm:"default:0"`
+	Storage   string    `json:"storage" gorm:"type:varchar(16);not null"`                             // 写入时使用的 blob 存储
+	Location  string    `json:"location" gorm:"type:varchar(191);not null"`                           // 在 blob 存储中的位置：本地为哈希，IPFS 为 CID
+	PHash     string    `json:"phash" gorm:"column:phash;type:varchar(16);index;not null;default:''"` // 64 位感知哈希（十六进制），为空表示尚未处理
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ImageVariant 图片的缩放版本，按原图哈希共享，同一张图片用于多个资产时只生成一次
+type ImageVariant struct {
+	ID         uint64    `json:"id" gorm:"primaryKey"`
+	SourceHash string    `json:"sourceHash" gorm:"type:varchar(64);uniqueIndex:idx_image_variant;not null"`
+	Variant    string    `json:"variant" gorm:"type:varchar(16);uniqueIndex:idx_image_variant;not null"`
+	Hash       string    `json:"hash" gorm:"type:varchar(64);not null"`
+	MimeType   string    `json:"mimeType" gorm:"type:varchar(64);not null"`
+	Width      int       `json:"width" gorm:"default:0"`
+	Height     int       `json:"height" gorm:"default:0"`
+	Size       int64     `json:"size" gorm:"default:0"`
+	Storage    string    `json:"storage" gorm:"type:varchar(16);not null"`
+	Location   string    `json:"location" gorm:"type:varchar(191);not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// URL 原图的访问地址，v 参数随内容变化，便于客户端长期缓存
+func (img *AssetImage) URL() string {
+	version := img.Hash
+	if len(version) > 12 {
+		version = version[:12]
+	}
+	return fmt.Sprintf("/assets/%d/images/%d?v=%s", img.AssetID, img.Position, version)
+}
+
+// VariantURL 缩放版本的访问地址，缩放版本不存在时服务端返回原图
+func (img *AssetImage) VariantURL(variant string) string {
+	return img.URL() + "&size=" + variant
+}
+
+// AfterFind 根据预加载的图片记录生成图片地址，并格式化 ETH 价格
+func (a *Asset) AfterFind(tx *gorm.DB) error {
+	a.PriceEth = a.Price.Ether()
+	a.Images = make([]string, 0, len(a.ImageRecords))
+	a.Thumbnails = make([]string, 0, len(a.ImageRecords))
+	for i := range a.ImageRecords {
+		a.Images = append(a.Images, a.ImageRecords[i].URL())
+		a.Thumbnails = append(a.Thumbnails, a.ImageRecords[i].VariantURL(VariantThumbnail))
+	}
+	return nil
+}
+
+// PHashBand 感知哈希索引中的一段，见 migrations 004_phash_index
+type PHashBand struct {
+	PHash string `gorm:"column:phash;type:varchar(16);primaryKey"`
+	Band  int    `gorm:"primaryKey;autoIncrement:false"`
+	Value int    `gorm:"index:idx_phash_bands_band_value"`
+}
+
+// TableName 指定表名
+func (PHashBand) TableName() string {
+	return "phash_bands"
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// 延迟数据的处理状态
+const (
+	PayloadPending = "pending" // 等待监听器索引对应交易
+	PayloadApplied = "applied" // 已写入
+	PayloadFailed  = "failed"  // 交易已索引但无法写入（例如交易没有注册资产）
+)
+
+// 延迟数据类型
+const (
+	PayloadAssetImages = "asset_images" // 资产图片，写入该交易注册的资产
+)
+
+// PendingPayload 依附于交易哈希的延迟数据
+// 前端在交易上链前后提交链下数据（如图片），监听器索引到该交易后再写入对应记录
+type PendingPayload struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	TxHash    string     `json:"txHash" gorm:"type:varchar(66);index;not null"`
+	Kind      string     `json:"kind" gorm:"type:varchar(64);not null"`
+	Submitter Address    `json:"submitter" gorm:"type:varchar(42);not null"` // 提交者（已登录的钱包地址）
+	Data      string     `json:"-" gorm:"type:text"`
+	Status    string     `json:"status" gorm:"type:varchar(32);index;not null"`
+	Error     string     `json:"error,omitempty" gorm:"type:text"`
+	AppliedAt *time.Time `json:"appliedAt"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
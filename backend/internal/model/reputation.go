@@ -8,7 +8,7 @@ import (
 // UserReputation 用户信誉
 type UserReputation struct {
 	ID          uint64  `json:"id" gorm:"primaryKey"`
-	UserAddress string  `json:"userAddress" gorm:"type:varchar(191);uniqueIndex;not null"`
+	UserAddress Address `json:"userAddress" gorm:"type:varchar(191);uniqueIndex;not null"`
 	
 	// 等级信息
 	Level            int `json:"level" gorm:"default:1"`
@@ -47,11 +47,11 @@ type UserReputation struct {
 
 // UserReview 用户评价
 type UserReview struct {
-	ID              uint64 `json:"id" gorm:"primaryKey"`
-	OrderID         uint64 `json:"orderId" gorm:"index;not null"`
-	ReviewerAddress string `json:"reviewerAddress" gorm:"type:varchar(191);index;not null"`
-	RevieweeAddress string `json:"revieweeAddress" gorm:"type:varchar(191);index;not null"`
-	Role            string `json:"role" gorm:"type:enum('seller','buyer');not null"` // seller 或 buyer
+	ID              uint64  `json:"id" gorm:"primaryKey"`
+	OrderID         uint64  `json:"orderId" gorm:"index;uniqueIndex:unique_review;not null"`
+	ReviewerAddress Address `json:"reviewerAddress" gorm:"type:varchar(191);index;uniqueIndex:unique_review;not null"`
+	RevieweeAddress Address `json:"revieweeAddress" gorm:"type:varchar(191);index;not null"`
+	Role            string  `json:"role" gorm:"type:varchar(16);uniqueIndex:unique_review;not null"` // 被评价人的角色：seller 或 buyer
 	
 	// 评分
 	Rating int `json:"rating" gorm:"not null"` // 1-5
@@ -65,6 +65,28 @@ type UserReview struct {
 	gorm.Model
 }
 
+// 计入信誉的订单事件类型
+const (
+	ReputationOrderCompleted = "completed"
+	ReputationOrderCancelled = "cancelled"
+	ReputationOrderRefunded  = "refunded"
+)
+
+// ReputationEvent 已计入信誉的订单事件
+// 同一订单的同一类事件只计入一次，重新扫描区块不会重复增加经验值；链重组时按区块号撤销
+type ReputationEvent struct {
+	ID       uint64  `json:"id" gorm:"primaryKey"`
+	OrderID  uint64  `json:"orderId" gorm:"uniqueIndex:idx_reputation_order_kind;not null"`
+	Kind     string  `json:"kind" gorm:"type:varchar(16);uniqueIndex:idx_reputation_order_kind;not null"`
+	Seller   Address `json:"seller" gorm:"type:varchar(191)"`
+	Buyer    Address `json:"buyer" gorm:"type:varchar(191)"`
+	Actor    Address `json:"actor" gorm:"type:varchar(191)"` // 取消订单的一方
+	TxHash   string  `json:"txHash" gorm:"type:varchar(66)"`
+	BlockNum uint64  `json:"blockNum" gorm:"index"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 // LevelConfig 等级配置
 type LevelConfig struct {
 	ID       int    `json:"id" gorm:"primaryKey"`
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// AssetSearchDoc 资产的搜索文档，汇总资产、品牌和元数据中可搜索的字段
+// MySQL 上由 FULLTEXT 索引，SQLite 上由触发器同步到 FTS5 表 asset_search_fts
+type AssetSearchDoc struct {
+	AssetID          uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name             string `gorm:"type:varchar(500);not null;default:''"`
+	SerialNumber     string `gorm:"type:varchar(191);not null;default:''"`
+	BrandName        string `gorm:"type:varchar(191);not null;default:''"`
+	Category         string `gorm:"type:varchar(191);not null;default:'';index"` // 以下来自元数据的 product 字段
+	Model            string `gorm:"type:varchar(191);not null;default:''"`
+	Color            string `gorm:"type:varchar(191);not null;default:''"`
+	Size             string `gorm:"type:varchar(191);not null;default:''"`
+	MetadataIndexed  bool   `gorm:"not null;default:false"` // 元数据字段已写入
+	MetadataAttempts int    `gorm:"not null;default:0"`     // 读取元数据失败的次数
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// SyncState 事件同步进度
+// 每个合约地址一行，记录已完整处理（并已提交）的最后一个区块
+type SyncState struct {
+	ID              uint64 `json:"id" gorm:"primaryKey"`
+	ContractAddress string `json:"contractAddress" gorm:"type:varchar(191);uniqueIndex;not null"`
+	LastBlock       uint64 `json:"lastBlock" gorm:"not null"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName 指定表名
+func (SyncState) TableName() string {
+	return "sync_state"
+}
+
+// ProcessedBlock 已处理区块的哈希记录，用于检测链重组
+// 只记录包含合约事件的区块和每批扫描的最后一个区块，并定期清理旧记录
+type ProcessedBlock struct {
+	ID              uint64 `json:"id" gorm:"primaryKey"`
+	ContractAddress string `json:"contractAddress" gorm:"type:varchar(191);uniqueIndex:idx_processed_block;not null"`
+	BlockNum        uint64 `json:"blockNum" gorm:"uniqueIndex:idx_processed_block;not null"`
+	BlockHash       string `json:"blockHash" gorm:"type:varchar(66);not null"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ChainEvent 已处理的合约事件日志
+// 每条链上日志对应一行，用于去重以及链重组时定位需要回滚的数据
+type ChainEvent struct {
+	ID              uint64 `json:"id" gorm:"primaryKey"`
+	ContractAddress string `json:"contractAddress" gorm:"type:varchar(191);index;not null"`
+	BlockNum        uint64 `json:"blockNum" gorm:"index;not null"`
+	BlockHash       string `json:"blockHash" gorm:"type:varchar(66);not null"`
+	TxHash          string `json:"txHash" gorm:"type:varchar(66);uniqueIndex:idx_chain_event_log;not null"`
+	LogIndex        uint   `json:"logIndex" gorm:"uniqueIndex:idx_chain_event_log;not null"`
+	EventName       string `json:"eventName" gorm:"type:varchar(64);index;not null"`
+
+	// 事件涉及的实体，便于回滚时按实体刷新状态
+	AssetID uint64  `json:"assetId,omitempty" gorm:"index"`
+	OrderID uint64  `json:"orderId,omitempty" gorm:"index"`
+	Address Address `json:"address,omitempty" gorm:"type:varchar(191);index"` // 品牌事件的品牌地址
+
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook 订阅范围
+const (
+	WebhookScopeBrand = "brand" // 该品牌的资产：注册、验证、转移、上下架及相关订单
+	WebhookScopeOwner = "owner" // 该地址转入或转出的资产，以及作为买家或卖家的订单
+)
+
+// Webhook 投递状态
+const (
+	DeliveryPending   = "pending"   // 等待投递或重试
+	DeliveryDelivered = "delivered" // 对方返回 2xx
+	DeliveryDead      = "dead"      // 重试次数用尽，需手动重放
+)
+
+// WebhookSubscription 品牌方或卖家登记的 Webhook 地址
+type WebhookSubscription struct {
+	ID         uint64   `json:"id" gorm:"primaryKey"`
+	Address    Address  `json:"address" gorm:"type:varchar(42);index;not null"` // 订阅的品牌或持有人地址，即创建者的登录地址
+	Scope      string   `json:"scope" gorm:"type:varchar(16);not null"`
+	URL        string   `json:"url" gorm:"type:varchar(2048);not null"`
+	Secret     string   `json:"-" gorm:"type:varchar(128);not null"`             // HMAC 签名密钥，只在创建时返回
+	EventTypes string   `json:"-" gorm:"type:varchar(1024);not null;default:''"` // 逗号分隔，为空表示全部事件
+	Types      []string `json:"eventTypes" gorm:"-"`
+	Active     bool     `json:"active" gorm:"not null;default:true"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SetTypes 设置订阅的事件类型，为空表示全部事件
+func (s *WebhookSubscription) SetTypes(types []string) {
+	s.Types = append([]string{}, types...)
+	s.EventTypes = strings.Join(types, ",")
+}
+
+// AfterFind 从 EventTypes 还原 Types
+func (s *WebhookSubscription) AfterFind(tx *gorm.DB) error {
+	s.Types = []string{}
+	if s.EventTypes != "" {
+		s.Types = strings.Split(s.EventTypes, ",")
+	}
+	return nil
+}
+
+// WebhookDelivery Webhook 发件箱：每个订阅的每个事件一行，与事件在同一事务中写入
+// Payload 为写入时的事件快照，重试和重放发送相同的内容
+type WebhookDelivery struct {
+	ID             uint64     `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint64     `json:"subscriptionId" gorm:"uniqueIndex:idx_webhook_delivery_event;not null"`
+	EventID        uint64     `json:"eventId" gorm:"uniqueIndex:idx_webhook_delivery_event;not null"` // chain_events 的 ID
+	EventType      string     `json:"eventType" gorm:"type:varchar(64);not null"`
+	Payload        string     `json:"-" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"type:varchar(16);index:idx_webhook_delivery_due;not null"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt" gorm:"index:idx_webhook_delivery_due;not null"`
+	LastStatusCode int        `json:"lastStatusCode,omitempty"`
+	LastError      string     `json:"lastError,omitempty" gorm:"type:text"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// WeiDigits uint256 的最大十进制位数
+const WeiDigits = 78
+
+// Wei 以 wei 为单位的金额
+// 数据库中存为左侧补零到 WeiDigits 位的十进制字符串：定长字符串的字典序与数值大小一致，可直接比较和排序，
+// MySQL 和 SQLite 行为相同（MySQL 的 DECIMAL 最多 65 位，放不下 uint256）；JSON 中为不补零的十进制字符串
+type Wei struct {
+	i *big.Int
+}
+
+// NewWei 由 big.Int 创建金额，nil 视为 0
+func NewWei(v *big.Int) Wei {
+	if v == nil {
+		return Wei{}
+	}
+	return Wei{i: new(big.Int).Set(v)}
+}
+
+// ParseWei 解析非负整数的十进制 wei 金额
+func ParseWei(s string) (Wei, error) {
+	v, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok || v.Sign() < 0 {
+		return Wei{}, errors.New("must be a non-negative integer amount in wei")
+	}
+	if len(v.String()) > WeiDigits {
+		return Wei{}, errors.New("exceeds uint256")
+	}
+	return Wei{i: v}, nil
+}
+
+// BigInt 返回金额的副本
+func (w Wei) BigInt() *big.Int {
+	if w.i == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(w.i)
+}
+
+// Cmp 比较两个金额
+func (w Wei) Cmp(other Wei) int {
+	return w.BigInt().Cmp(other.BigInt())
+}
+
+// String 不补零的十进制字符串
+func (w Wei) String() string {
+	return w.BigInt().String()
+}
+
+// Ether 以 ETH 为单位的金额，去除末尾的零，如 "1.5"
+func (w Wei) Ether() string {
+	s := w.String()
+	if len(s) <= 18 {
+		s = strings.Repeat("0", 19-len(s)) + s
+	}
+	whole, frac := s[:len(s)-18], strings.TrimRight(s[len(s)-18:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// Padded 数据库中的补零形式
+func (w Wei) Padded() string {
+	s := w.String()
+	return strings.Repeat("0", WeiDigits-len(s)) + s
+}
+
+func (w Wei) GormDataType() string {
+	return fmt.Sprintf("char(%d)", WeiDigits)
+}
+
+// Value 写入数据库时补零到 WeiDigits 位
+func (w Wei) Value() (driver.Value, error) {
+	if w.i != nil && (w.i.Sign() < 0 || len(w.i.String()) > WeiDigits) {
+		return nil, fmt.Errorf("wei amount %s out of range", w.i)
+	}
+	return w.Padded(), nil
+}
+
+// Scan 读取补零或未补零的十进制字符串，NULL 和空字符串视为 0
+func (w *Wei) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*w = Wei{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Wei", src)
+	}
+	if s == "" {
+		*w = Wei{}
+		return nil
+	}
+	parsed, err := ParseWei(s)
+	if err != nil {
+		return fmt.Errorf("invalid wei amount %q: %w", s, err)
+	}
+	*w = parsed
+	return nil
+}
+
+func (w Wei) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.String())
+}
+
+// UnmarshalJSON 接受十进制字符串或整数
+func (w *Wei) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	parsed, err := ParseWei(s)
+	if err != nil {
+		return err
+	}
+	*w = parsed
+	return nil
+}
+
+// FiatValue 金额按 ETH 价格折算的法币价值
+type FiatValue struct {
+	Currency string `json:"currency"`
+	Amount   string `json:"amount"` // 保留两位小数
+	Rate     string `json:"rate"`   // 1 ETH 的价格
+}
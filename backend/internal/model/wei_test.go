@@ -0,0 +1,195 @@
+package model
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// maxUint256 2^256-1，78 位十进制
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+func TestParseWei(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"0", "0", false},
+		{" 1500000000000000000 ", "1500000000000000000", false},
+		{"007", "7", false},
+		{maxUint256.String(), maxUint256.String(), false},
+		{strings.Repeat("9", WeiDigits), strings.Repeat("9", WeiDigits), false},
+		{strings.Repeat("9", WeiDigits+1), "", true},
+		{"-1", "", true},
+		{"1.5", "", true},
+		{"1e18", "", true},
+		{"0x10", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWei(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseWei(%q) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseWei(%q) = %s, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestWeiFormatting(t *testing.T) {
+	tests := []struct {
+		wei    Wei
+		str    string
+		ether  string
+		padded string
+	}{
+		{Wei{}, "0", "0", strings.Repeat("0", WeiDigits)},
+		{NewWei(nil), "0", "0", strings.Repeat("0", WeiDigits)},
+		{NewWei(big.NewInt(1)), "1", "0.000000000000000001", strings.Repeat("0", WeiDigits-1) + "1"},
+		{NewWei(big.NewInt(1e17)), "100000000000000000", "0.1", strings.Repeat("0", WeiDigits-18) + "100000000000000000"},
+		{NewWei(big.NewInt(1e18)), "1000000000000000000", "1", strings.Repeat("0", WeiDigits-19) + "1000000000000000000"},
+		{NewWei(big.NewInt(1_500_000_000_000_000_000)), "1500000000000000000", "1.5", strings.Repeat("0", WeiDigits-19) + "1500000000000000000"},
+		{NewWei(maxUint256), maxUint256.String(), "115792089237316195423570985008687907853269984665640564039457.584007913129639935", maxUint256.String()},
+	}
+	for _, tt := range tests {
+		if got := tt.wei.String(); got != tt.str {
+			t.Errorf("String() = %s, want %s", got, tt.str)
+		}
+		if got := tt.wei.Ether(); got != tt.ether {
+			t.Errorf("%s.Ether() = %s, want %s", tt.str, got, tt.ether)
+		}
+		if got := tt.wei.Padded(); got != tt.padded || len(got) != WeiDigits {
+			t.Errorf("%s.Padded() = %s, want %s", tt.str, got, tt.padded)
+		}
+	}
+}
+
+func TestNewWeiCopies(t *testing.T) {
+	v := big.NewInt(5)
+	w := NewWei(v)
+	v.SetInt64(6)
+	w.BigInt().SetInt64(7)
+	if w.String() != "5" {
+		t.Errorf("Wei shares its big.Int: got %s, want 5", w)
+	}
+}
+
+func TestWeiPaddedOrdering(t *testing.T) {
+	// 补零后的字典序必须与数值大小一致，数据库才能直接比较和排序
+	values := []string{"0", "9", "10", "99", "100", "1000000000000000000", "999999999999999999", maxUint256.String()}
+	var weis []Wei
+	for _, v := range values {
+		w, err := ParseWei(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		weis = append(weis, w)
+	}
+	byValue := append([]Wei(nil), weis...)
+	sort.Slice(byValue, func(i, j int) bool { return byValue[i].Cmp(byValue[j]) < 0 })
+	byPadded := append([]Wei(nil), weis...)
+	sort.Slice(byPadded, func(i, j int) bool { return byPadded[i].Padded() < byPadded[j].Padded() })
+	for i := range byValue {
+		if byValue[i].Cmp(byPadded[i]) != 0 {
+			t.Fatalf("position %d: numeric order %s, padded order %s", i, byValue[i], byPadded[i])
+		}
+	}
+}
+
+func TestWeiValue(t *testing.T) {
+	tests := []struct {
+		wei     Wei
+		want    string
+		wantErr bool
+	}{
+		{Wei{}, strings.Repeat("0", WeiDigits), false},
+		{NewWei(big.NewInt(42)), strings.Repeat("0", WeiDigits-2) + "42", false},
+		{NewWei(big.NewInt(-1)), "", true},
+		{NewWei(new(big.Int).Exp(big.NewInt(10), big.NewInt(WeiDigits), nil)), "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.wei.Value()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Value() of %s = %v, want error", tt.wei, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Value() of %s = %v, %v, want %s", tt.wei, got, err, tt.want)
+		}
+	}
+}
+
+func TestWeiScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{nil, "0", false},
+		{"", "0", false},
+		{strings.Repeat("0", WeiDigits-2) + "42", "42", false},
+		{[]byte("1500000000000000000"), "1500000000000000000", false},
+		{int64(7), "7", false},
+		{"abc", "", true},
+		{"-5", "", true},
+		{1.5, "", true},
+	}
+	for _, tt := range tests {
+		w := NewWei(big.NewInt(99))
+		err := w.Scan(tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %s, want error", tt.src, w)
+			}
+			continue
+		}
+		if err != nil || w.String() != tt.want {
+			t.Errorf("Scan(%#v) = %s, %v, want %s", tt.src, w, err, tt.want)
+		}
+	}
+}
+
+func TestWeiJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Price Wei `json:"price"`
+	}{NewWei(big.NewInt(1e18))})
+	if err != nil || string(data) != `{"price":"1000000000000000000"}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{`"1000000000000000000"`, "1000000000000000000", false},
+		{`1000000000000000000`, "1000000000000000000", false},
+		{`"0"`, "0", false},
+		{`"-1"`, "", true},
+		{`1.5`, "", true},
+		{`null`, "", true},
+		{`"` + strings.Repeat("9", WeiDigits+1) + `"`, "", true},
+	}
+	for _, tt := range tests {
+		var w Wei
+		err := json.Unmarshal([]byte(tt.in), &w)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %s, want error", tt.in, w)
+			}
+			continue
+		}
+		if err != nil || w.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, %v, want %s", tt.in, w, err, tt.want)
+		}
+	}
+}
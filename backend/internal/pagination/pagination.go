@@ -0,0 +1,227 @@
+// Package pagination 列表接口共用的分页参数和游标
+//
+// 列表默认按 (区块号, ID) 倒序排列，游标记录上一页最后一条记录的排序键，
+// 下一页从该记录之后开始查询，翻页深度不影响查询速度。
+// 支持多种排序的列表在游标中记录排序方式，换了排序的游标视为无效。
+// 游标对客户端不透明，只需把响应中的 next_cursor 原样传回。
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var (
+	limitsMu     sync.RWMutex
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// SetLimits 设置未指定 limit 时每页的条数和每页最多的条数
+func SetLimits(defaultPerPage, maxPerPage int) {
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+	defaultLimit = defaultPerPage
+	maxLimit = maxPerPage
+}
+
+// Limits 返回未指定 limit 时每页的条数和每页最多的条数
+func Limits() (defaultPerPage, maxPerPage int) {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	return defaultLimit, maxLimit
+}
+
+// ErrInvalidCursor 游标或分页参数无效
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor 下一页的起点：上一页最后一条记录的排序键
+// 不能按键翻页的排序（如搜索的相关度）使用 Offset
+type Cursor struct {
+	Sort     string `json:"s,omitempty"` // 生成游标时的排序方式，只有一种排序的列表为空
+	Price    string `json:"p,omitempty"` // 按价格排序时的价格（补零的十进制字符串）
+	BlockNum uint64 `json:"b,omitempty"`
+	ID       uint64 `json:"i,omitempty"`
+	Offset   int    `json:"o,omitempty"`
+}
+
+// Encode 编码为不透明的游标字符串
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode 解析游标字符串
+func Decode(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Offset < 0 || !isDigits(cursor.Price) {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Params 列表分页参数
+type Params struct {
+	Limit  int
+	Cursor *Cursor
+	Offset int // 兼容旧客户端的 offset 参数，只在没有游标时生效
+}
+
+// Parse 从查询参数解析 limit、cursor 和 offset
+// limit 缺省时为默认条数，超过上限时取上限，见 SetLimits；不是正整数时返回错误
+func Parse(query url.Values) (Params, error) {
+	defaultPerPage, maxPerPage := Limits()
+	params := Params{Limit: defaultPerPage}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return params, fmt.Errorf("%w: limit must be a positive integer", ErrInvalidCursor)
+		}
+		params.Limit = min(limit, maxPerPage)
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := Decode(value)
+		if err != nil {
+			return params, err
+		}
+		params.Cursor = cursor
+		return params, nil
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return params, fmt.Errorf("%w: offset must be a non-negative integer", ErrInvalidCursor)
+		}
+		params.Offset = offset
+	}
+	return params, nil
+}
+
+// CheckSort 游标不是按 sort 排序时生成的，返回错误
+func (p Params) CheckSort(sort string) error {
+	if p.Cursor != nil && p.Cursor.Sort != sort {
+		return fmt.Errorf("%w: cursor does not belong to sort %s", ErrInvalidCursor, sort)
+	}
+	return nil
+}
+
+// Start 按偏移量翻页时当前页的起始位置
+func (p Params) Start() int {
+	if p.Cursor != nil {
+		return p.Cursor.Offset
+	}
+	return p.Offset
+}
+
+// Keyset 按 blockColumn、idColumn 倒序取一页，多取一条用于判断是否还有下一页
+// blockColumn 为空时只按 idColumn 排序（用于没有区块号的链下记录）
+func (p Params) Keyset(db *gorm.DB, blockColumn, idColumn string) *gorm.DB {
+	switch {
+	case p.Cursor == nil:
+		db = db.Offset(p.Offset)
+	case blockColumn == "":
+		db = db.Where(idColumn+" < ?", p.Cursor.ID)
+	default:
+		db = db.Where("("+blockColumn+" < ? OR ("+blockColumn+" = ? AND "+idColumn+" < ?))",
+			p.Cursor.BlockNum, p.Cursor.BlockNum, p.Cursor.ID)
+	}
+	if blockColumn != "" {
+		db = db.Order(blockColumn + " DESC")
+	}
+	return db.Order(idColumn + " DESC").Limit(p.Limit + 1)
+}
+
+// Key 按键翻页的一个排序列，Value 为上一页最后一条记录在该列的值（没有游标时不使用）
+type Key struct {
+	Column string
+	Desc   bool
+	Value  interface{}
+}
+
+// Seek 按 keys 依次排序，从游标对应的记录之后取一页，多取一条用于判断是否还有下一页
+// 没有游标时从 offset 开始；最后一列须唯一（如 ID），保证顺序确定
+func (p Params) Seek(db *gorm.DB, keys []Key) *gorm.DB {
+	if p.Cursor == nil {
+		db = db.Offset(p.Offset)
+	} else {
+		condition, args := seekCondition(keys)
+		db = db.Where(condition, args...)
+	}
+	for _, key := range keys {
+		if key.Desc {
+			db = db.Order(key.Column + " DESC")
+		} else {
+			db = db.Order(key.Column)
+		}
+	}
+	return db.Limit(p.Limit + 1)
+}
+
+// seekCondition 排在 keys 对应记录之后的条件：k1 > v1 OR (k1 = v1 AND (k2 > v2 OR ...))，倒序的列用 <
+func seekCondition(keys []Key) (string, []interface{}) {
+	key := keys[0]
+	op := " > ?"
+	if key.Desc {
+		op = " < ?"
+	}
+	if len(keys) == 1 {
+		return key.Column + op, []interface{}{key.Value}
+	}
+	rest, args := seekCondition(keys[1:])
+	condition := "(" + key.Column + op + " OR (" + key.Column + " = ? AND " + rest + "))"
+	return condition, append([]interface{}{key.Value, key.Value}, args...)
+}
+
+// Paged 按偏移量取一页，多取一条用于判断是否还有下一页；排序由调用方指定
+func (p Params) Paged(db *gorm.DB) *gorm.DB {
+	return db.Offset(p.Start()).Limit(p.Limit + 1)
+}
+
+// Page 一页结果
+type Page[T any] struct {
+	Items      []T
+	Total      int64  // 符合筛选条件的记录总数
+	NextCursor string // 没有下一页时为空
+}
+
+// NewPage 截去 Keyset 多取的一条记录，还有下一页时用最后一条记录的 key 生成游标
+func NewPage[T any](items []T, total int64, p Params, key func(T) Cursor) *Page[T] {
+	page := &Page[T]{Items: items, Total: total}
+	if len(items) > p.Limit {
+		page.Items = items[:p.Limit]
+		page.NextCursor = key(page.Items[p.Limit-1]).Encode()
+	}
+	return page
+}
+
+// NewOffsetPage 截去 Paged 多取的一条记录，还有下一页时生成 sort 的偏移量游标
+func NewOffsetPage[T any](items []T, total int64, p Params, sort string) *Page[T] {
+	page := &Page[T]{Items: items, Total: total}
+	if len(items) > p.Limit {
+		page.Items = items[:p.Limit]
+		page.NextCursor = Cursor{Sort: sort, Offset: p.Start() + p.Limit}.Encode()
+	}
+	return page
+}
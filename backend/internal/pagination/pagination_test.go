@@ -0,0 +1,161 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []Cursor{
+		{},
+		{BlockNum: 123, ID: 45},
+		{Sort: "price_asc", Price: "000000000000000000000000000001000000000000000000", BlockNum: 7, ID: 9},
+		{Sort: "relevance", Offset: 40},
+	}
+	for _, cursor := range tests {
+		decoded, err := Decode(cursor.Encode())
+		if err != nil {
+			t.Errorf("Decode(%+v.Encode()) error: %v", cursor, err)
+			continue
+		}
+		if *decoded != cursor {
+			t.Errorf("Decode(%+v.Encode()) = %+v", cursor, *decoded)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []string{
+		"not base64!",
+		Cursor{}.Encode() + "@",
+		"bm90IGpzb24",                  // "not json"
+		"eyJvIjotMX0",                  // {"o":-1}
+		"eyJwIjoiMTsgRFJPUCBUQUJMRSJ9", // {"p":"1; DROP TABLE"}
+	}
+	for _, s := range tests {
+		if _, err := Decode(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	SetLimits(20, 100)
+	cursor := Cursor{Sort: "newest", BlockNum: 5, ID: 6}
+
+	tests := []struct {
+		query   string
+		want    Params
+		wantErr bool
+	}{
+		{"", Params{Limit: 20}, false},
+		{"limit=10", Params{Limit: 10}, false},
+		{"limit=500", Params{Limit: 100}, false},
+		{"limit=0", Params{}, true},
+		{"limit=-1", Params{}, true},
+		{"limit=abc", Params{}, true},
+		{"offset=40", Params{Limit: 20, Offset: 40}, false},
+		{"offset=-1", Params{}, true},
+		{"offset=x", Params{}, true},
+		{"cursor=" + cursor.Encode(), Params{Limit: 20, Cursor: &cursor}, false},
+		{"cursor=" + cursor.Encode() + "&offset=40", Params{Limit: 20, Cursor: &cursor}, false},
+		{"cursor=!!!", Params{}, true},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		got, err := Parse(query)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidCursor", tt.query, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSort(t *testing.T) {
+	tests := []struct {
+		cursor *Cursor
+		sort   string
+		ok     bool
+	}{
+		{nil, "newest", true},
+		{&Cursor{Sort: "newest"}, "newest", true},
+		{&Cursor{Sort: "newest"}, "price_asc", false},
+		{&Cursor{Sort: "relevance", Offset: 20}, "newest", false},
+		{&Cursor{}, "oldest", false},
+	}
+	for _, tt := range tests {
+		err := Params{Limit: 10, Cursor: tt.cursor}.CheckSort(tt.sort)
+		if tt.ok && err != nil {
+			t.Errorf("CheckSort(%+v, %q) = %v, want nil", tt.cursor, tt.sort, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("CheckSort(%+v, %q) = %v, want ErrInvalidCursor", tt.cursor, tt.sort, err)
+		}
+	}
+}
+
+func TestSeekCondition(t *testing.T) {
+	tests := []struct {
+		keys      []Key
+		condition string
+		args      []interface{}
+	}{
+		{
+			[]Key{{Column: "id", Desc: true, Value: 9}},
+			"id < ?",
+			[]interface{}{9},
+		},
+		{
+			[]Key{{Column: "block_num", Value: 3}, {Column: "id", Value: 9}},
+			"(block_num > ? OR (block_num = ? AND id > ?))",
+			[]interface{}{3, 3, 9},
+		},
+		{
+			[]Key{{Column: "price", Value: "100"}, {Column: "block_num", Desc: true, Value: 3}, {Column: "id", Desc: true, Value: 9}},
+			"(price > ? OR (price = ? AND (block_num < ? OR (block_num = ? AND id < ?))))",
+			[]interface{}{"100", "100", 3, 3, 9},
+		},
+	}
+	for _, tt := range tests {
+		condition, args := seekCondition(tt.keys)
+		if condition != tt.condition || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("seekCondition(%+v) = %q %v, want %q %v", tt.keys, condition, args, tt.condition, tt.args)
+		}
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	key := func(id int) Cursor { return Cursor{ID: uint64(id)} }
+	tests := []struct {
+		items     []int
+		limit     int
+		wantItems []int
+		wantNext  string
+	}{
+		{[]int{5, 4, 3}, 3, []int{5, 4, 3}, ""},
+		{[]int{5, 4, 3, 2}, 3, []int{5, 4, 3}, Cursor{ID: 3}.Encode()},
+		{nil, 3, nil, ""},
+	}
+	for _, tt := range tests {
+		page := NewPage(tt.items, 10, Params{Limit: tt.limit}, key)
+		if !reflect.DeepEqual(page.Items, tt.wantItems) || page.NextCursor != tt.wantNext {
+			t.Errorf("NewPage(%v, limit %d) = %v %q, want %v %q", tt.items, tt.limit, page.Items, page.NextCursor, tt.wantItems, tt.wantNext)
+		}
+	}
+
+	page := NewOffsetPage([]int{1, 2, 3}, 10, Params{Limit: 2, Offset: 4}, "relevance")
+	if want := (Cursor{Sort: "relevance", Offset: 6}).Encode(); page.NextCursor != want {
+		t.Errorf("NewOffsetPage next cursor = %q, want %q", page.NextCursor, want)
+	}
+}
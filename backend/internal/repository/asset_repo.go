@@ -3,8 +3,10 @@ package repository
 import (
 	"chain-vault-backend/internal/database"
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -29,6 +31,16 @@ func (r *AssetRepository) ensureDB() error {
 	return nil
 }
 
+// WithTx 返回绑定到指定事务的仓储
+func (r *AssetRepository) WithTx(tx *gorm.DB) *AssetRepository {
+	return &AssetRepository{db: tx}
+}
+
+// withImages 查询资产时按顺序预加载图片记录，用于生成 Asset.Images
+func (r *AssetRepository) withImages() *gorm.DB {
+	return preloadImages(r.db)
+}
+
 func (r *AssetRepository) Create(asset *model.Asset) error {
 	if err := r.ensureDB(); err != nil {
 		return err
@@ -41,67 +53,100 @@ func (r *AssetRepository) FindByID(id uint64) (*model.Asset, error) {
 		return nil, err
 	}
 	var asset model.Asset
-	err := r.db.First(&asset, id).Error
+	err := r.withImages().First(&asset, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return &asset, err
 }
 
-func (r *AssetRepository) FindAll(limit, offset int) ([]model.Asset, error) {
+// AssetFilter 资产列表的筛选和排序条件，零值表示不限制
+type AssetFilter struct {
+	Owner         model.Address
+	Brand         model.Address // 品牌地址
+	Status        *model.VerificationStatus
+	Listed        *bool
+	MinPrice      *model.Wei // 价格范围，包含两端
+	MaxPrice      *model.Wei
+	CreatedFrom   *time.Time // 创建时间 >= CreatedFrom
+	CreatedBefore *time.Time // 创建时间 < CreatedBefore
+	FromBlock     *uint64    // 区块号范围，包含两端
+	ToBlock       *uint64
+	Sort          string // newest（默认）/ oldest / price_asc / price_desc
+}
+
+// Find 分页查询符合条件的资产
+// 各种排序都按 (排序列, 区块号, ID) 使用游标翻页，游标与排序方式绑定，换了排序时返回 pagination.ErrInvalidCursor
+func (r *AssetRepository) Find(filter AssetFilter, page pagination.Params) (*pagination.Page[model.Asset], error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
-	var assets []model.Asset
-	// 排序：1. 已上架的优先 2. 按更新时间倒序 3. 按创建时间倒序
-	err := r.db.Order("is_listed DESC, updated_at DESC, created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
 
-func (r *AssetRepository) Count() (int64, error) {
-	if err := r.ensureDB(); err != nil {
-		return 0, err
+	db := r.db.Model(&model.Asset{})
+	if filter.Owner != "" {
+		db = db.Where("owner = ?", filter.Owner)
+	}
+	if filter.Brand != "" {
+		db = db.Where("brand = ?", filter.Brand)
+	}
+	if filter.Status != nil {
+		db = db.Where("status = ?", *filter.Status)
+	}
+	if filter.Listed != nil {
+		db = db.Where("is_listed = ?", *filter.Listed)
+	}
+	db = wherePriceRange(db, "price", filter.MinPrice, filter.MaxPrice)
+	if filter.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.FromBlock != nil {
+		db = db.Where("block_num >= ?", *filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		db = db.Where("block_num <= ?", *filter.ToBlock)
 	}
-	var count int64
-	err := r.db.Model(&model.Asset{}).Count(&count).Error
-	return count, err
-}
 
-func (r *AssetRepository) FindByOwner(owner string, limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
+	sort := filter.Sort
+	if sort == "" {
+		sort = SortNewest
+	}
+	if err := page.CheckSort(sort); err != nil {
 		return nil, err
 	}
-	var assets []model.Asset
-	err := r.db.Where("LOWER(owner) = LOWER(?)", owner).
-		Order("is_listed DESC, updated_at DESC, created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
+	return seekPage(db, page, assetSortKeys(sort, page), assetCursor(sort), preloadImages)
 }
 
-func (r *AssetRepository) FindByTxHash(txHash string) (*model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
+// wherePriceRange 按价格范围筛选，nil 表示不限制
+// 价格列为定长补零的十进制字符串，直接比较即为数值比较，见 model.Wei
+func wherePriceRange(db *gorm.DB, column string, minPrice, maxPrice *model.Wei) *gorm.DB {
+	if minPrice != nil {
+		db = db.Where(column+" >= ?", *minPrice)
 	}
-	var asset model.Asset
-	err := r.db.Where("tx_hash = ?", txHash).First(&asset).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, nil
+	if maxPrice != nil {
+		db = db.Where(column+" <= ?", *maxPrice)
 	}
-	return &asset, err
+	return db
+}
+
+func (r *AssetRepository) Count() (int64, error) {
+	if err := r.ensureDB(); err != nil {
+		return 0, err
+	}
+	var count int64
+	err := r.db.Model(&model.Asset{}).Count(&count).Error
+	return count, err
 }
 
 // CountByOwner 统计特定所有者的资产数量
-func (r *AssetRepository) CountByOwner(owner string) (int64, error) {
+func (r *AssetRepository) CountByOwner(owner model.Address) (int64, error) {
 	if err := r.ensureDB(); err != nil {
 		return 0, err
 	}
 	var count int64
-	err := r.db.Model(&model.Asset{}).Where("LOWER(owner) = LOWER(?)", owner).Count(&count).Error
+	err := r.db.Model(&model.Asset{}).Where("owner = ?", owner).Count(&count).Error
 	return count, err
 }
 
@@ -120,28 +165,6 @@ func (r *AssetRepository) GetTopOwners(limit int) ([]map[string]interface{}, err
 	return results, err
 }
 
-// GetAssetsByDateRange 按日期范围查询资产
-func (r *AssetRepository) GetAssetsByDateRange(startDate, endDate string, limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var assets []model.Asset
-	query := r.db.Model(&model.Asset{})
-	
-	if startDate != "" {
-		query = query.Where("DATE(created_at) >= ?", startDate)
-	}
-	if endDate != "" {
-		query = query.Where("DATE(created_at) <= ?", endDate)
-	}
-	
-	err := query.Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
-
 // GetDailyStats 获取每日注册统计
 func (r *AssetRepository) GetDailyStats(days int) ([]map[string]interface{}, error) {
 	if err := r.ensureDB(); err != nil {
@@ -158,7 +181,7 @@ func (r *AssetRepository) GetDailyStats(days int) ([]map[string]interface{}, err
 }
 
 // UpdateOwner 更新资产所有者（用于处理转移事件）
-func (r *AssetRepository) UpdateOwner(assetID uint64, newOwner string, txHash string, blockNum uint64) error {
+func (r *AssetRepository) UpdateOwner(assetID uint64, newOwner model.Address, txHash string, blockNum uint64) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -167,9 +190,8 @@ func (r *AssetRepository) UpdateOwner(assetID uint64, newOwner string, txHash st
 	var asset model.Asset
 	if err := r.db.First(&asset, assetID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// 如果资产不存在，可能是先收到转移事件，后收到注册事件
-			// 这种情况下先记录日志，等待注册事件
-			return fmt.Errorf("asset %d not found, waiting for registration event", assetID)
+			// 注册事件早于起始区块时数据库中没有该资产
+			return fmt.Errorf("asset %d: %w", assetID, ErrNotFound)
 		}
 		return err
 	}
@@ -188,82 +210,15 @@ func (r *AssetRepository) FindBySerialNumber(serialNumber string) (*model.Asset,
 		return nil, err
 	}
 	var asset model.Asset
-	err := r.db.Where("serial_number = ?", serialNumber).First(&asset).Error
+	err := r.withImages().Where("serial_number = ?", serialNumber).First(&asset).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return &asset, err
 }
 
-// FindByBrand 查找品牌的所有资产
-func (r *AssetRepository) FindByBrand(brand string, limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var assets []model.Asset
-	err := r.db.Where("brand = ?", brand).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
-
-// FindListed 查找所有在售资产
-func (r *AssetRepository) FindListed(limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var assets []model.Asset
-	// 市场列表：按最近上架时间排序
-	err := r.db.Where("is_listed = ?", true).
-		Order("updated_at DESC, created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
-
-// CountListed 统计在售资产数量
-func (r *AssetRepository) CountListed() (int64, error) {
-	if err := r.ensureDB(); err != nil {
-		return 0, err
-	}
-	var count int64
-	err := r.db.Model(&model.Asset{}).Where("is_listed = ?", true).Count(&count).Error
-	return count, err
-}
-
-// FindByStatus 按验证状态查找资产
-func (r *AssetRepository) FindByStatus(status model.VerificationStatus, limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var assets []model.Asset
-	err := r.db.Where("status = ?", status).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
-
-// Search 搜索资产（按名称或序列号）
-func (r *AssetRepository) Search(keyword string, limit, offset int) ([]model.Asset, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var assets []model.Asset
-	err := r.db.Where("name LIKE ? OR serial_number LIKE ?", "%"+keyword+"%", "%"+keyword+"%").
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&assets).Error
-	return assets, err
-}
-
 // UpdateListingStatus 更新上架状态
-func (r *AssetRepository) UpdateListingStatus(assetID uint64, isListed bool, price string) error {
+func (r *AssetRepository) UpdateListingStatus(assetID uint64, isListed bool, price model.Wei) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -275,8 +230,19 @@ func (r *AssetRepository) UpdateListingStatus(assetID uint64, isListed bool, pri
 		}).Error
 }
 
+// UpdateListedFlag 只更新上架标记，保留原有价格
+func (r *AssetRepository) UpdateListedFlag(assetID uint64, isListed bool) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.Asset{}).
+		Where("id = ?", assetID).
+		Update("is_listed", isListed).Error
+}
+
 // UpdateVerificationStatus 更新验证状态
-func (r *AssetRepository) UpdateVerificationStatus(assetID uint64, status model.VerificationStatus, brand string) error {
+// brand 和 verifier 为空时不修改对应字段
+func (r *AssetRepository) UpdateVerificationStatus(assetID uint64, status model.VerificationStatus, brand model.Address, verifier model.Address) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -286,27 +252,52 @@ func (r *AssetRepository) UpdateVerificationStatus(assetID uint64, status model.
 	if brand != "" {
 		updates["brand"] = brand
 	}
+	if verifier != "" {
+		updates["verifier"] = verifier
+	}
+	found, err := exists(r.db, &model.Asset{}, assetID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("asset %d: %w", assetID, ErrNotFound)
+	}
 	return r.db.Model(&model.Asset{}).
 		Where("id = ?", assetID).
 		Updates(updates).Error
 }
 
-// UpdateImages 更新资产的图片
-func (r *AssetRepository) UpdateImages(assetID uint64, imagesJSON string) error {
+// UpdateFields 更新资产的多个字段（用于链重组后按链上状态恢复）
+func (r *AssetRepository) UpdateFields(assetID uint64, updates map[string]interface{}) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
-	result := r.db.Model(&model.Asset{}).
+	return r.db.Model(&model.Asset{}).
 		Where("id = ?", assetID).
-		Update("images", imagesJSON)
-	
-	if result.Error != nil {
-		return result.Error
+		Updates(updates).Error
+}
+
+// FindIDsWithoutMetadataURI 查询 id 大于 afterID、没有元数据 URI 的资产 ID
+func (r *AssetRepository) FindIDsWithoutMetadataURI(afterID uint64, limit int) ([]uint64, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
 	}
-	
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("asset %d not found", assetID)
+	var ids []uint64
+	err := r.db.Model(&model.Asset{}).
+		Where("id > ? AND (metadata_uri IS NULL OR metadata_uri = '')", afterID).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// DeleteByIDs 物理删除资产（用于回滚孤块中注册的资产）
+func (r *AssetRepository) DeleteByIDs(ids []uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
 	}
-	
-	return nil
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Unscoped().Where("id IN ?", ids).Delete(&model.Asset{}).Error
 }
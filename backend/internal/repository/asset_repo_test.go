@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB 内存 SQLite，建好资产、图片和订单表
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 每个连接是独立的内存数据库
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&model.Asset{}, &model.AssetImage{}, &model.Order{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// seedAssets 写入价格和区块号有重复的资产，用于检查排序键相同时翻页不重复、不遗漏
+func seedAssets(t *testing.T, db *gorm.DB) []model.Asset {
+	t.Helper()
+	var assets []model.Asset
+	for i := 1; i <= 23; i++ {
+		assets = append(assets, model.Asset{
+			ID:           uint64(i),
+			Owner:        "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			Name:         fmt.Sprintf("asset %d", i),
+			SerialNumber: fmt.Sprintf("SN-%d", i),
+			Price:        model.NewWei(new(big.Int).Mul(big.NewInt(int64(i%4)), big.NewInt(1e18))),
+			BlockNum:     uint64(i / 3),
+		})
+	}
+	if err := db.Create(&assets).Error; err != nil {
+		t.Fatal(err)
+	}
+	return assets
+}
+
+func TestFindPagesEverySort(t *testing.T) {
+	db := openTestDB(t)
+	assets := seedAssets(t, db)
+	repo := &AssetRepository{db: db}
+
+	newestFirst := func(a, b model.Asset) bool {
+		if a.BlockNum != b.BlockNum {
+			return a.BlockNum > b.BlockNum
+		}
+		return a.ID > b.ID
+	}
+	tests := []struct {
+		sort string
+		less func(a, b model.Asset) bool
+	}{
+		{SortNewest, newestFirst},
+		{SortOldest, func(a, b model.Asset) bool { return !newestFirst(a, b) }},
+		{SortPriceAsc, func(a, b model.Asset) bool {
+			if c := a.Price.Cmp(b.Price); c != 0 {
+				return c < 0
+			}
+			return newestFirst(a, b)
+		}},
+		{SortPriceDesc, func(a, b model.Asset) bool {
+			if c := a.Price.Cmp(b.Price); c != 0 {
+				return c > 0
+			}
+			return newestFirst(a, b)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			want := append([]model.Asset(nil), assets...)
+			sort.Slice(want, func(i, j int) bool { return tt.less(want[i], want[j]) })
+
+			var got []uint64
+			page := pagination.Params{Limit: 5}
+			for pages := 0; ; pages++ {
+				if pages > len(assets) {
+					t.Fatal("pagination does not terminate")
+				}
+				result, err := repo.Find(AssetFilter{Sort: tt.sort}, page)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if result.Total != int64(len(assets)) {
+					t.Fatalf("total = %d, want %d", result.Total, len(assets))
+				}
+				for _, asset := range result.Items {
+					got = append(got, asset.ID)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+				cursor, err := pagination.Decode(result.NextCursor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				page.Cursor = cursor
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("got %d assets, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i].ID {
+					t.Fatalf("position %d: got asset %d, want %d (got order %v)", i, got[i], want[i].ID, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFindRejectsCursorFromOtherSort(t *testing.T) {
+	db := openTestDB(t)
+	seedAssets(t, db)
+	repo := &AssetRepository{db: db}
+
+	tests := []struct {
+		from, to string
+	}{
+		{SortNewest, SortPriceAsc},
+		{SortPriceAsc, SortPriceDesc},
+		{SortOldest, SortNewest},
+		{SortPriceDesc, ""},
+	}
+	for _, tt := range tests {
+		first, err := repo.Find(AssetFilter{Sort: tt.from}, pagination.Params{Limit: 5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor, err := pagination.Decode(first.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repo.Find(AssetFilter{Sort: tt.to}, pagination.Params{Limit: 5, Cursor: cursor})
+		if !errors.Is(err, pagination.ErrInvalidCursor) {
+			t.Errorf("cursor from %s used with %q: error = %v, want ErrInvalidCursor", tt.from, tt.to, err)
+		}
+	}
+}
+
+func TestUpdateVerificationStatusRequiresAsset(t *testing.T) {
+	db := openTestDB(t)
+	seedAssets(t, db)
+	repo := &AssetRepository{db: db}
+	verifier := model.Address("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+
+	// 重复写入相同的状态（事务回滚后重试）不算记录不存在
+	for i := 0; i < 2; i++ {
+		if err := repo.UpdateVerificationStatus(1, model.Verified, "", verifier); err != nil {
+			t.Fatalf("attempt %d: UpdateVerificationStatus = %v", i+1, err)
+		}
+	}
+	if err := repo.UpdateVerificationStatus(99, model.Verified, "", verifier); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateVerificationStatus(missing) = %v, want ErrNotFound", err)
+	}
+}
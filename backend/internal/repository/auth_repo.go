@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuthRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthRepository() *AuthRepository {
+	return &AuthRepository{
+		db: nil,
+	}
+}
+
+func (r *AuthRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *AuthRepository) WithTx(tx *gorm.DB) *AuthRepository {
+	return &AuthRepository{db: tx}
+}
+
+func (r *AuthRepository) CreateNonce(nonce *model.AuthNonce) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Create(nonce).Error
+}
+
+// ConsumeNonce 将未过期且未使用的随机数标记为已使用，返回是否成功
+// 条件更新保证同一个随机数只能登录一次
+func (r *AuthRepository) ConsumeNonce(nonce string, now time.Time) (bool, error) {
+	if err := r.ensureDB(); err != nil {
+		return false, err
+	}
+	result := r.db.Model(&model.AuthNonce{}).
+		Where("nonce = ? AND used_at IS NULL AND expires_at > ?", nonce, now).
+		Update("used_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *AuthRepository) CreateSession(session *model.AuthSession) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Create(session).Error
+}
+
+// FindActiveSession 查询未过期且未注销的会话
+func (r *AuthRepository) FindActiveSession(tokenHash string, now time.Time) (*model.AuthSession, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var session model.AuthSession
+	err := r.db.Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *AuthRepository) RevokeSession(tokenHash string, now time.Time) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.AuthSession{}).
+		Where("token_hash = ? AND revoked_at IS NULL", tokenHash).
+		Update("revoked_at", now).Error
+}
+
+// DeleteExpired 清理过期的随机数和会话
+func (r *AuthRepository) DeleteExpired(now time.Time) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if err := r.db.Where("expires_at <= ?", now).Delete(&model.AuthNonce{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("expires_at <= ?", now).Delete(&model.AuthSession{}).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"chain-vault-backend/internal/database"
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"errors"
 
 	"gorm.io/gorm"
@@ -28,6 +29,11 @@ func (r *BrandRepository) ensureDB() error {
 	return nil
 }
 
+// WithTx 返回绑定到指定事务的仓储
+func (r *BrandRepository) WithTx(tx *gorm.DB) *BrandRepository {
+	return &BrandRepository{db: tx}
+}
+
 func (r *BrandRepository) Create(brand *model.Brand) error {
 	if err := r.ensureDB(); err != nil {
 		return err
@@ -35,7 +41,7 @@ func (r *BrandRepository) Create(brand *model.Brand) error {
 	return r.db.Create(brand).Error
 }
 
-func (r *BrandRepository) FindByAddress(address string) (*model.Brand, error) {
+func (r *BrandRepository) FindByAddress(address model.Address) (*model.Brand, error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
@@ -47,25 +53,23 @@ func (r *BrandRepository) FindByAddress(address string) (*model.Brand, error) {
 	return &brand, err
 }
 
-func (r *BrandRepository) FindAll(limit, offset int) ([]model.Brand, error) {
+// FindAll 分页查询所有品牌，按区块号倒序
+func (r *BrandRepository) FindAll(page pagination.Params) (*pagination.Page[model.Brand], error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
-	var brands []model.Brand
-	err := r.db.Order("registered_at DESC").Limit(limit).Offset(offset).Find(&brands).Error
-	return brands, err
+	return keysetPage(r.db.Model(&model.Brand{}), page, "block_num", brandCursor)
 }
 
-func (r *BrandRepository) FindAuthorized() ([]model.Brand, error) {
+// FindAuthorized 分页查询已授权的品牌，按区块号倒序
+func (r *BrandRepository) FindAuthorized(page pagination.Params) (*pagination.Page[model.Brand], error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
-	var brands []model.Brand
-	err := r.db.Where("is_authorized = ?", true).Find(&brands).Error
-	return brands, err
+	return keysetPage(r.db.Model(&model.Brand{}).Where("is_authorized = ?", true), page, "block_num", brandCursor)
 }
 
-func (r *BrandRepository) UpdateAuthorization(address string, authorized bool) error {
+func (r *BrandRepository) UpdateAuthorization(address model.Address, authorized bool) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -74,6 +78,16 @@ func (r *BrandRepository) UpdateAuthorization(address string, authorized bool) e
 		Update("is_authorized", authorized).Error
 }
 
+// UpdateName 更新品牌名称（未授权的品牌可以在链上重新注册）
+func (r *BrandRepository) UpdateName(address model.Address, brandName string) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.Brand{}).
+		Where("brand_address = ?", address).
+		Update("brand_name", brandName).Error
+}
+
 func (r *BrandRepository) Count() (int64, error) {
 	if err := r.ensureDB(); err != nil {
 		return 0, err
@@ -83,4 +97,23 @@ func (r *BrandRepository) Count() (int64, error) {
 	return count, err
 }
 
+// UpdateFields 更新品牌的多个字段（用于链重组后按链上状态恢复）
+func (r *BrandRepository) UpdateFields(address model.Address, updates map[string]interface{}) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.Brand{}).
+		Where("brand_address = ?", address).
+		Updates(updates).Error
+}
 
+// DeleteByAddresses 物理删除品牌（用于回滚孤块中注册的品牌）
+func (r *BrandRepository) DeleteByAddresses(addresses []model.Address) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+	return r.db.Unscoped().Where("brand_address IN ?", addresses).Delete(&model.Brand{}).Error
+}
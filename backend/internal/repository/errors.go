@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound 表示要更新的记录不存在
+// 从 START_BLOCK 开始同步时，更早创建的订单和资产不在数据库中，调用方可据此跳过
+var ErrNotFound = errors.New("record not found")
+
+// exists 检查主键为 id 的记录是否存在
+// MySQL 的 RowsAffected 只统计值有变化的行，重复写入相同的值（如回滚后重试）时为 0，
+// 不能用来判断记录是否存在
+func exists(db *gorm.DB, value interface{}, id uint64) (bool, error) {
+	var count int64
+	if err := db.Model(value).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
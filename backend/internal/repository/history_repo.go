@@ -3,6 +3,7 @@ package repository
 import (
 	"chain-vault-backend/internal/database"
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"errors"
 
 	"gorm.io/gorm"
@@ -28,6 +29,11 @@ func (r *HistoryRepository) ensureDB() error {
 	return nil
 }
 
+// WithTx 返回绑定到指定事务的仓储
+func (r *HistoryRepository) WithTx(tx *gorm.DB) *HistoryRepository {
+	return &HistoryRepository{db: tx}
+}
+
 func (r *HistoryRepository) Create(history *model.AssetOwnerHistory) error {
 	if err := r.ensureDB(); err != nil {
 		return err
@@ -35,28 +41,45 @@ func (r *HistoryRepository) Create(history *model.AssetOwnerHistory) error {
 	return r.db.Create(history).Error
 }
 
+// FindByAssetID 按链上顺序返回资产的全部所有权记录
 func (r *HistoryRepository) FindByAssetID(assetID uint64) ([]model.AssetOwnerHistory, error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
 	var histories []model.AssetOwnerHistory
 	err := r.db.Where("asset_id = ?", assetID).
-		Order("timestamp ASC").
+		Order("block_num ASC, log_index ASC").
 		Find(&histories).Error
 	return histories, err
 }
 
-func (r *HistoryRepository) FindByOwner(owner string, limit, offset int) ([]model.AssetOwnerHistory, error) {
+// FindByLog 查询某条转移或注册日志写入的所有权记录，不存在时返回 nil
+func (r *HistoryRepository) FindByLog(txHash string, logIndex uint) (*model.AssetOwnerHistory, error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
-	var histories []model.AssetOwnerHistory
-	err := r.db.Where("owner = ?", owner).
-		Order("timestamp DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&histories).Error
-	return histories, err
+	var history model.AssetOwnerHistory
+	err := r.db.Where("tx_hash = ? AND log_index = ?", txHash, logIndex).First(&history).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &history, err
 }
 
+// FindByOwner 分页查询地址转入或转出的所有权记录，按区块号倒序
+func (r *HistoryRepository) FindByOwner(owner model.Address, page pagination.Params) (*pagination.Page[model.AssetOwnerHistory], error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	query := r.db.Model(&model.AssetOwnerHistory{}).
+		Where("owner = ? OR from_address = ?", owner, owner)
+	return keysetPage(query, page, "block_num", historyCursor)
+}
 
+// DeleteAfterBlock 物理删除 blockNum 之后的记录（用于链重组回滚）
+func (r *HistoryRepository) DeleteAfterBlock(blockNum uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Unscoped().Where("block_num > ?", blockNum).Delete(&model.AssetOwnerHistory{}).Error
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImageRepository struct {
+	db *gorm.DB
+}
+
+func NewImageRepository() *ImageRepository {
+	return &ImageRepository{
+		db: nil,
+	}
+}
+
+func (r *ImageRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *ImageRepository) WithTx(tx *gorm.DB) *ImageRepository {
+	return &ImageRepository{db: tx}
+}
+
+// FindByAssetID 按顺序返回资产的所有图片
+func (r *ImageRepository) FindByAssetID(assetID uint64) ([]model.AssetImage, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var images []model.AssetImage
+	err := r.db.Where("asset_id = ?", assetID).Order("position").Find(&images).Error
+	return images, err
+}
+
+// FindByPosition 查找资产的第 position 张图片
+func (r *ImageRepository) FindByPosition(assetID uint64, position int) (*model.AssetImage, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var image model.AssetImage
+	err := r.db.Where("asset_id = ? AND position = ?", assetID, position).First(&image).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &image, err
+}
+
+// ReplaceForAsset 用 images 替换资产的全部图片，position 按切片顺序重新编号
+func (r *ImageRepository) ReplaceForAsset(assetID uint64, images []model.AssetImage) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("asset_id = ?", assetID).Delete(&model.AssetImage{}).Error; err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return nil
+		}
+		records := make([]model.AssetImage, len(images))
+		for i, image := range images {
+			image.ID = 0
+			image.AssetID = assetID
+			image.Position = i
+			records[i] = image
+		}
+		return tx.Create(&records).Error
+	})
+}
+
+// DeleteByAssetIDs 删除资产的图片记录，blob 内容可能被其他图片引用，不在这里删除
+func (r *ImageRepository) DeleteByAssetIDs(assetIDs []uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	return r.db.Where("asset_id IN ?", assetIDs).Delete(&model.AssetImage{}).Error
+}
+
+// UpdateImage 更新图片记录的内容字段（重新处理旧图片时使用）
+func (r *ImageRepository) UpdateImage(image *model.AssetImage) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.AssetImage{}).
+		Where("id = ?", image.ID).
+		Updates(map[string]interface{}{
+			"hash":      image.Hash,
+			"mime_type": image.MimeType,
+			"width":     image.Width,
+			"height":    image.Height,
+			"size":      image.Size,
+			"storage":   image.Storage,
+			"location":  image.Location,
+			"phash":     image.PHash,
+		}).Error
+}
+
+// FindUnprocessed 查询 id 大于 afterID、尚未经过图片处理（没有感知哈希）的图片
+func (r *ImageRepository) FindUnprocessed(afterID uint64, mimeTypes []string, limit int) ([]model.AssetImage, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var images []model.AssetImage
+	err := r.db.Where("id > ? AND phash = '' AND mime_type IN ?", afterID, mimeTypes).
+		Order("id").
+		Limit(limit).
+		Find(&images).Error
+	return images, err
+}
+
+// SaveVariant 保存缩放版本，同一原图的同一规格已存在时忽略
+func (r *ImageRepository) SaveVariant(variant *model.ImageVariant) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(variant).Error
+}
+
+// FindVariant 查找原图的指定缩放版本
+func (r *ImageRepository) FindVariant(sourceHash, variant string) (*model.ImageVariant, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var result model.ImageVariant
+	err := r.db.Where("source_hash = ? AND variant = ?", sourceHash, variant).First(&result).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &result, err
+}
+
+// LegacyImages 旧版本存储在 assets.images 中的 base64 图片
+type LegacyImages struct {
+	ID     uint64
+	Images string
+}
+
+// FindLegacyImages 查询 assets.images 仍有数据的资产
+func (r *ImageRepository) FindLegacyImages(limit int) ([]LegacyImages, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var rows []LegacyImages
+	err := r.db.Table("assets").
+		Select("id, images").
+		Where("images IS NOT NULL AND images <> ''").
+		Order("id").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ClearLegacyImages 清空资产的 assets.images
+func (r *ImageRepository) ClearLegacyImages(assetID uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Table("assets").Where("id = ?", assetID).Update("images", "").Error
+}
+
+// IndexPHash 写入感知哈希的分段索引，已存在时忽略
+func (r *ImageRepository) IndexPHash(phash string, bands []int) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	rows := make([]model.PHashBand, len(bands))
+	for i, value := range bands {
+		rows[i] = model.PHashBand{PHash: phash, Band: i, Value: value}
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// FindUnindexedPHashes 查询尚未建立分段索引的感知哈希
+func (r *ImageRepository) FindUnindexedPHashes(limit int) ([]string, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var phashes []string
+	err := r.db.Model(&model.AssetImage{}).
+		Distinct("phash").
+		Where("phash <> '' AND NOT EXISTS (SELECT 1 FROM phash_bands WHERE phash_bands.phash = asset_images.phash)").
+		Limit(limit).
+		Pluck("phash", &phashes).Error
+	return phashes, err
+}
+
+// ImageWithAsset 图片及其所属资产的基本信息
+type ImageWithAsset struct {
+	model.AssetImage
+	Name         string
+	SerialNumber string
+	Owner        model.Address
+	Brand        model.Address
+}
+
+// withAsset 关联未删除的资产，查询 ImageWithAsset
+func (r *ImageRepository) withAsset() *gorm.DB {
+	return r.db.Table("asset_images").
+		Select("asset_images.*, assets.name, assets.serial_number, assets.owner, assets.brand").
+		Joins("JOIN assets ON assets.id = asset_images.asset_id AND assets.deleted_at IS NULL")
+}
+
+// FindWithAssetByAssetID 查询资产的已处理图片（有感知哈希）
+func (r *ImageRepository) FindWithAssetByAssetID(assetID uint64) ([]ImageWithAsset, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var images []ImageWithAsset
+	err := r.withAsset().
+		Where("asset_images.asset_id = ? AND asset_images.phash <> ''", assetID).
+		Order("asset_images.position").
+		Scan(&images).Error
+	return images, err
+}
+
+// FindWithAssetByBrand 查询品牌方所有资产的已处理图片
+func (r *ImageRepository) FindWithAssetByBrand(brand model.Address) ([]ImageWithAsset, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var images []ImageWithAsset
+	err := r.withAsset().
+		Where("assets.brand = ? AND asset_images.phash <> ''", brand).
+		Order("asset_images.asset_id, asset_images.position").
+		Scan(&images).Error
+	return images, err
+}
+
+// FindByPHashBands 查询与任一感知哈希至少有一段相同的图片，是相近图片的候选，调用方需再计算汉明距离
+// bands 中每一项是一个哈希的全部分段
+func (r *ImageRepository) FindByPHashBands(bands [][]int) ([]ImageWithAsset, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	if len(bands) == 0 {
+		return nil, nil
+	}
+
+	// 相同的 (band, value) 只查询一次
+	seen := make(map[[2]int]bool)
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	for _, hashBands := range bands {
+		for band, value := range hashBands {
+			key := [2]int{band, value}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			conditions = append(conditions, "(band = ? AND value = ?)")
+			args = append(args, band, value)
+		}
+	}
+	candidates := r.db.Model(&model.PHashBand{}).
+		Select("phash").
+		Where(strings.Join(conditions, " OR "), args...)
+
+	var images []ImageWithAsset
+	err := r.withAsset().
+		Where("asset_images.phash IN (?)", candidates).
+		Order("asset_images.asset_id, asset_images.position").
+		Scan(&images).Error
+	return images, err
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"chain-vault-backend/internal/database"
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
 )
@@ -28,6 +30,11 @@ func (r *OrderRepository) ensureDB() error {
 	return nil
 }
 
+// WithTx 返回绑定到指定事务的仓储
+func (r *OrderRepository) WithTx(tx *gorm.DB) *OrderRepository {
+	return &OrderRepository{db: tx}
+}
+
 func (r *OrderRepository) Create(order *model.Order) error {
 	if err := r.ensureDB(); err != nil {
 		return err
@@ -47,54 +54,32 @@ func (r *OrderRepository) FindByID(id uint64) (*model.Order, error) {
 	return &order, err
 }
 
-func (r *OrderRepository) FindByAssetID(assetID uint64) ([]model.Order, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var orders []model.Order
-	err := r.db.Where("asset_id = ?", assetID).
-		Order("order_created_at DESC").
-		Find(&orders).Error
-	return orders, err
+// FindByAssetID 分页查询资产的订单
+func (r *OrderRepository) FindByAssetID(assetID uint64, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return r.findPage(page, "asset_id = ?", assetID)
 }
 
-func (r *OrderRepository) FindByBuyer(buyer string, limit, offset int) ([]model.Order, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var orders []model.Order
-	err := r.db.Where("buyer = ?", buyer).
-		Order("order_created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&orders).Error
-	return orders, err
+// FindByBuyer 分页查询买家的订单
+func (r *OrderRepository) FindByBuyer(buyer model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return r.findPage(page, "buyer = ?", buyer)
 }
 
-func (r *OrderRepository) FindBySeller(seller string, limit, offset int) ([]model.Order, error) {
-	if err := r.ensureDB(); err != nil {
-		return nil, err
-	}
-	var orders []model.Order
-	err := r.db.Where("seller = ?", seller).
-		Order("order_created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&orders).Error
-	return orders, err
+// FindBySeller 分页查询卖家的订单
+func (r *OrderRepository) FindBySeller(seller model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return r.findPage(page, "seller = ?", seller)
 }
 
-func (r *OrderRepository) FindByUser(user string, limit, offset int) ([]model.Order, error) {
+// FindByUser 分页查询用户作为买家或卖家的订单
+func (r *OrderRepository) FindByUser(user model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return r.findPage(page, "buyer = ? OR seller = ?", user, user)
+}
+
+// findPage 分页查询符合条件的订单，按区块号倒序
+func (r *OrderRepository) findPage(page pagination.Params, query string, args ...interface{}) (*pagination.Page[model.Order], error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
-	var orders []model.Order
-	err := r.db.Where("buyer = ? OR seller = ?", user, user).
-		Order("order_created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&orders).Error
-	return orders, err
+	return keysetPage(r.db.Model(&model.Order{}).Where(query, args...), page, "block_num", orderCursor)
 }
 
 func (r *OrderRepository) UpdateStatus(orderID uint64, status model.OrderStatus) error {
@@ -106,6 +91,23 @@ func (r *OrderRepository) UpdateStatus(orderID uint64, status model.OrderStatus)
 		Update("status", status).Error
 }
 
+// UpdateFields 更新订单的多个字段（用于同步链上状态及时间戳）
+func (r *OrderRepository) UpdateFields(orderID uint64, updates map[string]interface{}) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	found, err := exists(r.db, &model.Order{}, orderID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("order %d: %w", orderID, ErrNotFound)
+	}
+	return r.db.Model(&model.Order{}).
+		Where("id = ?", orderID).
+		Updates(updates).Error
+}
+
 func (r *OrderRepository) Count() (int64, error) {
 	if err := r.ensureDB(); err != nil {
 		return 0, err
@@ -124,4 +126,13 @@ func (r *OrderRepository) CountByStatus(status model.OrderStatus) (int64, error)
 	return count, err
 }
 
-
+// DeleteByIDs 物理删除订单（用于回滚孤块中创建的订单）
+func (r *OrderRepository) DeleteByIDs(ids []uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Unscoped().Where("id IN ?", ids).Delete(&model.Order{}).Error
+}
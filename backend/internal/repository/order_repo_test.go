@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chain-vault-backend/internal/model"
+)
+
+func TestUpdateFieldsRequiresOrder(t *testing.T) {
+	db := openTestDB(t)
+	repo := &OrderRepository{db: db}
+	order := &model.Order{
+		ID:      7,
+		AssetID: 1,
+		Seller:  "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		Buyer:   "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		Status:  model.OrderCreated,
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatal(err)
+	}
+
+	paidAt := time.Unix(1700000000, 0)
+	updates := map[string]interface{}{"status": model.OrderPaid, "paid_at": paidAt}
+	// 重复写入相同的值（事务回滚后重试）不算记录不存在
+	for i := 0; i < 2; i++ {
+		if err := repo.UpdateFields(order.ID, updates); err != nil {
+			t.Fatalf("attempt %d: UpdateFields = %v", i+1, err)
+		}
+	}
+	got, err := repo.FindByID(order.ID)
+	if err != nil || got == nil || got.Status != model.OrderPaid {
+		t.Fatalf("FindByID = %+v, %v, want a paid order", got, err)
+	}
+
+	if err := repo.UpdateFields(99, updates); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateFields(missing) = %v, want ErrNotFound", err)
+	}
+}
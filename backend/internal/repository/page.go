@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// keysetPage 统计 query 匹配的记录总数，并按 (blockColumn, id) 倒序取一页
+// blockColumn 为空时只按 id 排序；scopes 只作用于取数据的查询（如预加载）
+func keysetPage[T any](query *gorm.DB, page pagination.Params, blockColumn string, key func(T) pagination.Cursor, scopes ...func(*gorm.DB) *gorm.DB) (*pagination.Page[T], error) {
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []T
+	if err := page.Keyset(query.Scopes(scopes...), blockColumn, "id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(items, total, page, key), nil
+}
+
+// seekPage 统计 query 匹配的记录总数，并按 keys 排序、从游标之后取一页
+func seekPage[T any](query *gorm.DB, page pagination.Params, keys []pagination.Key, key func(T) pagination.Cursor, scopes ...func(*gorm.DB) *gorm.DB) (*pagination.Page[T], error) {
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []T
+	if err := page.Seek(query.Scopes(scopes...), keys).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(items, total, page, key), nil
+}
+
+// preloadImages 按顺序预加载资产的图片记录
+func preloadImages(db *gorm.DB) *gorm.DB {
+	return db.Preload("ImageRecords", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
+
+// assetSortKeys 资产列表和搜索按 sort 翻页的排序列，价格相同时新的在前
+// 游标的值取自 page.Cursor，没有游标时不使用
+func assetSortKeys(sort string, page pagination.Params) []pagination.Key {
+	var cursor pagination.Cursor
+	if page.Cursor != nil {
+		cursor = *page.Cursor
+	}
+	newest := []pagination.Key{
+		{Column: "assets.block_num", Desc: true, Value: cursor.BlockNum},
+		{Column: "assets.id", Desc: true, Value: cursor.ID},
+	}
+	switch sort {
+	case SortOldest:
+		return []pagination.Key{
+			{Column: "assets.block_num", Value: cursor.BlockNum},
+			{Column: "assets.id", Value: cursor.ID},
+		}
+	case SortPriceAsc:
+		return append([]pagination.Key{{Column: "assets.price", Value: cursor.Price}}, newest...)
+	case SortPriceDesc:
+		return append([]pagination.Key{{Column: "assets.price", Desc: true, Value: cursor.Price}}, newest...)
+	}
+	return newest
+}
+
+// assetCursor 生成 sort 排序下资产的游标
+func assetCursor(sort string) func(model.Asset) pagination.Cursor {
+	return func(asset model.Asset) pagination.Cursor {
+		cursor := pagination.Cursor{Sort: sort, BlockNum: asset.BlockNum, ID: asset.ID}
+		if sort == SortPriceAsc || sort == SortPriceDesc {
+			cursor.Price = asset.Price.Padded()
+		}
+		return cursor
+	}
+}
+
+func brandCursor(brand model.Brand) pagination.Cursor {
+	return pagination.Cursor{BlockNum: brand.BlockNum, ID: brand.ID}
+}
+
+func orderCursor(order model.Order) pagination.Cursor {
+	return pagination.Cursor{BlockNum: order.BlockNum, ID: order.ID}
+}
+
+func historyCursor(history model.AssetOwnerHistory) pagination.Cursor {
+	return pagination.Cursor{BlockNum: history.BlockNum, ID: history.ID}
+}
+
+func reviewCursor(review model.UserReview) pagination.Cursor {
+	return pagination.Cursor{ID: review.ID}
+}
+
+func deliveryCursor(delivery model.WebhookDelivery) pagination.Cursor {
+	return pagination.Cursor{ID: delivery.ID}
+}
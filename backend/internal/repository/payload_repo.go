@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PayloadRepository struct {
+	db *gorm.DB
+}
+
+func NewPayloadRepository() *PayloadRepository {
+	return &PayloadRepository{
+		db: nil,
+	}
+}
+
+func (r *PayloadRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *PayloadRepository) WithTx(tx *gorm.DB) *PayloadRepository {
+	return &PayloadRepository{db: tx}
+}
+
+func (r *PayloadRepository) Create(payload *model.PendingPayload) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Create(payload).Error
+}
+
+func (r *PayloadRepository) FindByTxHash(txHash string) ([]model.PendingPayload, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var payloads []model.PendingPayload
+	err := r.db.Where("tx_hash = ?", txHash).Order("id ASC").Find(&payloads).Error
+	return payloads, err
+}
+
+// FindReady 查询对应交易已被索引、但尚未处理的延迟数据
+func (r *PayloadRepository) FindReady() ([]model.PendingPayload, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var payloads []model.PendingPayload
+	err := r.db.Where("status = ? AND tx_hash IN (?)",
+		model.PayloadPending,
+		r.db.Model(&model.ChainEvent{}).Select("tx_hash"),
+	).Order("id ASC").Find(&payloads).Error
+	return payloads, err
+}
+
+// UpdateStatus 更新处理结果
+func (r *PayloadRepository) UpdateStatus(id uint64, status, errMsg string, appliedAt *time.Time) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.PendingPayload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"error":      errMsg,
+			"applied_at": appliedAt,
+		}).Error
+}
+
+// ResetByTxHashes 将指定交易的延迟数据恢复为待处理（用于链重组回滚）
+func (r *PayloadRepository) ResetByTxHashes(txHashes []string) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(txHashes) == 0 {
+		return nil
+	}
+	return r.db.Model(&model.PendingPayload{}).
+		Where("tx_hash IN ?", txHashes).
+		Updates(map[string]interface{}{
+			"status":     model.PayloadPending,
+			"error":      "",
+			"applied_at": nil,
+		}).Error
+}
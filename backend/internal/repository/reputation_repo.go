@@ -3,9 +3,11 @@ package repository
 import (
 	"chain-vault-backend/internal/database"
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"errors"
 	
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReputationRepository struct {
@@ -28,8 +30,13 @@ func (r *ReputationRepository) ensureDB() error {
 	return nil
 }
 
+// WithTx 返回绑定到指定事务的仓储
+func (r *ReputationRepository) WithTx(tx *gorm.DB) *ReputationRepository {
+	return &ReputationRepository{db: tx}
+}
+
 // GetOrCreateReputation 获取或创建用户信誉记录
-func (r *ReputationRepository) GetOrCreateReputation(userAddress string) (*model.UserReputation, error) {
+func (r *ReputationRepository) GetOrCreateReputation(userAddress model.Address) (*model.UserReputation, error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
@@ -49,6 +56,8 @@ func (r *ReputationRepository) GetOrCreateReputation(userAddress string) (*model
 			OnTimeDeliveryRate: 100.00,
 			ResponseTimeHours:  24.00,
 			DisputeRate:        0.00,
+			Badges:             "[]",
+			Achievements:       "[]",
 		}
 		if err := r.db.Create(&reputation).Error; err != nil {
 			return nil, err
@@ -69,7 +78,7 @@ func (r *ReputationRepository) UpdateReputation(reputation *model.UserReputation
 }
 
 // AddExperience 添加经验值
-func (r *ReputationRepository) AddExperience(userAddress string, exp int) error {
+func (r *ReputationRepository) AddExperience(userAddress model.Address, exp int) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -90,7 +99,12 @@ func (r *ReputationRepository) AddExperience(userAddress string, exp int) error
 }
 
 // IncrementOrderCount 增加订单计数
-func (r *ReputationRepository) IncrementOrderCount(userAddress string, role string, completed bool) error {
+func (r *ReputationRepository) IncrementOrderCount(userAddress model.Address, role string, completed bool) error {
+	return r.AdjustOrderCount(userAddress, role, completed, 1)
+}
+
+// AdjustOrderCount 按 delta 调整订单计数（撤销时 delta 为 -1）
+func (r *ReputationRepository) AdjustOrderCount(userAddress model.Address, role string, completed bool, delta int) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -100,20 +114,20 @@ func (r *ReputationRepository) IncrementOrderCount(userAddress string, role stri
 		return err
 	}
 	
-	reputation.TotalOrders++
+	reputation.TotalOrders += delta
 	if completed {
-		reputation.CompletedOrders++
+		reputation.CompletedOrders += delta
 	}
 	
 	if role == "seller" {
-		reputation.SellerOrders++
+		reputation.SellerOrders += delta
 		if completed {
-			reputation.SellerCompleted++
+			reputation.SellerCompleted += delta
 		}
 	} else if role == "buyer" {
-		reputation.BuyerOrders++
+		reputation.BuyerOrders += delta
 		if completed {
-			reputation.BuyerCompleted++
+			reputation.BuyerCompleted += delta
 		}
 	}
 	
@@ -128,23 +142,38 @@ func (r *ReputationRepository) CreateReview(review *model.UserReview) error {
 	return r.db.Create(review).Error
 }
 
-// GetReviewsByUser 获取用户的所有评价
-func (r *ReputationRepository) GetReviewsByUser(userAddress string, role string) ([]model.UserReview, error) {
+// FindReview 查询评价人对订单的评价，不存在时返回 nil
+func (r *ReputationRepository) FindReview(orderID uint64, reviewerAddress model.Address) (*model.UserReview, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var review model.UserReview
+	err := r.db.Where("order_id = ? AND reviewer_address = ?", orderID, reviewerAddress).First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetReviewsByUser 分页获取用户收到的评价，按 ID 倒序（即最新的在前）
+// 评价是链下记录，没有区块号
+func (r *ReputationRepository) GetReviewsByUser(userAddress model.Address, role string, page pagination.Params) (*pagination.Page[model.UserReview], error) {
 	if err := r.ensureDB(); err != nil {
 		return nil, err
 	}
 	
-	var reviews []model.UserReview
-	query := r.db.Where("reviewee_address = ?", userAddress)
+	query := r.db.Model(&model.UserReview{}).Where("reviewee_address = ?", userAddress)
 	if role != "" {
 		query = query.Where("role = ?", role)
 	}
-	err := query.Order("created_at DESC").Find(&reviews).Error
-	return reviews, err
+	return keysetPage(query, page, "", reviewCursor)
 }
 
 // UpdateRating 更新用户评分
-func (r *ReputationRepository) UpdateRating(userAddress string, role string, rating float64) error {
+func (r *ReputationRepository) UpdateRating(userAddress model.Address, role string, rating float64) error {
 	if err := r.ensureDB(); err != nil {
 		return err
 	}
@@ -167,3 +196,30 @@ func (r *ReputationRepository) UpdateRating(userAddress string, role string, rat
 	
 	return r.UpdateReputation(reputation)
 }
+
+// RecordEvent 记录已计入信誉的订单事件，同一订单的同一类事件已存在时返回 false
+func (r *ReputationRepository) RecordEvent(event *model.ReputationEvent) (bool, error) {
+	if err := r.ensureDB(); err != nil {
+		return false, err
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	return result.RowsAffected == 1, result.Error
+}
+
+// FindEventsAfter 查询指定区块之后计入的订单事件，按区块倒序
+func (r *ReputationRepository) FindEventsAfter(blockNum uint64) ([]model.ReputationEvent, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var events []model.ReputationEvent
+	err := r.db.Where("block_num > ?", blockNum).Order("block_num DESC, id DESC").Find(&events).Error
+	return events, err
+}
+
+// DeleteEvent 删除订单事件记录（撤销后调用）
+func (r *ReputationRepository) DeleteEvent(id uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Delete(&model.ReputationEvent{}, id).Error
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 搜索结果和资产列表的排序方式
+const (
+	SortRelevance = "relevance" // 按相关度，没有关键词时等同于 newest
+	SortNewest    = "newest"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortOldest    = "oldest" // 只用于资产列表
+)
+
+// 分面名称，计算某个分面时不应用该分面自身的筛选条件
+const (
+	FacetBrand    = "brand"
+	FacetCategory = "category"
+	FacetStatus   = "status"
+	FacetListed   = "listed"
+)
+
+// facetLimit 品牌和分类分面最多返回的取值数
+const facetLimit = 20
+
+// searchColumns 全文索引的列，顺序与迁移中的索引定义一致
+var searchColumns = []string{"name", "serial_number", "brand_name", "category", "model", "color", "size"}
+
+// AssetSearch 资产搜索条件
+type AssetSearch struct {
+	Keyword  string
+	Brand    model.Address // 品牌地址
+	Category string
+	Status   *model.VerificationStatus
+	Listed   *bool
+	MinPrice *model.Wei
+	MaxPrice *model.Wei
+	Sort     string
+	Page     pagination.Params
+}
+
+// FacetCount 分面中一个取值及其结果数
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// PendingMetadata 尚未读取元数据的资产
+type PendingMetadata struct {
+	AssetID     uint64
+	MetadataURI string
+}
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{
+		db: nil,
+	}
+}
+
+func (r *SearchRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *SearchRepository) WithTx(tx *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: tx}
+}
+
+// UpsertDoc 写入资产的名称、序列号和品牌名称，已读取的元数据字段保持不变
+func (r *SearchRepository) UpsertDoc(doc *model.AssetSearchDoc) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "asset_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "serial_number", "brand_name", "updated_at"}),
+	}).Create(doc).Error
+}
+
+// UpdateBrandName 更新品牌下所有资产的品牌名称
+func (r *SearchRepository) UpdateBrandName(brand model.Address, brandName string) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.AssetSearchDoc{}).
+		Where("asset_id IN (?)", r.db.Model(&model.Asset{}).Select("id").Where("brand = ?", brand)).
+		Updates(map[string]interface{}{
+			"brand_name": brandName,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// DeleteDocs 删除资产的搜索文档
+func (r *SearchRepository) DeleteDocs(assetIDs []uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	return r.db.Where("asset_id IN ?", assetIDs).Delete(&model.AssetSearchDoc{}).Error
+}
+
+// FindPendingMetadata 查询有元数据 URI 但尚未读取、失败次数小于 maxAttempts 的资产
+func (r *SearchRepository) FindPendingMetadata(maxAttempts, limit int) ([]PendingMetadata, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var rows []PendingMetadata
+	err := r.db.Table("asset_search_docs").
+		Select("asset_search_docs.asset_id, assets.metadata_uri").
+		Joins("JOIN assets ON assets.id = asset_search_docs.asset_id AND assets.deleted_at IS NULL").
+		Where("asset_search_docs.metadata_indexed = ? AND asset_search_docs.metadata_attempts < ?", false, maxAttempts).
+		Where("assets.metadata_uri IS NOT NULL AND assets.metadata_uri <> ''").
+		Order("asset_search_docs.metadata_attempts, asset_search_docs.asset_id").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SaveMetadata 写入元数据中的可搜索字段
+func (r *SearchRepository) SaveMetadata(assetID uint64, category, productModel, color, size string) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.AssetSearchDoc{}).
+		Where("asset_id = ?", assetID).
+		Updates(map[string]interface{}{
+			"category":         category,
+			"model":            productModel,
+			"color":            color,
+			"size":             size,
+			"metadata_indexed": true,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+// RecordMetadataFailure 记录一次读取元数据失败
+func (r *SearchRepository) RecordMetadataFailure(assetID uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(&model.AssetSearchDoc{}).
+		Where("asset_id = ?", assetID).
+		Update("metadata_attempts", gorm.Expr("metadata_attempts + 1")).Error
+}
+
+// Search 按条件搜索资产，返回当前页的资产和结果总数
+// 按时间或价格排序时使用 (排序列, 区块号, ID) 游标翻页，按相关度排序时使用偏移量游标；
+// 游标与排序方式绑定，换了排序时返回 pagination.ErrInvalidCursor
+func (r *SearchRepository) Search(q *AssetSearch) (*pagination.Page[model.Asset], error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	if err := q.Page.CheckSort(q.Sort); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := r.filtered(q, "").Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query := r.filtered(q, "").
+		Select("assets.*").
+		Preload("ImageRecords", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
+	var assets []model.Asset
+	if q.Sort == SortRelevance && r.hasFullText(q.Keyword) {
+		err := q.Page.Paged(query.Order(r.relevanceOrder(q.Keyword)).Order("assets.block_num DESC, assets.id DESC")).Find(&assets).Error
+		return pagination.NewOffsetPage(assets, total, q.Page, q.Sort), err
+	}
+
+	// 没有全文关键词时相关度排序等同于 newest，游标仍记为 relevance
+	err := q.Page.Seek(query, assetSortKeys(q.Sort, q.Page)).Find(&assets).Error
+	return pagination.NewPage(assets, total, q.Page, assetCursor(q.Sort)), err
+}
+
+
+// Facets 计算各分面的取值及结果数，每个分面忽略自身的筛选条件
+func (r *SearchRepository) Facets(q *AssetSearch) (map[string][]FacetCount, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+
+	facets := make(map[string][]FacetCount)
+	queries := map[string]*gorm.DB{
+		FacetBrand: r.filtered(q, FacetBrand).
+			Select("assets.brand AS value, MAX(asset_search_docs.brand_name) AS label, COUNT(*) AS count").
+			Where("assets.brand IS NOT NULL AND assets.brand <> '' AND assets.brand <> ?", zeroAddress).
+			Group("assets.brand").
+			Order("COUNT(*) DESC").
+			Limit(facetLimit),
+		FacetCategory: r.filtered(q, FacetCategory).
+			Select("asset_search_docs.category AS value, COUNT(*) AS count").
+			Where("asset_search_docs.category <> ''").
+			Group("asset_search_docs.category").
+			Order("COUNT(*) DESC").
+			Limit(facetLimit),
+		FacetStatus: r.filtered(q, FacetStatus).
+			Select("CAST(assets.status AS CHAR) AS value, COUNT(*) AS count").
+			Group("assets.status").
+			Order("assets.status"),
+		FacetListed: r.filtered(q, FacetListed).
+			Select("CAST(assets.is_listed AS CHAR) AS value, COUNT(*) AS count").
+			Group("assets.is_listed").
+			Order("assets.is_listed DESC"),
+	}
+	for name, query := range queries {
+		rows := make([]FacetCount, 0)
+		if err := query.Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		facets[name] = rows
+	}
+	return facets, nil
+}
+
+// zeroAddress 用户自行注册的资产品牌为零地址
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// filtered 应用关键词和筛选条件，skip 为不应用的分面筛选
+func (r *SearchRepository) filtered(q *AssetSearch, skip string) *gorm.DB {
+	db := r.db.Model(&model.Asset{}).
+		Joins("LEFT JOIN asset_search_docs ON asset_search_docs.asset_id = assets.id")
+
+	fullText, short := r.splitTerms(q.Keyword)
+	if len(fullText) > 0 {
+		if r.isSQLite() {
+			db = db.Joins("JOIN asset_search_fts ON asset_search_fts.rowid = assets.id").
+				Where("asset_search_fts MATCH ?", ftsQuery(fullText))
+		} else {
+			db = db.Where(matchAgainst(), booleanQuery(fullText))
+		}
+	}
+	// 全文索引无法匹配的短词逐列模糊匹配
+	for _, term := range short {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions := make([]string, len(searchColumns))
+		args := make([]interface{}, len(searchColumns))
+		for i, column := range searchColumns {
+			conditions[i] = "asset_search_docs." + column + " LIKE ? ESCAPE '!'"
+			args[i] = pattern
+		}
+		db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	if q.Brand != "" && skip != FacetBrand {
+		db = db.Where("assets.brand = ?", q.Brand)
+	}
+	if q.Category != "" && skip != FacetCategory {
+		db = db.Where("asset_search_docs.category = ?", q.Category)
+	}
+	if q.Status != nil && skip != FacetStatus {
+		db = db.Where("assets.status = ?", *q.Status)
+	}
+	if q.Listed != nil && skip != FacetListed {
+		db = db.Where("assets.is_listed = ?", *q.Listed)
+	}
+	return wherePriceRange(db, "assets.price", q.MinPrice, q.MaxPrice)
+}
+
+// relevanceOrder 按全文匹配的相关度排序
+// SQLite 使用 bm25（越小越相关），名称和序列号的权重最高；MySQL 使用 MATCH 的得分（越大越相关）
+func (r *SearchRepository) relevanceOrder(keyword string) clause.OrderBy {
+	fullText, _ := r.splitTerms(keyword)
+	if r.isSQLite() {
+		return clause.OrderBy{Expression: clause.Expr{
+			SQL: "bm25(asset_search_fts, 10.0, 10.0, 5.0, 2.0, 4.0, 1.0, 1.0)",
+		}}
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                matchAgainst() + " DESC",
+		Vars:               []interface{}{booleanQuery(fullText)},
+		WithoutParentheses: true,
+	}}
+}
+
+// hasFullText 关键词中是否有可以使用全文索引的词
+func (r *SearchRepository) hasFullText(keyword string) bool {
+	fullText, _ := r.splitTerms(keyword)
+	return len(fullText) > 0
+}
+
+// splitTerms 按空白拆分关键词，返回可用全文索引匹配的词和过短的词
+// SQLite trigram 分词至少需要 3 个字符，MySQL ngram 分词（ngram_token_size 默认 2）至少需要 2 个字符
+func (r *SearchRepository) splitTerms(keyword string) (fullText, short []string) {
+	minLength := 2
+	if r.isSQLite() {
+		minLength = 3
+	}
+	for _, term := range strings.Fields(keyword) {
+		term = strings.ReplaceAll(term, `"`, "")
+		if term == "" {
+			continue
+		}
+		if utf8.RuneCountInString(term) >= minLength {
+			fullText = append(fullText, term)
+		} else {
+			short = append(short, term)
+		}
+	}
+	return fullText, short
+}
+
+func (r *SearchRepository) isSQLite() bool {
+	return r.db.Dialector.Name() == "sqlite"
+}
+
+// matchAgainst MySQL 全文匹配表达式，列与 FULLTEXT 索引一致
+func matchAgainst() string {
+	columns := make([]string, len(searchColumns))
+	for i, column := range searchColumns {
+		columns[i] = "asset_search_docs." + column
+	}
+	return "MATCH(" + strings.Join(columns, ", ") + ") AGAINST (? IN BOOLEAN MODE)"
+}
+
+// booleanQuery MySQL 布尔模式查询：每个词都必须出现
+func booleanQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `+"` + term + `"`
+	}
+	return strings.Join(parts, " ")
+}
+
+// ftsQuery FTS5 查询：每个词作为短语，空格分隔表示都必须出现
+func ftsQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `"` + term + `"`
+	}
+	return strings.Join(parts, " ")
+}
+
+// escapeLike 转义 LIKE 模式中的特殊字符，转义符为 !
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
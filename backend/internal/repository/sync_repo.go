@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SyncRepository struct {
+	db *gorm.DB
+}
+
+func NewSyncRepository() *SyncRepository {
+	return &SyncRepository{
+		db: nil,
+	}
+}
+
+func (r *SyncRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *SyncRepository) WithTx(tx *gorm.DB) *SyncRepository {
+	return &SyncRepository{db: tx}
+}
+
+// FindByContract 查询合约的同步进度，不存在时返回 nil
+func (r *SyncRepository) FindByContract(contractAddress string) (*model.SyncState, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var state model.SyncState
+	err := r.db.Where("contract_address = ?", contractAddress).First(&state).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &state, err
+}
+
+// SaveLastBlock 写入合约的最后处理区块（不存在则创建）
+func (r *SyncRepository) SaveLastBlock(contractAddress string, lastBlock uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	state := &model.SyncState{
+		ContractAddress: contractAddress,
+		LastBlock:       lastBlock,
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_block", "updated_at"}),
+	}).Create(state).Error
+}
+
+// CreateEvent 记录已处理的事件日志
+func (r *SyncRepository) CreateEvent(event *model.ChainEvent) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Create(event).Error
+}
+
+// EventExists 检查日志是否已处理过
+func (r *SyncRepository) EventExists(txHash string, logIndex uint) (bool, error) {
+	if err := r.ensureDB(); err != nil {
+		return false, err
+	}
+	var count int64
+	err := r.db.Model(&model.ChainEvent{}).
+		Where("tx_hash = ? AND log_index = ?", txHash, logIndex).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindEventsByTxHash 查询同一交易产生的事件，按日志顺序排列
+func (r *SyncRepository) FindEventsByTxHash(txHash string) ([]model.ChainEvent, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var events []model.ChainEvent
+	err := r.db.Where("tx_hash = ?", txHash).Order("log_index ASC").Find(&events).Error
+	return events, err
+}
+
+// FindEventsAfter 查询指定区块之后的所有事件（按链上顺序）
+func (r *SyncRepository) FindEventsAfter(contractAddress string, blockNum uint64) ([]model.ChainEvent, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var events []model.ChainEvent
+	err := r.db.Where("contract_address = ? AND block_num > ?", contractAddress, blockNum).
+		Order("block_num ASC, log_index ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// FindEventsSince 按 ID 升序查询 ID 大于 afterID 的事件，最多 limit 条
+func (r *SyncRepository) FindEventsSince(afterID uint64, limit int) ([]model.ChainEvent, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var events []model.ChainEvent
+	err := r.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// FindLatestAssetEvent 查询资产在指定区块（含）之前最近一次的指定类型事件
+func (r *SyncRepository) FindLatestAssetEvent(contractAddress string, assetID uint64, eventNames []string, maxBlock uint64) (*model.ChainEvent, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var event model.ChainEvent
+	err := r.db.Where("contract_address = ? AND asset_id = ? AND event_name IN ? AND block_num <= ?",
+		contractAddress, assetID, eventNames, maxBlock).
+		Order("block_num DESC, log_index DESC").
+		First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &event, err
+}
+
+// CountAddressEvents 统计地址在指定区块（含）之前的指定类型事件数量
+func (r *SyncRepository) CountAddressEvents(contractAddress string, address model.Address, eventName string, maxBlock uint64) (int64, error) {
+	if err := r.ensureDB(); err != nil {
+		return 0, err
+	}
+	var count int64
+	err := r.db.Model(&model.ChainEvent{}).
+		Where("contract_address = ? AND address = ? AND event_name = ? AND block_num <= ?",
+			contractAddress, address, eventName, maxBlock).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteEventsAfter 删除指定区块之后的事件记录
+func (r *SyncRepository) DeleteEventsAfter(contractAddress string, blockNum uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Where("contract_address = ? AND block_num > ?", contractAddress, blockNum).
+		Delete(&model.ChainEvent{}).Error
+}
+
+// SaveBlockHash 记录已处理区块的哈希（已存在则覆盖）
+func (r *SyncRepository) SaveBlockHash(contractAddress string, blockNum uint64, blockHash string) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	block := &model.ProcessedBlock{
+		ContractAddress: contractAddress,
+		BlockNum:        blockNum,
+		BlockHash:       blockHash,
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_address"}, {Name: "block_num"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_hash"}),
+	}).Create(block).Error
+}
+
+// FindRecentBlocks 按区块高度倒序查询已记录的区块哈希
+func (r *SyncRepository) FindRecentBlocks(contractAddress string, limit int) ([]model.ProcessedBlock, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var blocks []model.ProcessedBlock
+	err := r.db.Where("contract_address = ?", contractAddress).
+		Order("block_num DESC").
+		Limit(limit).
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// DeleteBlocksAfter 删除指定区块之后的哈希记录
+func (r *SyncRepository) DeleteBlocksAfter(contractAddress string, blockNum uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Where("contract_address = ? AND block_num > ?", contractAddress, blockNum).
+		Delete(&model.ProcessedBlock{}).Error
+}
+
+// DeleteBlocksBefore 清理指定区块之前的哈希记录
+func (r *SyncRepository) DeleteBlocksBefore(contractAddress string, blockNum uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Where("contract_address = ? AND block_num < ?", contractAddress, blockNum).
+		Delete(&model.ProcessedBlock{}).Error
+}
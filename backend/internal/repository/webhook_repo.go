@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"chain-vault-backend/internal/database"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		db: nil,
+	}
+}
+
+func (r *WebhookRepository) ensureDB() error {
+	if r.db == nil {
+		r.db = database.GetDB()
+		if r.db == nil {
+			return errors.New("database connection is nil")
+		}
+	}
+	return nil
+}
+
+// WithTx 返回绑定到指定事务的仓储
+func (r *WebhookRepository) WithTx(tx *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: tx}
+}
+
+func (r *WebhookRepository) CreateSubscription(subscription *model.WebhookSubscription) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Create(subscription).Error
+}
+
+func (r *WebhookRepository) FindSubscription(id uint64) (*model.WebhookSubscription, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var subscription model.WebhookSubscription
+	err := r.db.First(&subscription, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &subscription, err
+}
+
+// FindSubscriptionsByAddress 查询地址登记的全部订阅，address 为空时返回所有订阅
+func (r *WebhookRepository) FindSubscriptionsByAddress(address model.Address) ([]model.WebhookSubscription, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	query := r.db.Order("id ASC")
+	if address != "" {
+		query = query.Where("address = ?", address)
+	}
+	var subscriptions []model.WebhookSubscription
+	err := query.Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// FindActiveSubscriptions 查询订阅了 addresses 中任一地址的启用中的订阅
+func (r *WebhookRepository) FindActiveSubscriptions(addresses []model.Address) ([]model.WebhookSubscription, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	var subscriptions []model.WebhookSubscription
+	err := r.db.Where("active = ? AND address IN ?", true, addresses).Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// UpdateSubscription 更新订阅的回调地址、事件类型和启用状态
+func (r *WebhookRepository) UpdateSubscription(subscription *model.WebhookSubscription) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(subscription).Updates(map[string]interface{}{
+		"url":         subscription.URL,
+		"event_types": subscription.EventTypes,
+		"active":      subscription.Active,
+	}).Error
+}
+
+// DeleteSubscription 删除订阅及其投递记录
+func (r *WebhookRepository) DeleteSubscription(id uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.WebhookSubscription{}, id).Error
+	})
+}
+
+// CreateDeliveries 写入投递记录，同一订阅的同一事件只写入一次
+func (r *WebhookRepository) CreateDeliveries(deliveries []model.WebhookDelivery) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+func (r *WebhookRepository) FindDelivery(id uint64) (*model.WebhookDelivery, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var delivery model.WebhookDelivery
+	err := r.db.First(&delivery, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &delivery, err
+}
+
+// FindDeliveriesBySubscription 分页查询订阅的投递记录，按 ID 倒序；status 为空时不限制
+func (r *WebhookRepository) FindDeliveriesBySubscription(subscriptionID uint64, status string, page pagination.Params) (*pagination.Page[model.WebhookDelivery], error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	query := r.db.Model(&model.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return keysetPage(query, page, "", deliveryCursor)
+}
+
+// FindDueDeliveries 查询到期待投递的记录，按到期时间排序
+func (r *WebhookRepository) FindDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	if err := r.ensureDB(); err != nil {
+		return nil, err
+	}
+	var deliveries []model.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// UpdateDelivery 保存一次投递的结果
+func (r *WebhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	return r.db.Model(delivery).Updates(map[string]interface{}{
+		"status":           delivery.Status,
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"last_status_code": delivery.LastStatusCode,
+		"last_error":       delivery.LastError,
+		"delivered_at":     delivery.DeliveredAt,
+	}).Error
+}
+
+// ResetDeliveries 把订阅的指定投递记录恢复为立即待投递并清零重试次数，ids 为空时重放该订阅的全部死信，返回重放的数量
+func (r *WebhookRepository) ResetDeliveries(subscriptionID uint64, ids []uint64, now time.Time) (int64, error) {
+	if err := r.ensureDB(); err != nil {
+		return 0, err
+	}
+	query := r.db.Model(&model.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	} else {
+		query = query.Where("status = ?", model.DeliveryDead)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":          model.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// DeletePendingByEvents 删除指定事件尚未投递的记录（用于链重组回滚）
+func (r *WebhookRepository) DeletePendingByEvents(eventIDs []uint64) error {
+	if err := r.ensureDB(); err != nil {
+		return err
+	}
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	return r.db.Where("status = ? AND event_id IN ?", model.DeliveryPending, eventIDs).
+		Delete(&model.WebhookDelivery{}).Error
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"chain-vault-backend/internal/repository"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidFilter 资产列表的筛选参数无效
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// dateLayout 只有日期的时间参数，按服务器本地时区解析
+const dateLayout = "2006-01-02"
+
+// AssetListParams 资产列表的筛选和排序参数，字符串形式，由 ListAssets 校验
+type AssetListParams struct {
+	Owner       string
+	Brand       string // 品牌地址
+	Status      string // unverified / pending / verified / rejected，或对应的数字 0-3
+	Listed      string // true / false
+	MinPrice    string // wei
+	MaxPrice    string
+	CreatedFrom string // 2006-01-02 或 RFC3339，包含当天
+	CreatedTo   string // 2006-01-02 或 RFC3339，只有日期时包含当天
+	FromBlock   string
+	ToBlock     string
+	Sort        string // newest / oldest / price_asc / price_desc
+}
+
+// ListAssets 按筛选条件分页查询资产
+func (s *AssetService) ListAssets(params AssetListParams, page pagination.Params) (*pagination.Page[model.Asset], error) {
+	filter, err := buildAssetFilter(params)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Find(*filter, page)
+}
+
+// GetListedAssets 分页查询在售资产
+func (s *AssetService) GetListedAssets(page pagination.Params) (*pagination.Page[model.Asset], error) {
+	listed := true
+	return s.repo.Find(repository.AssetFilter{Listed: &listed}, page)
+}
+
+// buildAssetFilter 校验资产列表的筛选参数
+func buildAssetFilter(params AssetListParams) (*repository.AssetFilter, error) {
+	filter := &repository.AssetFilter{Sort: params.Sort}
+
+	var err error
+	if filter.Owner, err = parseAddress(params.Owner); err != nil {
+		return nil, fmt.Errorf("%w: owner: %v", ErrInvalidFilter, err)
+	}
+	if filter.Brand, err = parseAddress(params.Brand); err != nil {
+		return nil, fmt.Errorf("%w: brand: %v", ErrInvalidFilter, err)
+	}
+
+	if params.Status != "" {
+		status, ok := parseStatus(params.Status)
+		if !ok {
+			return nil, fmt.Errorf("%w: status must be one of unverified, pending, verified, rejected", ErrInvalidFilter)
+		}
+		filter.Status = &status
+	}
+	if params.Listed != "" {
+		listed, err := strconv.ParseBool(params.Listed)
+		if err != nil {
+			return nil, fmt.Errorf("%w: listed must be true or false", ErrInvalidFilter)
+		}
+		filter.Listed = &listed
+	}
+
+	if filter.MinPrice, err = parseWei(params.MinPrice); err != nil {
+		return nil, fmt.Errorf("%w: minPrice %v", ErrInvalidFilter, err)
+	}
+	if filter.MaxPrice, err = parseWei(params.MaxPrice); err != nil {
+		return nil, fmt.Errorf("%w: maxPrice %v", ErrInvalidFilter, err)
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && filter.MinPrice.Cmp(*filter.MaxPrice) > 0 {
+		return nil, fmt.Errorf("%w: minPrice must not exceed maxPrice", ErrInvalidFilter)
+	}
+
+	if params.CreatedFrom != "" {
+		from, _, err := parseDate(params.CreatedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("%w: createdFrom %v", ErrInvalidFilter, err)
+		}
+		filter.CreatedFrom = &from
+	}
+	if params.CreatedTo != "" {
+		to, dateOnly, err := parseDate(params.CreatedTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: createdTo %v", ErrInvalidFilter, err)
+		}
+		// 只有日期时包含当天，否则包含该时刻
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		} else {
+			to = to.Add(time.Nanosecond)
+		}
+		filter.CreatedBefore = &to
+	}
+	if filter.CreatedFrom != nil && filter.CreatedBefore != nil && !filter.CreatedFrom.Before(*filter.CreatedBefore) {
+		return nil, fmt.Errorf("%w: createdFrom must not be after createdTo", ErrInvalidFilter)
+	}
+
+	if params.FromBlock != "" {
+		block, err := strconv.ParseUint(params.FromBlock, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: fromBlock must be a block number", ErrInvalidFilter)
+		}
+		filter.FromBlock = &block
+	}
+	if params.ToBlock != "" {
+		block, err := strconv.ParseUint(params.ToBlock, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: toBlock must be a block number", ErrInvalidFilter)
+		}
+		filter.ToBlock = &block
+	}
+	if filter.FromBlock != nil && filter.ToBlock != nil && *filter.FromBlock > *filter.ToBlock {
+		return nil, fmt.Errorf("%w: fromBlock must not exceed toBlock", ErrInvalidFilter)
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = repository.SortNewest
+	case repository.SortNewest, repository.SortOldest, repository.SortPriceAsc, repository.SortPriceDesc:
+	default:
+		return nil, fmt.Errorf("%w: sort must be one of newest, oldest, price_asc, price_desc", ErrInvalidFilter)
+	}
+	return filter, nil
+}
+
+// parseAddress 解析可选的地址参数，为空时返回空地址
+func parseAddress(value string) (model.Address, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return "", nil
+	}
+	return model.ParseAddress(value)
+}
+
+// parseDate 解析 2006-01-02 或 RFC3339 格式的时间，dateOnly 表示只有日期
+func parseDate(value string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, errors.New("must be a date (2006-01-02) or RFC3339 time")
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"chain-vault-backend/internal/imaging"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// 重复照片检测：同一张照片出现在序列号或持有人不同的另一个资产上，可能是用正品照片上架仿品
+
+// duplicatePhotoDistance 感知哈希的汉明距离不超过该值视为同一张照片
+// 重新压缩、缩放后的照片距离通常在 0-4，不同照片通常在 20 以上；必须小于 imaging.BandCount 才能用分段索引查到
+const duplicatePhotoDistance = 6
+
+// phashQueryBatch 一次查询候选图片的感知哈希数
+const phashQueryBatch = 50
+
+// 风险等级
+const (
+	RiskNone   = "none"   // 没有相同的照片
+	RiskLow    = "low"    // 只与同一持有人的其他资产相同（如同一卖家重复上架）
+	RiskMedium = "medium" // 与其他持有人的资产相同
+	RiskHigh   = "high"   // 与其他持有人的资产相同，且对方更早使用该照片
+)
+
+var riskRank = map[string]int{RiskNone: 0, RiskLow: 1, RiskMedium: 2, RiskHigh: 3}
+
+// PhotoMatch 资产的一张照片与另一资产照片相同
+type PhotoMatch struct {
+	Position            int           `json:"position"`
+	ImageURL            string        `json:"imageUrl"`
+	MatchedAssetID      uint64        `json:"matchedAssetId"`
+	MatchedPosition     int           `json:"matchedPosition"`
+	MatchedImageURL     string        `json:"matchedImageUrl"`
+	MatchedName         string        `json:"matchedName"`
+	MatchedSerialNumber string        `json:"matchedSerialNumber"`
+	MatchedOwner        model.Address `json:"matchedOwner"`
+	MatchedBrand        model.Address `json:"matchedBrand"`
+	MatchedAt           time.Time     `json:"matchedAt"` // 对方照片的上传时间
+	Distance            int           `json:"distance"`  // 感知哈希的汉明距离
+	SameOwner           bool          `json:"sameOwner"`
+	Earlier             bool          `json:"earlier"` // 对方的照片比本资产的更早上传
+}
+
+// AssetRisk 资产的重复照片风险
+type AssetRisk struct {
+	AssetID      uint64        `json:"assetId"`
+	Name         string        `json:"name"`
+	SerialNumber string        `json:"serialNumber"`
+	Owner        model.Address `json:"owner"`
+	Level        string        `json:"level"`
+	Matches      []PhotoMatch  `json:"matches"`
+}
+
+// GetAssetRisk 查询资产的照片是否与其他资产相同，资产不存在时返回 nil
+func (s *AssetService) GetAssetRisk(assetID uint64) (*AssetRisk, error) {
+	asset, err := s.repo.FindByID(assetID)
+	if err != nil || asset == nil {
+		return nil, err
+	}
+	images, err := s.imageRepo.FindWithAssetByAssetID(assetID)
+	if err != nil {
+		return nil, err
+	}
+	risks, err := s.photoRisks(images)
+	if err != nil {
+		return nil, err
+	}
+	if risk, ok := risks[assetID]; ok {
+		return risk, nil
+	}
+	return &AssetRisk{
+		AssetID:      asset.ID,
+		Name:         asset.Name,
+		SerialNumber: asset.SerialNumber,
+		Owner:        asset.Owner,
+		Level:        RiskNone,
+		Matches:      []PhotoMatch{},
+	}, nil
+}
+
+// FlagReusedPhotos 资产图片更新后检查是否与其他资产的照片相同，有风险时记录日志
+func (s *AssetService) FlagReusedPhotos(assetID uint64) (*AssetRisk, error) {
+	risk, err := s.GetAssetRisk(assetID)
+	if err != nil || risk == nil || risk.Level == RiskNone {
+		return risk, err
+	}
+	for _, match := range risk.Matches {
+		slog.Warn("possible reused photo", "asset_id", assetID, "image", match.Position,
+			"matched_asset_id", match.MatchedAssetID, "matched_image", match.MatchedPosition,
+			"matched_serial_number", match.MatchedSerialNumber, "matched_owner", match.MatchedOwner,
+			"distance", match.Distance, "risk", risk.Level)
+	}
+	return risk, nil
+}
+
+// GetBrandRisk 查询品牌方资产中照片与其他资产相同的资产，按风险等级从高到低排序
+func (s *AssetService) GetBrandRisk(brand model.Address) ([]AssetRisk, error) {
+	images, err := s.imageRepo.FindWithAssetByBrand(brand)
+	if err != nil {
+		return nil, err
+	}
+	risks, err := s.photoRisks(images)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AssetRisk, 0, len(risks))
+	for _, risk := range risks {
+		if len(risk.Matches) > 0 {
+			result = append(result, *risk)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if riskRank[result[i].Level] != riskRank[result[j].Level] {
+			return riskRank[result[i].Level] > riskRank[result[j].Level]
+		}
+		return result[i].AssetID < result[j].AssetID
+	})
+	return result, nil
+}
+
+// photoRisks 为 images 所属的每个资产查找相同的照片
+func (s *AssetService) photoRisks(images []repository.ImageWithAsset) (map[uint64]*AssetRisk, error) {
+	risks := make(map[uint64]*AssetRisk)
+	byPHash := make(map[uint64][]repository.ImageWithAsset)
+	for _, image := range images {
+		if _, ok := risks[image.AssetID]; !ok {
+			risks[image.AssetID] = &AssetRisk{
+				AssetID:      image.AssetID,
+				Name:         image.Name,
+				SerialNumber: image.SerialNumber,
+				Owner:        image.Owner,
+				Level:        RiskNone,
+				Matches:      []PhotoMatch{},
+			}
+		}
+		hash, err := imaging.ParsePHash(image.PHash)
+		if err != nil {
+			return nil, err
+		}
+		byPHash[hash] = append(byPHash[hash], image)
+	}
+
+	hashes := make([]uint64, 0, len(byPHash))
+	for hash := range byPHash {
+		hashes = append(hashes, hash)
+	}
+	for start := 0; start < len(hashes); start += phashQueryBatch {
+		batch := hashes[start:min(start+phashQueryBatch, len(hashes))]
+		bands := make([][]int, len(batch))
+		for i, hash := range batch {
+			bands[i] = phashBands(hash)
+		}
+		candidates, err := s.imageRepo.FindByPHashBands(bands)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range candidates {
+			candidateHash, err := imaging.ParsePHash(candidate.PHash)
+			if err != nil {
+				return nil, err
+			}
+			for _, hash := range batch {
+				distance := imaging.Distance(hash, candidateHash)
+				if distance > duplicatePhotoDistance {
+					continue
+				}
+				for _, image := range byPHash[hash] {
+					if match, ok := photoMatch(image, candidate, distance); ok {
+						risk := risks[image.AssetID]
+						risk.Matches = append(risk.Matches, match)
+					}
+				}
+			}
+		}
+	}
+
+	for _, risk := range risks {
+		sort.Slice(risk.Matches, func(i, j int) bool {
+			a, b := risk.Matches[i], risk.Matches[j]
+			if a.Position != b.Position {
+				return a.Position < b.Position
+			}
+			if a.MatchedAssetID != b.MatchedAssetID {
+				return a.MatchedAssetID < b.MatchedAssetID
+			}
+			return a.MatchedPosition < b.MatchedPosition
+		})
+		risk.Level = riskLevel(risk.Matches)
+	}
+	return risks, nil
+}
+
+// photoMatch 判断候选图片是否构成风险：必须属于另一资产，且序列号或持有人不同
+func photoMatch(image, candidate repository.ImageWithAsset, distance int) (PhotoMatch, bool) {
+	if candidate.AssetID == image.AssetID {
+		return PhotoMatch{}, false
+	}
+	sameOwner := candidate.Owner == image.Owner
+	if sameOwner && candidate.SerialNumber == image.SerialNumber {
+		return PhotoMatch{}, false
+	}
+	earlier := candidate.CreatedAt.Before(image.CreatedAt) ||
+		(candidate.CreatedAt.Equal(image.CreatedAt) && candidate.ID < image.ID)
+	return PhotoMatch{
+		Position:            image.Position,
+		ImageURL:            image.URL(),
+		MatchedAssetID:      candidate.AssetID,
+		MatchedPosition:     candidate.Position,
+		MatchedImageURL:     candidate.URL(),
+		MatchedName:         candidate.Name,
+		MatchedSerialNumber: candidate.SerialNumber,
+		MatchedOwner:        candidate.Owner,
+		MatchedBrand:        candidate.Brand,
+		MatchedAt:           candidate.CreatedAt,
+		Distance:            distance,
+		SameOwner:           sameOwner,
+		Earlier:             earlier,
+	}, true
+}
+
+// riskLevel 按最严重的匹配确定风险等级
+func riskLevel(matches []PhotoMatch) string {
+	level := RiskNone
+	for _, match := range matches {
+		current := RiskLow
+		if !match.SameOwner {
+			current = RiskMedium
+			if match.Earlier {
+				current = RiskHigh
+			}
+		}
+		if riskRank[current] > riskRank[level] {
+			level = current
+		}
+	}
+	return level
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"chain-vault-backend/internal/imaging"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+)
+
+func TestPhotoMatch(t *testing.T) {
+	const (
+		alice = model.Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+		bob   = model.Address("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+	)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	image := func(id, assetID uint64, owner model.Address, serial string, createdAt time.Time) repository.ImageWithAsset {
+		img := repository.ImageWithAsset{Owner: owner, SerialNumber: serial}
+		img.ID, img.AssetID, img.CreatedAt = id, assetID, createdAt
+		return img
+	}
+	mine := image(10, 1, alice, "SN-1", now)
+
+	tests := []struct {
+		name      string
+		candidate repository.ImageWithAsset
+		ok        bool
+		sameOwner bool
+		earlier   bool
+	}{
+		{"same asset", image(11, 1, alice, "SN-1", now), false, false, false},
+		{"same owner and serial relisted", image(20, 2, alice, "SN-1", now), false, false, false},
+		{"same owner, other serial", image(20, 2, alice, "SN-2", now.Add(time.Hour)), true, true, false},
+		{"other owner, later", image(20, 2, bob, "SN-1", now.Add(time.Hour)), true, false, false},
+		{"other owner, earlier", image(20, 2, bob, "SN-2", now.Add(-time.Hour)), true, false, true},
+		{"same time, lower id is earlier", image(5, 2, bob, "SN-2", now), true, false, true},
+		{"same time, higher id is later", image(15, 2, bob, "SN-2", now), true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := photoMatch(mine, tt.candidate, 3)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if match.SameOwner != tt.sameOwner || match.Earlier != tt.earlier {
+				t.Errorf("sameOwner = %v, earlier = %v, want %v, %v", match.SameOwner, match.Earlier, tt.sameOwner, tt.earlier)
+			}
+			if match.MatchedAssetID != tt.candidate.AssetID || match.Distance != 3 {
+				t.Errorf("match = %+v", match)
+			}
+		})
+	}
+}
+
+func TestRiskLevel(t *testing.T) {
+	sameOwner := PhotoMatch{SameOwner: true}
+	otherLater := PhotoMatch{}
+	otherEarlier := PhotoMatch{Earlier: true}
+
+	tests := []struct {
+		matches []PhotoMatch
+		want    string
+	}{
+		{nil, RiskNone},
+		{[]PhotoMatch{sameOwner}, RiskLow},
+		{[]PhotoMatch{{SameOwner: true, Earlier: true}}, RiskLow},
+		{[]PhotoMatch{sameOwner, otherLater}, RiskMedium},
+		{[]PhotoMatch{otherEarlier, sameOwner, otherLater}, RiskHigh},
+	}
+	for _, tt := range tests {
+		if got := riskLevel(tt.matches); got != tt.want {
+			t.Errorf("riskLevel(%+v) = %s, want %s", tt.matches, got, tt.want)
+		}
+	}
+}
+
+func TestDuplicatePhotoDistanceFitsBandIndex(t *testing.T) {
+	// 距离达到 BandCount 的哈希可能没有相同的段，分段索引查不到
+	if duplicatePhotoDistance >= imaging.BandCount {
+		t.Errorf("duplicatePhotoDistance = %d, must be below imaging.BandCount (%d)", duplicatePhotoDistance, imaging.BandCount)
+	}
+}
@@ -3,22 +3,44 @@ package service
 import (
 	"chain-vault-backend/internal/model"
 	"chain-vault-backend/internal/repository"
-	"encoding/json"
-	logpkg "log"
+	"context"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type AssetService struct {
-	repo *repository.AssetRepository
+	repo       *repository.AssetRepository
+	imageRepo  *repository.ImageRepository
+	brandRepo  *repository.BrandRepository
+	searchRepo *repository.SearchRepository
 }
 
 func NewAssetService() *AssetService {
 	return &AssetService{
-		repo: repository.NewAssetRepository(),
+		repo:       repository.NewAssetRepository(),
+		imageRepo:  repository.NewImageRepository(),
+		brandRepo:  repository.NewBrandRepository(),
+		searchRepo: repository.NewSearchRepository(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *AssetService) WithTx(tx *gorm.DB) *AssetService {
+	return &AssetService{
+		repo:       s.repo.WithTx(tx),
+		imageRepo:  s.imageRepo.WithTx(tx),
+		brandRepo:  s.brandRepo.WithTx(tx),
+		searchRepo: s.searchRepo.WithTx(tx),
 	}
 }
 
-func (s *AssetService) CreateAsset(assetID uint64, owner string, name string, txHash string, blockNum uint64) error {
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *AssetService) WithContext(ctx context.Context) *AssetService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+func (s *AssetService) CreateAsset(assetID uint64, owner model.Address, name string, txHash string, blockNum uint64) error {
 	asset := &model.Asset{
 		ID:        assetID,
 		Owner:     owner,
@@ -27,27 +49,13 @@ func (s *AssetService) CreateAsset(assetID uint64, owner string, name string, tx
 		TxHash:    txHash,
 		BlockNum:  blockNum,
 	}
-	return s.repo.Create(asset)
-}
-
-func (s *AssetService) CreateAssetV3(assetID uint64, owner, brand, name, serialNumber, metadataURI, txHash string, blockNum uint64, status model.VerificationStatus) error {
-	asset := &model.Asset{
-		ID:           assetID,
-		Owner:        owner,
-		Brand:        brand,
-		Name:         name,
-		SerialNumber: serialNumber,
-		MetadataURI:  metadataURI,
-		Status:       status,
-		CreatedAt:    time.Now(),
-		TxHash:       txHash,
-		BlockNum:     blockNum,
+	if err := s.repo.Create(asset); err != nil {
+		return err
 	}
-	return s.repo.Create(asset)
+	return s.indexAsset(asset)
 }
 
-// CreateAssetV3WithImages 创建资产并存储 base64 图片数组
-func (s *AssetService) CreateAssetV3WithImages(assetID uint64, owner, brand, name, serialNumber, metadataURI, txHash string, blockNum uint64, status model.VerificationStatus, imageBase64Array []string) error {
+func (s *AssetService) CreateAssetV3(assetID uint64, owner, brand model.Address, name, serialNumber, metadataURI, txHash string, blockNum uint64, status model.VerificationStatus) error {
 	asset := &model.Asset{
 		ID:           assetID,
 		Owner:        owner,
@@ -60,74 +68,21 @@ func (s *AssetService) CreateAssetV3WithImages(assetID uint64, owner, brand, nam
 		TxHash:       txHash,
 		BlockNum:     blockNum,
 	}
-
-	// 将 base64 图片数组转为 JSON 存储
-	if len(imageBase64Array) > 0 {
-		imagesJSON, err := json.Marshal(imageBase64Array)
-		if err == nil {
-			asset.Images = string(imagesJSON)
-		}
-	}
-
-	return s.repo.Create(asset)
-}
-
-// UpdateAssetImages 更新资产的图片
-func (s *AssetService) UpdateAssetImages(assetID uint64, imageBase64Array []string) error {
-	var imagesJSON string
-	if len(imageBase64Array) > 0 {
-		bytes, err := json.Marshal(imageBase64Array)
-		if err != nil {
-			return err
-		}
-		imagesJSON = string(bytes)
-	}
-
-	// 打印日志以便调试
-	if len(imagesJSON) > 100 {
-		logpkg.Printf("Updating images for asset %d, data length: %d bytes", assetID, len(imagesJSON))
-	} else {
-		logpkg.Printf("Updating images for asset %d, data: %s", assetID, imagesJSON)
-	}
-
-	// 重试机制：尝试 20 次，每次间隔 500ms（共10秒），等待 EventListener 创建资产
-	// 前端在交易确认后立即调用此接口，但后端 EventListener 需要轮询区块，存在延迟
-	var lastErr error
-	for i := 0; i < 20; i++ {
-		err := s.repo.UpdateImages(assetID, imagesJSON)
-		if err == nil {
-			logpkg.Printf("Successfully updated images for asset %d on attempt %d", assetID, i+1)
-			return nil
-		}
-		// 如果是未找到资产错误，继续重试
-		lastErr = err
-		if i%5 == 0 { // 每5次打印一次日志
-			logpkg.Printf("Attempt %d/20: Failed to update images for asset %d: %v", i+1, assetID, err)
-		}
-		time.Sleep(500 * time.Millisecond)
+	if err := s.repo.Create(asset); err != nil {
+		return err
 	}
-	
-	logpkg.Printf("Final failure: Could not update images for asset %d after 20 attempts: %v", assetID, lastErr)
-	return lastErr
+	return s.indexAsset(asset)
 }
 
 func (s *AssetService) GetAsset(id uint64) (*model.Asset, error) {
 	return s.repo.FindByID(id)
 }
 
-func (s *AssetService) ListAssets(limit, offset int) ([]model.Asset, error) {
-	return s.repo.FindAll(limit, offset)
-}
-
 func (s *AssetService) GetTotalCount() (int64, error) {
 	return s.repo.Count()
 }
 
-func (s *AssetService) GetAssetsByOwner(owner string, limit, offset int) ([]model.Asset, error) {
-	return s.repo.FindByOwner(owner, limit, offset)
-}
-
-func (s *AssetService) GetCountByOwner(owner string) (int64, error) {
+func (s *AssetService) GetCountByOwner(owner model.Address) (int64, error) {
 	return s.repo.CountByOwner(owner)
 }
 
@@ -135,15 +90,11 @@ func (s *AssetService) GetTopOwners(limit int) ([]map[string]interface{}, error)
 	return s.repo.GetTopOwners(limit)
 }
 
-func (s *AssetService) GetAssetsByDateRange(startDate, endDate string, limit, offset int) ([]model.Asset, error) {
-	return s.repo.GetAssetsByDateRange(startDate, endDate, limit, offset)
-}
-
 func (s *AssetService) GetDailyStats(days int) ([]map[string]interface{}, error) {
 	return s.repo.GetDailyStats(days)
 }
 
-func (s *AssetService) UpdateAssetOwner(assetID uint64, newOwner string, txHash string, blockNum uint64) error {
+func (s *AssetService) UpdateAssetOwner(assetID uint64, newOwner model.Address, txHash string, blockNum uint64) error {
 	return s.repo.UpdateOwner(assetID, newOwner, txHash, blockNum)
 }
 
@@ -151,40 +102,100 @@ func (s *AssetService) GetAssetBySerialNumber(serialNumber string) (*model.Asset
 	return s.repo.FindBySerialNumber(serialNumber)
 }
 
-func (s *AssetService) GetAssetsByBrand(brand string, limit, offset int) ([]model.Asset, error) {
-	return s.repo.FindByBrand(brand, limit, offset)
+func (s *AssetService) UpdateListingStatus(assetID uint64, isListed bool, price model.Wei) error {
+	return s.repo.UpdateListingStatus(assetID, isListed, price)
 }
 
-func (s *AssetService) GetListedAssets(limit, offset int) ([]model.Asset, error) {
-	return s.repo.FindListed(limit, offset)
+func (s *AssetService) UpdateVerificationStatus(assetID uint64, status model.VerificationStatus, brand, verifier model.Address) error {
+	if err := s.repo.UpdateVerificationStatus(assetID, status, brand, verifier); err != nil {
+		return err
+	}
+	if brand == "" {
+		return nil
+	}
+	return s.reindexAsset(assetID)
 }
 
-func (s *AssetService) GetListedAssetsCount() (int64, error) {
-	return s.repo.CountListed()
+// SetListed 只修改上架标记（订单创建、取消、退款时合约会直接改动该标记）
+func (s *AssetService) SetListed(assetID uint64, isListed bool) error {
+	return s.repo.UpdateListedFlag(assetID, isListed)
 }
 
-func (s *AssetService) GetAssetsByStatus(status model.VerificationStatus, limit, offset int) ([]model.Asset, error) {
-	return s.repo.FindByStatus(status, limit, offset)
+// ListAsset 上架资产
+func (s *AssetService) ListAsset(assetID uint64, price model.Wei) error {
+	return s.repo.UpdateListingStatus(assetID, true, price)
 }
 
-func (s *AssetService) SearchAssets(keyword string, limit, offset int) ([]model.Asset, error) {
-	return s.repo.Search(keyword, limit, offset)
+// UnlistAsset 下架资产
+func (s *AssetService) UnlistAsset(assetID uint64) error {
+	return s.repo.UpdateListingStatus(assetID, false, model.Wei{})
+}
+
+// RestoreAssetState 按链上状态恢复资产（链重组回滚时使用）
+func (s *AssetService) RestoreAssetState(assetID uint64, owner, brand model.Address, status model.VerificationStatus, isListed bool, price model.Wei, txHash string, blockNum uint64) error {
+	if err := s.repo.UpdateFields(assetID, map[string]interface{}{
+		"owner":     owner,
+		"brand":     brand,
+		"status":    status,
+		"is_listed": isListed,
+		"price":     price,
+		"tx_hash":   txHash,
+		"block_num": blockNum,
+	}); err != nil {
+		return err
+	}
+	return s.reindexAsset(assetID)
 }
 
-func (s *AssetService) UpdateListingStatus(assetID uint64, isListed bool, price string) error {
-	return s.repo.UpdateListingStatus(assetID, isListed, price)
+// DeleteAssets 删除孤块中注册的资产及其图片记录、搜索文档
+func (s *AssetService) DeleteAssets(ids []uint64) error {
+	if err := s.imageRepo.DeleteByAssetIDs(ids); err != nil {
+		return err
+	}
+	if err := s.searchRepo.DeleteDocs(ids); err != nil {
+		return err
+	}
+	return s.repo.DeleteByIDs(ids)
 }
 
-func (s *AssetService) UpdateVerificationStatus(assetID uint64, status model.VerificationStatus, brand string) error {
-	return s.repo.UpdateVerificationStatus(assetID, status, brand)
+// SetMetadataURI 记录资产在链上登记的元数据 URI，元数据由 SearchService 在后台读取
+func (s *AssetService) SetMetadataURI(assetID uint64, metadataURI string) error {
+	return s.repo.UpdateFields(assetID, map[string]interface{}{
+		"metadata_uri": metadataURI,
+	})
 }
 
-// ListAsset 上架资产
-func (s *AssetService) ListAsset(assetID uint64, priceWei string) error {
-	return s.repo.UpdateListingStatus(assetID, true, priceWei)
+// FindAssetsWithoutMetadataURI 查询 id 大于 afterID、没有元数据 URI 的资产 ID
+func (s *AssetService) FindAssetsWithoutMetadataURI(afterID uint64, limit int) ([]uint64, error) {
+	return s.repo.FindIDsWithoutMetadataURI(afterID, limit)
 }
 
-// UnlistAsset 下架资产
-func (s *AssetService) UnlistAsset(assetID uint64) error {
-	return s.repo.UpdateListingStatus(assetID, false, "0")
+// reindexAsset 重新写入资产的搜索文档（品牌变化后）
+func (s *AssetService) reindexAsset(assetID uint64) error {
+	asset, err := s.repo.FindByID(assetID)
+	if err != nil || asset == nil {
+		return err
+	}
+	return s.indexAsset(asset)
+}
+
+// indexAsset 写入资产的搜索文档：名称、序列号和品牌名称
+func (s *AssetService) indexAsset(asset *model.Asset) error {
+	brandName := ""
+	if asset.Brand != "" {
+		brand, err := s.brandRepo.FindByAddress(asset.Brand)
+		if err != nil {
+			return err
+		}
+		if brand != nil {
+			brandName = brand.BrandName
+		}
+	}
+	return s.searchRepo.UpsertDoc(&model.AssetSearchDoc{
+		AssetID:      asset.ID,
+		Name:         asset.Name,
+		SerialNumber: asset.SerialNumber,
+		BrandName:    brandName,
+		UpdatedAt:    time.Now(),
+	})
 }
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"chain-vault-backend/internal/auth"
+	"chain-vault-backend/internal/config"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceTTL 登录随机数的有效期，需在此时间内完成签名
+const nonceTTL = 10 * time.Minute
+
+var (
+	// ErrNonceInvalid 随机数不存在、已过期或已被使用
+	ErrNonceInvalid = errors.New("nonce is invalid, expired or already used")
+	// ErrSessionInvalid 会话 token 不存在、已过期或已注销
+	ErrSessionInvalid = errors.New("session is invalid or expired")
+)
+
+// AuthService 钱包签名登录与会话管理
+type AuthService struct {
+	repo       *repository.AuthRepository
+	domain     string
+	chainID    uint64
+	sessionTTL time.Duration
+	admins     map[common.Address]bool
+}
+
+func NewAuthService(cfg *config.Config) *AuthService {
+	admins := make(map[common.Address]bool)
+	for _, addr := range cfg.Auth.AdminAddresses {
+		if !common.IsHexAddress(addr) {
+			slog.Warn("ignoring invalid admin address", "address", addr)
+			continue
+		}
+		admins[common.HexToAddress(addr)] = true
+	}
+
+	ttl := cfg.Auth.SessionTTL.Duration
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return &AuthService{
+		repo:       repository.NewAuthRepository(),
+		domain:     cfg.Auth.Domain,
+		chainID:    cfg.Auth.ChainID,
+		sessionTTL: ttl,
+		admins:     admins,
+	}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *AuthService) WithContext(ctx context.Context) *AuthService {
+	copied := *s
+	copied.repo = s.repo.WithTx(dbWithContext(ctx))
+	return &copied
+}
+
+// IssueNonce 生成登录随机数，客户端将其写入 SIWE 消息的 Nonce 字段
+func (s *AuthService) IssueNonce() (*model.AuthNonce, error) {
+	now := time.Now()
+	if err := s.repo.DeleteExpired(now); err != nil {
+		slog.Warn("failed to delete expired auth records", "error", err)
+	}
+
+	value, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	nonce := &model.AuthNonce{
+		Nonce:     value,
+		ExpiresAt: now.Add(nonceTTL),
+	}
+	if err := s.repo.CreateNonce(nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// Login 校验签名后的 SIWE 消息并签发会话，返回会话及 token
+func (s *AuthService) Login(message, signature string) (*model.AuthSession, string, error) {
+	msg, err := auth.Verify(message, signature)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.domain != "" && !strings.EqualFold(msg.Domain, s.domain) {
+		return nil, "", fmt.Errorf("%w: domain %q does not match %q", auth.ErrInvalidMessage, msg.Domain, s.domain)
+	}
+	if s.chainID != 0 && msg.ChainID != s.chainID {
+		return nil, "", fmt.Errorf("%w: chain ID %d does not match %d", auth.ErrInvalidMessage, msg.ChainID, s.chainID)
+	}
+	now := time.Now()
+	if err := msg.ValidAt(now); err != nil {
+		return nil, "", err
+	}
+
+	ok, err := s.repo.ConsumeNonce(msg.Nonce, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		return nil, "", ErrNonceInvalid
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+	expiresAt := now.Add(s.sessionTTL)
+	if msg.ExpirationTime != nil && msg.ExpirationTime.Before(expiresAt) {
+		expiresAt = *msg.ExpirationTime
+	}
+
+	session := &model.AuthSession{
+		TokenHash: hashToken(token),
+		Address:   model.NewAddress(msg.Address),
+		ChainID:   msg.ChainID,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repo.CreateSession(session); err != nil {
+		return nil, "", err
+	}
+	return session, token, nil
+}
+
+// Authenticate 根据 token 查询有效会话
+func (s *AuthService) Authenticate(token string) (*model.AuthSession, error) {
+	if token == "" {
+		return nil, ErrSessionInvalid
+	}
+	session, err := s.repo.FindActiveSession(hashToken(token), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionInvalid
+	}
+	return session, nil
+}
+
+// Logout 注销会话
+func (s *AuthService) Logout(token string) error {
+	return s.repo.RevokeSession(hashToken(token), time.Now())
+}
+
+// IsAdmin 判断地址是否为配置的管理员
+func (s *AuthService) IsAdmin(address model.Address) bool {
+	return common.IsHexAddress(address.String()) && s.admins[address.Common()]
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
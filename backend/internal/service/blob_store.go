@@ -0,0 +1,155 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrBlobNotFound blob 存储中没有对应的内容
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore 按内容寻址的二进制存储
+type BlobStore interface {
+	// Name 存储后端名称，记录在 AssetImage.Storage 中
+	Name() string
+	// Put 保存内容，hash 为内容的 SHA-256，返回在存储中的位置
+	Put(data []byte, hash, mimeType string) (string, error)
+	// Get 读取 Put 返回的位置上的内容
+	Get(location string) ([]byte, error)
+}
+
+var (
+	blobStoresMu sync.RWMutex
+	blobStores   = map[string]BlobStore{
+		model.StorageLocal: NewLocalBlobStore("data/blobs"),
+	}
+	defaultBlobStore = model.StorageLocal
+)
+
+// ConfigureBlobStores 配置 blob 存储
+// 本地存储始终可读，backend 决定新图片写入哪个存储；切换后端后旧图片仍从原存储读取
+func ConfigureBlobStores(backend, localDir string) error {
+	stores := map[string]BlobStore{
+		model.StorageLocal: NewLocalBlobStore(localDir),
+		model.StorageIPFS:  NewIPFSBlobStore(NewIPFSService()),
+	}
+	if _, ok := stores[backend]; !ok {
+		return fmt.Errorf("unknown blob backend %q", backend)
+	}
+
+	blobStoresMu.Lock()
+	defer blobStoresMu.Unlock()
+	blobStores = stores
+	defaultBlobStore = backend
+	return nil
+}
+
+// getBlobStore 按名称返回 blob 存储，name 为空时返回写入新内容使用的存储
+func getBlobStore(name string) (BlobStore, error) {
+	blobStoresMu.RLock()
+	defer blobStoresMu.RUnlock()
+	if name == "" {
+		name = defaultBlobStore
+	}
+	store, ok := blobStores[name]
+	if !ok {
+		return nil, fmt.Errorf("blob backend %q is not configured", name)
+	}
+	return store, nil
+}
+
+// LocalBlobStore 本地文件系统存储，文件路径为 <dir>/<哈希前两位>/<哈希>
+type LocalBlobStore struct {
+	dir string
+}
+
+func NewLocalBlobStore(dir string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir}
+}
+
+func (s *LocalBlobStore) Name() string {
+	return model.StorageLocal
+}
+
+func (s *LocalBlobStore) Put(data []byte, hash, mimeType string) (string, error) {
+	path, err := s.path(hash)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil // 内容相同，已经存在
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	// 先写临时文件再重命名，避免并发读到写了一半的文件
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return hash, nil
+}
+
+func (s *LocalBlobStore) Get(location string) ([]byte, error) {
+	path, err := s.path(location)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+// path 返回哈希对应的文件路径，拒绝非十六进制的名称以免越出存储目录
+func (s *LocalBlobStore) path(hash string) (string, error) {
+	if len(hash) < 3 {
+		return "", fmt.Errorf("invalid blob hash %q", hash)
+	}
+	for _, c := range hash {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return "", fmt.Errorf("invalid blob hash %q", hash)
+		}
+	}
+	return filepath.Join(s.dir, hash[:2], hash), nil
+}
+
+// IPFSBlobStore 通过 IPFS 节点存储，位置为 CID
+type IPFSBlobStore struct {
+	ipfs *IPFSService
+}
+
+func NewIPFSBlobStore(ipfs *IPFSService) *IPFSBlobStore {
+	return &IPFSBlobStore{ipfs: ipfs}
+}
+
+func (s *IPFSBlobStore) Name() string {
+	return model.StorageIPFS
+}
+
+func (s *IPFSBlobStore) Put(data []byte, hash, mimeType string) (string, error) {
+	return s.ipfs.UploadFile(data, hash)
+}
+
+func (s *IPFSBlobStore) Get(location string) ([]byte, error) {
+	return s.ipfs.GetFile(location)
+}
@@ -2,50 +2,103 @@ package service
 
 import (
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/repository"
+	"context"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type BrandService struct {
-	repo *repository.BrandRepository
+	repo       *repository.BrandRepository
+	searchRepo *repository.SearchRepository
 }
 
 func NewBrandService() *BrandService {
 	return &BrandService{
-		repo: repository.NewBrandRepository(),
+		repo:       repository.NewBrandRepository(),
+		searchRepo: repository.NewSearchRepository(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *BrandService) WithTx(tx *gorm.DB) *BrandService {
+	return &BrandService{
+		repo:       s.repo.WithTx(tx),
+		searchRepo: s.searchRepo.WithTx(tx),
 	}
 }
 
-func (s *BrandService) CreateBrand(brandAddress, brandName, txHash string, blockNum uint64) error {
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *BrandService) WithContext(ctx context.Context) *BrandService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// CreateBrand 创建品牌记录，registeredAt 应为 BrandRegistered 事件所在区块的时间
+func (s *BrandService) CreateBrand(brandAddress model.Address, brandName, txHash string, blockNum uint64, registeredAt time.Time) error {
 	brand := &model.Brand{
 		BrandAddress: brandAddress,
 		BrandName:    brandName,
 		IsAuthorized: false,
-		RegisteredAt: time.Now(),
+		RegisteredAt: registeredAt,
 		TxHash:       txHash,
 		BlockNum:     blockNum,
 	}
-	return s.repo.Create(brand)
+	if err := s.repo.Create(brand); err != nil {
+		return err
+	}
+	// 品牌可能在其资产之后注册
+	return s.searchRepo.UpdateBrandName(brandAddress, brandName)
 }
 
-func (s *BrandService) GetBrand(address string) (*model.Brand, error) {
+func (s *BrandService) GetBrand(address model.Address) (*model.Brand, error) {
 	return s.repo.FindByAddress(address)
 }
 
-func (s *BrandService) ListBrands(limit, offset int) ([]model.Brand, error) {
-	return s.repo.FindAll(limit, offset)
+func (s *BrandService) ListBrands(page pagination.Params) (*pagination.Page[model.Brand], error) {
+	return s.repo.FindAll(page)
 }
 
-func (s *BrandService) ListAuthorizedBrands() ([]model.Brand, error) {
-	return s.repo.FindAuthorized()
+func (s *BrandService) ListAuthorizedBrands(page pagination.Params) (*pagination.Page[model.Brand], error) {
+	return s.repo.FindAuthorized(page)
 }
 
-func (s *BrandService) UpdateAuthorization(address string, authorized bool) error {
+func (s *BrandService) UpdateAuthorization(address model.Address, authorized bool) error {
 	return s.repo.UpdateAuthorization(address, authorized)
 }
 
+func (s *BrandService) UpdateBrandName(address model.Address, brandName string) error {
+	if err := s.repo.UpdateName(address, brandName); err != nil {
+		return err
+	}
+	return s.searchRepo.UpdateBrandName(address, brandName)
+}
+
 func (s *BrandService) GetTotalCount() (int64, error) {
 	return s.repo.Count()
 }
 
+// RestoreBrandState 按链上状态恢复品牌（链重组回滚时使用）
+func (s *BrandService) RestoreBrandState(address model.Address, brandName string, authorized bool) error {
+	if err := s.repo.UpdateFields(address, map[string]interface{}{
+		"brand_name":    brandName,
+		"is_authorized": authorized,
+	}); err != nil {
+		return err
+	}
+	return s.searchRepo.UpdateBrandName(address, brandName)
+}
 
+// DeleteBrands 删除孤块中注册的品牌
+func (s *BrandService) DeleteBrands(addresses []model.Address) error {
+	if err := s.repo.DeleteByAddresses(addresses); err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if err := s.searchRepo.UpdateBrandName(address, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"chain-vault-backend/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// dbWithContext 返回携带 ctx 的数据库会话，SQL 日志会带上 ctx 上的日志属性（如请求 ID）
+// 数据库未连接时返回 nil，仓储在使用时返回连接错误
+func dbWithContext(ctx context.Context) *gorm.DB {
+	db := database.GetDB()
+	if db == nil {
+		return nil
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"chain-vault-backend/internal/events"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+// replayBatch 断线续传时每次从数据库读取的事件数
+const replayBatch = 200
+
+// zeroAddress 注册记录的原所有者
+var zeroAddress = model.NewAddress(common.Address{})
+
+// EventStreamService 把已处理的链上日志转换为推送事件
+type EventStreamService struct {
+	syncRepo    *repository.SyncRepository
+	assetRepo   *repository.AssetRepository
+	orderRepo   *repository.OrderRepository
+	brandRepo   *repository.BrandRepository
+	historyRepo *repository.HistoryRepository
+}
+
+func NewEventStreamService() *EventStreamService {
+	return &EventStreamService{
+		syncRepo:    repository.NewSyncRepository(),
+		assetRepo:   repository.NewAssetRepository(),
+		orderRepo:   repository.NewOrderRepository(),
+		brandRepo:   repository.NewBrandRepository(),
+		historyRepo: repository.NewHistoryRepository(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务，用于在写入事件的事务中读取事件涉及的最新状态
+func (s *EventStreamService) WithTx(tx *gorm.DB) *EventStreamService {
+	return &EventStreamService{
+		syncRepo:    s.syncRepo.WithTx(tx),
+		assetRepo:   s.assetRepo.WithTx(tx),
+		orderRepo:   s.orderRepo.WithTx(tx),
+		brandRepo:   s.brandRepo.WithTx(tx),
+		historyRepo: s.historyRepo.WithTx(tx),
+	}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *EventStreamService) WithContext(ctx context.Context) *EventStreamService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// Publish 发布已提交的事件记录，由监听器在事务提交后调用
+func (s *EventStreamService) Publish(records []model.ChainEvent) {
+	for _, record := range records {
+		ev, err := s.build(record)
+		if err != nil {
+			slog.Error("failed to build stream event", "event_id", record.ID, "event", record.EventName, "error", err)
+			continue
+		}
+		events.Default().Publish(*ev)
+	}
+}
+
+// PublishReorg 通知订阅者发生了链重组
+func (s *EventStreamService) PublishReorg(ancestor uint64) {
+	events.Default().Publish(events.Event{Type: events.TypeReorg, BlockNum: ancestor})
+}
+
+// Replay 按顺序把 ID 大于 afterID 且满足条件的事件交给 send，返回最后读取的事件 ID
+// send 返回错误时停止
+func (s *EventStreamService) Replay(afterID uint64, filter events.Filter, send func(events.Event) error) (uint64, error) {
+	for {
+		records, err := s.syncRepo.FindEventsSince(afterID, replayBatch)
+		if err != nil {
+			return afterID, err
+		}
+		for _, record := range records {
+			afterID = record.ID
+			ev, err := s.build(record)
+			if err != nil {
+				return afterID, err
+			}
+			if !filter.Match(*ev) {
+				continue
+			}
+			if err := send(*ev); err != nil {
+				return afterID, err
+			}
+		}
+		if len(records) < replayBatch {
+			return afterID, nil
+		}
+	}
+}
+
+// build 根据事件记录读取相关资产、订单或品牌的当前状态
+func (s *EventStreamService) build(record model.ChainEvent) (*events.Event, error) {
+	ev := &events.Event{
+		ID:       record.ID,
+		Type:     record.EventName,
+		AssetID:  record.AssetID,
+		OrderID:  record.OrderID,
+		TxHash:   record.TxHash,
+		BlockNum: record.BlockNum,
+	}
+
+	switch {
+	case record.OrderID != 0:
+		order, err := s.orderRepo.FindByID(record.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			ev.AssetID = order.AssetID
+			ev.Participants = []model.Address{order.Seller, order.Buyer}
+			ev.Data = order
+
+			// 订单事件带上资产的品牌，品牌方可以订阅自己商品的交易
+			asset, err := s.assetRepo.FindByID(order.AssetID)
+			if err != nil {
+				return nil, err
+			}
+			if asset != nil {
+				ev.Brand = asset.Brand
+			}
+		}
+
+	case record.AssetID != 0:
+		asset, err := s.assetRepo.FindByID(record.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		if asset != nil {
+			ev.Brand = asset.Brand
+			ev.Owners = []model.Address{asset.Owner}
+			ev.Data = asset
+		}
+		// 注册和转移按该日志的所有权记录给出当时的原所有者和新所有者
+		if record.EventName == "AssetRegistered" || record.EventName == "AssetTransferred" {
+			history, err := s.historyRepo.FindByLog(record.TxHash, record.LogIndex)
+			if err != nil {
+				return nil, err
+			}
+			if history != nil {
+				ev.Owners = []model.Address{history.Owner}
+				if history.FromAddress != "" && history.FromAddress != zeroAddress {
+					ev.Owners = append(ev.Owners, history.FromAddress)
+				}
+			}
+		}
+
+	case record.Address != "":
+		ev.Brand = record.Address
+		brand, err := s.brandRepo.FindByAddress(record.Address)
+		if err != nil {
+			return nil, err
+		}
+		if brand != nil {
+			ev.Data = brand
+		}
+	}
+	return ev, nil
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/repository"
+	"context"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type HistoryService struct {
@@ -16,13 +20,26 @@ func NewHistoryService() *HistoryService {
 	}
 }
 
-func (s *HistoryService) CreateHistory(assetID uint64, owner, txHash string, blockNum uint64) error {
+// WithTx 返回绑定到指定事务的服务
+func (s *HistoryService) WithTx(tx *gorm.DB) *HistoryService {
+	return &HistoryService{repo: s.repo.WithTx(tx)}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *HistoryService) WithContext(ctx context.Context) *HistoryService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// CreateHistory 记录一次所有权变更，timestamp 为事件所在区块的时间
+func (s *HistoryService) CreateHistory(assetID uint64, from, owner model.Address, txHash string, blockNum uint64, logIndex uint, timestamp time.Time) error {
 	history := &model.AssetOwnerHistory{
-		AssetID:   assetID,
-		Owner:     owner,
-		Timestamp: time.Now(),
-		TxHash:    txHash,
-		BlockNum:  blockNum,
+		AssetID:     assetID,
+		FromAddress: from,
+		Owner:       owner,
+		Timestamp:   timestamp,
+		TxHash:      txHash,
+		BlockNum:    blockNum,
+		LogIndex:    logIndex,
 	}
 	return s.repo.Create(history)
 }
@@ -31,8 +48,11 @@ func (s *HistoryService) GetHistoryByAsset(assetID uint64) ([]model.AssetOwnerHi
 	return s.repo.FindByAssetID(assetID)
 }
 
-func (s *HistoryService) GetHistoryByOwner(owner string, limit, offset int) ([]model.AssetOwnerHistory, error) {
-	return s.repo.FindByOwner(owner, limit, offset)
+func (s *HistoryService) GetHistoryByOwner(owner model.Address, page pagination.Params) (*pagination.Page[model.AssetOwnerHistory], error) {
+	return s.repo.FindByOwner(owner, page)
 }
 
-
+// DeleteHistoryAfter 删除 blockNum 之后产生的所有权记录
+func (s *HistoryService) DeleteHistoryAfter(blockNum uint64) error {
+	return s.repo.DeleteAfterBlock(blockNum)
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"chain-vault-backend/internal/imaging"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidImage 图片数据无法解析、不是支持的格式或超过大小限制
+// 超过大小限制时同时满足 errors.Is(err, imaging.ErrTooLarge)
+var ErrInvalidImage = errors.New("invalid image")
+
+var (
+	imageOptionsMu sync.RWMutex
+	imageOptions   = imaging.DefaultOptions()
+	maxAssetImages = 10 // 单个资产最多的图片数
+)
+
+// SetImageOptions 设置图片大小限制
+func SetImageOptions(opts imaging.Options) {
+	imageOptionsMu.Lock()
+	defer imageOptionsMu.Unlock()
+	imageOptions = opts
+}
+
+// SetMaxAssetImages 设置单个资产最多的图片数
+func SetMaxAssetImages(n int) {
+	imageOptionsMu.Lock()
+	defer imageOptionsMu.Unlock()
+	maxAssetImages = n
+}
+
+// MaxAssetImages 单个资产最多的图片数
+func MaxAssetImages() int {
+	imageOptionsMu.RLock()
+	defer imageOptionsMu.RUnlock()
+	return maxAssetImages
+}
+
+// MaxImagesRequestBytes 一次提交全部图片（base64 编码）时请求体的大致上限
+func MaxImagesRequestBytes() int64 {
+	return int64(MaxAssetImages()) * (int64(ImageOptions().MaxBytes)*4/3 + 1024)
+}
+
+// ImageOptions 当前的图片大小限制
+func ImageOptions() imaging.Options {
+	imageOptionsMu.RLock()
+	defer imageOptionsMu.RUnlock()
+	return imageOptions
+}
+
+// ImageContent 读取到的图片内容
+type ImageContent struct {
+	SourceHash string // 原图的哈希
+	Hash       string // 返回内容的哈希，缩放版本与原图不同
+	MimeType   string
+	Data       []byte
+}
+
+type ImageService struct {
+	repo *repository.ImageRepository
+}
+
+func NewImageService() *ImageService {
+	return &ImageService{
+		repo: repository.NewImageRepository(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *ImageService) WithTx(tx *gorm.DB) *ImageService {
+	return &ImageService{repo: s.repo.WithTx(tx)}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *ImageService) WithContext(ctx context.Context) *ImageService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// StoreDataURIs 将 base64 data URL 解码、处理后写入 blob 存储，返回尚未关联资产的图片记录
+func (s *ImageService) StoreDataURIs(dataURIs []string) ([]model.AssetImage, error) {
+	if limit := MaxAssetImages(); len(dataURIs) > limit {
+		return nil, fmt.Errorf("%w: at most %d images per asset", ErrInvalidImage, limit)
+	}
+	store, err := getBlobStore("")
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]model.AssetImage, 0, len(dataURIs))
+	for i, uri := range dataURIs {
+		data, err := decodeDataURI(uri)
+		if err != nil {
+			return nil, fmt.Errorf("%w: image %d: %v", ErrInvalidImage, i, err)
+		}
+		img, err := s.store(store, data)
+		if err != nil {
+			return nil, fmt.Errorf("image %d: %w", i, err)
+		}
+		images = append(images, *img)
+	}
+	return images, nil
+}
+
+// ReplaceImages 保存图片并替换资产原有的全部图片
+func (s *ImageService) ReplaceImages(assetID uint64, dataURIs []string) ([]model.AssetImage, error) {
+	images, err := s.StoreDataURIs(dataURIs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceForAsset(assetID, images); err != nil {
+		return nil, err
+	}
+	slog.Info("stored asset images", "asset_id", assetID, "images", len(images))
+	return s.repo.FindByAssetID(assetID)
+}
+
+// AttachImages 将已写入 blob 存储的图片关联到资产，替换原有图片
+func (s *ImageService) AttachImages(assetID uint64, images []model.AssetImage) error {
+	return s.repo.ReplaceForAsset(assetID, images)
+}
+
+// SanitizeImage 校验上传的图片并去除 GPS 信息，返回处理后的数据和类型
+func (s *ImageService) SanitizeImage(data []byte) ([]byte, string, error) {
+	img, err := imaging.Sanitize(data, ImageOptions())
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	return img.Data, img.MimeType, nil
+}
+
+// GetImage 返回资产第 position 张图片的内容，不存在时返回 nil
+// variant 不为空时返回对应的缩放版本，缩放版本不存在（如处理前上传的 GIF）时返回原图
+func (s *ImageService) GetImage(assetID uint64, position int, variant string) (*ImageContent, error) {
+	img, err := s.repo.FindByPosition(assetID, position)
+	if err != nil || img == nil {
+		return nil, err
+	}
+
+	content := &ImageContent{SourceHash: img.Hash, Hash: img.Hash, MimeType: img.MimeType}
+	storage, location := img.Storage, img.Location
+	if variant != "" {
+		found, err := s.repo.FindVariant(img.Hash, variant)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			content.Hash, content.MimeType = found.Hash, found.MimeType
+			storage, location = found.Storage, found.Location
+		}
+	}
+
+	store, err := getBlobStore(storage)
+	if err != nil {
+		return nil, err
+	}
+	content.Data, err = store.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s blob %s: %w", storage, location, err)
+	}
+	return content, nil
+}
+
+// DeleteImages 删除资产的图片记录
+func (s *ImageService) DeleteImages(assetIDs []uint64) error {
+	return s.repo.DeleteByAssetIDs(assetIDs)
+}
+
+// MoveLegacyImages 将 assets.images 中的旧 base64 图片迁移到 blob 存储，返回迁移的资产数
+// 无法解析的数据会被丢弃并记录日志
+func (s *ImageService) MoveLegacyImages() (int, error) {
+	moved := 0
+	for {
+		rows, err := s.repo.FindLegacyImages(50)
+		if err != nil {
+			return moved, err
+		}
+		if len(rows) == 0 {
+			return moved, nil
+		}
+
+		for _, row := range rows {
+			var uris []string
+			if err := json.Unmarshal([]byte(row.Images), &uris); err != nil {
+				slog.Warn("dropping unparseable legacy images", "asset_id", row.ID, "error", err)
+			} else {
+				images, err := s.storeLegacy(row.ID, uris)
+				if err != nil {
+					return moved, err
+				}
+				if len(images) > 0 {
+					if err := s.repo.ReplaceForAsset(row.ID, images); err != nil {
+						return moved, err
+					}
+				}
+			}
+			if err := s.repo.ClearLegacyImages(row.ID); err != nil {
+				return moved, err
+			}
+			moved++
+		}
+	}
+}
+
+// storeLegacy 写入单个资产的旧图片，跳过无法解析的图片；只有存储错误才返回 error
+func (s *ImageService) storeLegacy(assetID uint64, uris []string) ([]model.AssetImage, error) {
+	store, err := getBlobStore("")
+	if err != nil {
+		return nil, err
+	}
+	images := make([]model.AssetImage, 0, len(uris))
+	for i, uri := range uris {
+		data, err := decodeDataURI(uri)
+		if err == nil {
+			var img *model.AssetImage
+			img, err = s.store(store, data)
+			if err == nil {
+				images = append(images, *img)
+				continue
+			}
+			if !errors.Is(err, ErrInvalidImage) {
+				return nil, err
+			}
+		}
+		slog.Warn("dropping legacy image", "asset_id", assetID, "image", i, "error", err)
+	}
+	return images, nil
+}
+
+// ReprocessImages 处理图片流水线上线前保存的图片：去除 GPS 信息、生成缩放版本和感知哈希，返回处理的图片数
+// 无法处理的图片保持原样并记录日志
+func (s *ImageService) ReprocessImages() (int, error) {
+	store, err := getBlobStore("")
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	mimeTypes := []string{imaging.MimeJPEG, imaging.MimePNG, imaging.MimeWebP}
+	var afterID uint64
+	for {
+		images, err := s.repo.FindUnprocessed(afterID, mimeTypes, 50)
+		if err != nil {
+			return processed, err
+		}
+		if len(images) == 0 {
+			return processed, nil
+		}
+
+		for _, old := range images {
+			afterID = old.ID
+			source, err := getBlobStore(old.Storage)
+			if err != nil {
+				return processed, err
+			}
+			data, err := source.Get(old.Location)
+			if err != nil {
+				slog.Warn("skipping image", "asset_id", old.AssetID, "image_id", old.ID, "error", err)
+				continue
+			}
+			img, err := s.store(store, data)
+			if errors.Is(err, ErrInvalidImage) {
+				slog.Warn("skipping image", "asset_id", old.AssetID, "image_id", old.ID, "error", err)
+				continue
+			}
+			if err != nil {
+				return processed, err
+			}
+			img.ID = old.ID
+			if err := s.repo.UpdateImage(img); err != nil {
+				return processed, err
+			}
+			processed++
+		}
+	}
+}
+
+// IndexPHashes 为分段索引上线前生成的感知哈希建立索引，返回建立索引的哈希数
+func (s *ImageService) IndexPHashes() (int, error) {
+	indexed := 0
+	for {
+		phashes, err := s.repo.FindUnindexedPHashes(200)
+		if err != nil {
+			return indexed, err
+		}
+		if len(phashes) == 0 {
+			return indexed, nil
+		}
+		for _, phash := range phashes {
+			hash, err := imaging.ParsePHash(phash)
+			if err != nil {
+				return indexed, err
+			}
+			if err := s.repo.IndexPHash(phash, phashBands(hash)); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+	}
+}
+
+// phashBands 感知哈希的全部分段
+func phashBands(hash uint64) []int {
+	bands := imaging.Bands(hash)
+	return bands[:]
+}
+
+// store 处理图片并将原图和缩放版本写入 blob 存储
+func (s *ImageService) store(store BlobStore, data []byte) (*model.AssetImage, error) {
+	result, err := imaging.Process(data, ImageOptions())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+
+	hash, location, err := putBlob(store, result.Data, result.MimeType)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range result.Variants {
+		variantHash, variantLocation, err := putBlob(store, v.Data, v.MimeType)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.SaveVariant(&model.ImageVariant{
+			SourceHash: hash,
+			Variant:    v.Name,
+			Hash:       variantHash,
+			MimeType:   v.MimeType,
+			Width:      v.Width,
+			Height:     v.Height,
+			Size:       int64(len(v.Data)),
+			Storage:    store.Name(),
+			Location:   variantLocation,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	phash := imaging.FormatPHash(result.PHash)
+	if err := s.repo.IndexPHash(phash, phashBands(result.PHash)); err != nil {
+		return nil, err
+	}
+
+	return &model.AssetImage{
+		Hash:     hash,
+		MimeType: result.MimeType,
+		Width:    result.Width,
+		Height:   result.Height,
+		Size:     int64(len(result.Data)),
+		Storage:  store.Name(),
+		Location: location,
+		PHash:    phash,
+	}, nil
+}
+
+// putBlob 按 SHA-256 写入 blob 存储，返回哈希和位置
+func putBlob(store BlobStore, data []byte, mimeType string) (string, string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	location, err := store.Put(data, hash, mimeType)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store image in %s: %w", store.Name(), err)
+	}
+	return hash, location, nil
+}
+
+// decodeDataURI 解码 data:<mime>;base64,<data> 格式的图片
+func decodeDataURI(uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		return nil, errors.New("not a data URL")
+	}
+	header, payload, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok || !strings.HasSuffix(header, ";base64") {
+		return nil, errors.New("data URL is not base64 encoded")
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty image")
+	}
+	return data, nil
+}
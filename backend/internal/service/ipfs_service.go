@@ -23,12 +23,16 @@ package service
 
 import (
 	"bytes"
+	"chain-vault-backend/internal/imaging"
+	"chain-vault-backend/internal/metrics"
+	"chain-vault-backend/internal/model"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
+	"sync"
+	"time"
 )
 
 // IPFSService IPFS 服务结构体
@@ -78,7 +82,7 @@ type ProductInfo struct {
 // 存储商品的照片、视频等多媒体文件
 type MediaInfo struct {
 	Images      []string `json:"images"`      // 照片列表（IPFS 链接）
-	Thumbnail   string   `json:"thumbnail,omitempty"`   // 缩略图（第一张照片）
+	Thumbnail   string   `json:"thumbnail,omitempty"`   // 缩略图（IPFS 链接，由第一张照片缩放生成）
 	Video       string   `json:"video,omitempty"`       // 视频（IPFS 链接）
 	Documents   []string `json:"documents,omitempty"`   // 文档列表（如鉴定证书PDF）
 }
@@ -116,31 +120,45 @@ type IPFSUploadResponse struct {
 
 // ==================== 服务初始化 ====================
 
+// IPFSOptions IPFS 节点参数
+type IPFSOptions struct {
+	APIURL  string        // IPFS API 地址，如 http://localhost:5001/api/v0
+	Timeout time.Duration // 单次请求的超时时间
+}
+
+var (
+	ipfsOptionsMu sync.RWMutex
+	// 默认使用本地 IPFS 节点，确保本地运行了 IPFS daemon
+	ipfsOptions = IPFSOptions{APIURL: "http://localhost:5001/api/v0", Timeout: 60 * time.Second}
+)
+
+// SetIPFSOptions 设置 IPFS 节点地址和请求超时
+func SetIPFSOptions(opts IPFSOptions) {
+	ipfsOptionsMu.Lock()
+	defer ipfsOptionsMu.Unlock()
+	ipfsOptions = opts
+}
+
+func getIPFSOptions() IPFSOptions {
+	ipfsOptionsMu.RLock()
+	defer ipfsOptionsMu.RUnlock()
+	return ipfsOptions
+}
+
 // NewIPFSService 创建 IPFS 服务实例
 // 
 // 功能说明：
-// 1. 从环境变量读取 IPFS API 地址
-// 2. 如果未设置，使用默认的本地节点地址
-// 3. 创建 HTTP 客户端
-// 
-// 环境变量：
-// - IPFS_API_URL: IPFS API 地址（可选）
-//   默认值：http://localhost:5001/api/v0
+// 1. 使用 SetIPFSOptions 设置的 IPFS API 地址（配置项 ipfs.api_url / IPFS_API_URL）
+// 2. 如果未设置，使用默认的本地节点地址 http://localhost:5001/api/v0
+// 3. 创建带超时的 HTTP 客户端（配置项 ipfs.timeout，默认 60 秒）
 // 
 // 返回值：
 // - IPFSService 实例，可用于上传和获取文件
 func NewIPFSService() *IPFSService {
-	// 从环境变量读取 IPFS API 地址
-	apiURL := os.Getenv("IPFS_API_URL")
-	if apiURL == "" {
-		// 默认使用本地 IPFS 节点
-		// 确保本地运行了 IPFS daemon
-		apiURL = "http://localhost:5001/api/v0"
-	}
-
+	opts := getIPFSOptions()
 	return &IPFSService{
-		apiURL: apiURL,
-		client: &http.Client{},
+		apiURL: opts.APIURL,
+		client: &http.Client{Timeout: opts.Timeout},
 	}
 }
 
@@ -168,7 +186,9 @@ func NewIPFSService() *IPFSService {
 // }
 // fmt.Println("IPFS 哈希:", hash)
 // fmt.Println("访问链接:", "https://ipfs.io/ipfs/" + hash)
-func (s *IPFSService) UploadFile(fileData []byte, fileName string) (string, error) {
+func (s *IPFSService) UploadFile(fileData []byte, fileName string) (hash string, err error) {
+	defer metrics.ObserveIPFS("add", time.Now(), &err)
+
 	// 创建 multipart form
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -235,7 +255,9 @@ func (s *IPFSService) UploadMetadata(metadata *AssetMetadata) (string, error) {
 }
 
 // GetFile 从 IPFS 获取文件
-func (s *IPFSService) GetFile(hash string) ([]byte, error) {
+func (s *IPFSService) GetFile(hash string) (data []byte, err error) {
+	defer metrics.ObserveIPFS("cat", time.Now(), &err)
+
 	// 移除 ipfs:// 前缀
 	if len(hash) > 7 && hash[:7] == "ipfs://" {
 		hash = hash[7:]
@@ -258,7 +280,7 @@ func (s *IPFSService) GetFile(hash string) ([]byte, error) {
 	}
 
 	// 读取文件内容
-	data, err := io.ReadAll(resp.Body)
+	data, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
@@ -282,7 +304,9 @@ func (s *IPFSService) GetMetadata(uri string) (*AssetMetadata, error) {
 }
 
 // PinFile 固定文件到 IPFS（防止被垃圾回收）
-func (s *IPFSService) PinFile(hash string) error {
+func (s *IPFSService) PinFile(hash string) (err error) {
+	defer metrics.ObserveIPFS("pin", time.Now(), &err)
+
 	// 移除 ipfs:// 前缀
 	if len(hash) > 7 && hash[:7] == "ipfs://" {
 		hash = hash[7:]
@@ -306,6 +330,24 @@ func (s *IPFSService) PinFile(hash string) error {
 	return nil
 }
 
+// uploadThumbnail 下载 IPFS 上的图片，生成缩略图并上传，返回缩略图的哈希
+func (s *IPFSService) uploadThumbnail(imageHash string) (string, error) {
+	data, err := s.GetFile(imageHash)
+	if err != nil {
+		return "", err
+	}
+	result, err := imaging.Process(data, ImageOptions())
+	if err != nil {
+		return "", err
+	}
+	for _, variant := range result.Variants {
+		if variant.Name == model.VariantThumbnail {
+			return s.UploadFile(variant.Data, "thumbnail.webp")
+		}
+	}
+	return "", fmt.Errorf("no thumbnail generated")
+}
+
 // GenerateMetadataURI 生成完整的元数据并上传到 IPFS
 func (s *IPFSService) GenerateMetadataURI(
 	name, description, serialNumber string,
@@ -337,9 +379,12 @@ func (s *IPFSService) GenerateMetadataURI(
 		metadata.Media.Images[i] = fmt.Sprintf("ipfs://%s", hash)
 	}
 
-	// 设置缩略图（第一张图片）
+	// 设置缩略图：取第一张图片的缩放版本，无法生成时使用原图
 	if len(imageHashes) > 0 {
 		metadata.Media.Thumbnail = metadata.Media.Images[0]
+		if thumbnailHash, err := s.uploadThumbnail(imageHashes[0]); err == nil {
+			metadata.Media.Thumbnail = fmt.Sprintf("ipfs://%s", thumbnailHash)
+		}
 	}
 
 	// 上传元数据
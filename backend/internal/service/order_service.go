@@ -2,10 +2,39 @@ package service
 
 import (
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/repository"
+	"context"
+	"sync"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderOptions 订单参数，退货期需与合约保持一致
+type OrderOptions struct {
+	PaidRefundWindow      time.Duration // 支付后的退货期
+	DeliveredRefundWindow time.Duration // 收货后的退货期
+}
+
+var (
+	orderOptionsMu sync.RWMutex
+	orderOptions   = OrderOptions{PaidRefundWindow: 7 * 24 * time.Hour, DeliveredRefundWindow: 3 * 24 * time.Hour}
 )
 
+// SetOrderOptions 设置订单的退货期
+func SetOrderOptions(opts OrderOptions) {
+	orderOptionsMu.Lock()
+	defer orderOptionsMu.Unlock()
+	orderOptions = opts
+}
+
+func getOrderOptions() OrderOptions {
+	orderOptionsMu.RLock()
+	defer orderOptionsMu.RUnlock()
+	return orderOptions
+}
+
 type OrderService struct {
 	repo *repository.OrderRepository
 }
@@ -16,8 +45,18 @@ func NewOrderService() *OrderService {
 	}
 }
 
-func (s *OrderService) CreateOrder(orderID, assetID uint64, seller, buyer, price, txHash string, blockNum uint64, status model.OrderStatus) error {
-	now := time.Now()
+// WithTx 返回绑定到指定事务的服务
+func (s *OrderService) WithTx(tx *gorm.DB) *OrderService {
+	return &OrderService{repo: s.repo.WithTx(tx)}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *OrderService) WithContext(ctx context.Context) *OrderService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// CreateOrder 创建订单，createdAt 应为事件所在区块的时间
+func (s *OrderService) CreateOrder(orderID, assetID uint64, seller, buyer model.Address, price model.Wei, txHash string, blockNum uint64, status model.OrderStatus, createdAt time.Time) error {
 	order := &model.Order{
 		ID:             orderID,
 		AssetID:        assetID,
@@ -25,15 +64,15 @@ func (s *OrderService) CreateOrder(orderID, assetID uint64, seller, buyer, price
 		Buyer:          buyer,
 		Price:          price,
 		Status:         status,
-		OrderCreatedAt: now,
+		OrderCreatedAt: createdAt,
 		TxHash:         txHash,
 		BlockNum:       blockNum,
 	}
 	
 	// 根据状态设置时间戳
 	if status == model.OrderPaid {
-		order.PaidAt = &now
-		refundDeadline := now.Add(7 * 24 * time.Hour)
+		order.PaidAt = &createdAt
+		refundDeadline := createdAt.Add(getOrderOptions().PaidRefundWindow)
 		order.RefundDeadline = &refundDeadline
 	}
 	
@@ -44,26 +83,53 @@ func (s *OrderService) GetOrder(id uint64) (*model.Order, error) {
 	return s.repo.FindByID(id)
 }
 
-func (s *OrderService) GetOrdersByAsset(assetID uint64) ([]model.Order, error) {
-	return s.repo.FindByAssetID(assetID)
+func (s *OrderService) GetOrdersByAsset(assetID uint64, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return s.repo.FindByAssetID(assetID, page)
 }
 
-func (s *OrderService) GetOrdersByBuyer(buyer string, limit, offset int) ([]model.Order, error) {
-	return s.repo.FindByBuyer(buyer, limit, offset)
+func (s *OrderService) GetOrdersByBuyer(buyer model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return s.repo.FindByBuyer(buyer, page)
 }
 
-func (s *OrderService) GetOrdersBySeller(seller string, limit, offset int) ([]model.Order, error) {
-	return s.repo.FindBySeller(seller, limit, offset)
+func (s *OrderService) GetOrdersBySeller(seller model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return s.repo.FindBySeller(seller, page)
 }
 
-func (s *OrderService) GetOrdersByUser(user string, limit, offset int) ([]model.Order, error) {
-	return s.repo.FindByUser(user, limit, offset)
+func (s *OrderService) GetOrdersByUser(user model.Address, page pagination.Params) (*pagination.Page[model.Order], error) {
+	return s.repo.FindByUser(user, page)
 }
 
 func (s *OrderService) UpdateOrderStatus(orderID uint64, status model.OrderStatus) error {
 	return s.repo.UpdateStatus(orderID, status)
 }
 
+// AdvanceOrderStatus 推进订单状态，并按合约逻辑记录对应的时间戳
+// at 为事件所在区块的时间
+func (s *OrderService) AdvanceOrderStatus(orderID uint64, status model.OrderStatus, at time.Time) error {
+	updates := map[string]interface{}{
+		"status": status,
+	}
+
+	switch status {
+	case model.OrderPaid:
+		// 支付后的退货期，默认 7 天
+		updates["paid_at"] = at
+		updates["refund_deadline"] = at.Add(getOrderOptions().PaidRefundWindow)
+	case model.OrderShipped:
+		updates["shipped_at"] = at
+	case model.OrderDelivered:
+		// 收货后的退货期，默认 3 天
+		updates["delivered_at"] = at
+		updates["refund_deadline"] = at.Add(getOrderOptions().DeliveredRefundWindow)
+	case model.OrderCompleted, model.OrderRefunded:
+		// 合约在完成和退款时都会写入 completedAt 并关闭退款
+		updates["completed_at"] = at
+		updates["can_refund"] = false
+	}
+
+	return s.repo.UpdateFields(orderID, updates)
+}
+
 func (s *OrderService) GetTotalCount() (int64, error) {
 	return s.repo.Count()
 }
@@ -72,4 +138,21 @@ func (s *OrderService) GetCountByStatus(status model.OrderStatus) (int64, error)
 	return s.repo.CountByStatus(status)
 }
 
+// RestoreOrderState 按链上状态恢复订单（链重组回滚时使用）
+// 时间戳为 nil 表示链上尚未发生对应状态
+func (s *OrderService) RestoreOrderState(orderID uint64, status model.OrderStatus, paidAt, shippedAt, deliveredAt, completedAt, refundDeadline *time.Time, canRefund bool) error {
+	return s.repo.UpdateFields(orderID, map[string]interface{}{
+		"status":          status,
+		"paid_at":         paidAt,
+		"shipped_at":      shippedAt,
+		"delivered_at":    deliveredAt,
+		"completed_at":    completedAt,
+		"refund_deadline": refundDeadline,
+		"can_refund":      canRefund,
+	})
+}
 
+// DeleteOrders 删除孤块中创建的订单
+func (s *OrderService) DeleteOrders(ids []uint64) error {
+	return s.repo.DeleteByIDs(ids)
+}
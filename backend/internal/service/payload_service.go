@@ -0,0 +1,210 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPayload 延迟数据的类型或内容不合法
+var ErrInvalidPayload = errors.New("invalid payload")
+
+type PayloadService struct {
+	repo         *repository.PayloadRepository
+	syncRepo     *repository.SyncRepository
+	assetRepo    *repository.AssetRepository
+	imageService *ImageService
+	assetService *AssetService
+}
+
+func NewPayloadService() *PayloadService {
+	return &PayloadService{
+		repo:         repository.NewPayloadRepository(),
+		syncRepo:     repository.NewSyncRepository(),
+		assetRepo:    repository.NewAssetRepository(),
+		imageService: NewImageService(),
+		assetService: NewAssetService(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *PayloadService) WithTx(tx *gorm.DB) *PayloadService {
+	return &PayloadService{
+		repo:         s.repo.WithTx(tx),
+		syncRepo:     s.syncRepo.WithTx(tx),
+		assetRepo:    s.assetRepo.WithTx(tx),
+		imageService: s.imageService.WithTx(tx),
+		assetService: s.assetService.WithTx(tx),
+	}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *PayloadService) WithContext(ctx context.Context) *PayloadService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// SubmitPayload 保存依附于交易的延迟数据
+// 交易已被索引时立即写入，否则等待监听器索引该交易后写入；写入时校验 submitter 是记录的所有者
+func (s *PayloadService) SubmitPayload(txHash, kind string, submitter model.Address, data json.RawMessage) (*model.PendingPayload, error) {
+	normalized, err := s.normalize(kind, data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &model.PendingPayload{
+		TxHash:    txHash,
+		Kind:      kind,
+		Submitter: submitter,
+		Data:      normalized,
+		Status:    model.PayloadPending,
+	}
+	if err := s.repo.Create(payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ApplyReady(); err != nil {
+		return nil, err
+	}
+
+	payloads, err := s.repo.FindByTxHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	for i := range payloads {
+		if payloads[i].ID == payload.ID {
+			return &payloads[i], nil
+		}
+	}
+	return payload, nil
+}
+
+// GetPayloadsByTx 返回交易上的所有延迟数据
+func (s *PayloadService) GetPayloadsByTx(txHash string) ([]model.PendingPayload, error) {
+	return s.repo.FindByTxHash(txHash)
+}
+
+// ApplyReady 写入所有对应交易已被索引的延迟数据，返回处理的条数
+// 单条数据无法写入时标记为失败，不影响其他数据；只有数据库错误才返回 error
+func (s *PayloadService) ApplyReady() (int, error) {
+	payloads, err := s.repo.FindReady()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, payload := range payloads {
+		status, errMsg := model.PayloadApplied, ""
+		var appliedAt *time.Time
+		if err := s.apply(&payload); err != nil {
+			status, errMsg = model.PayloadFailed, err.Error()
+			slog.Error("failed to apply payload", "payload_id", payload.ID, "kind", payload.Kind, "tx", payload.TxHash, "error", err)
+		} else {
+			now := time.Now()
+			appliedAt = &now
+			slog.Info("applied payload", "payload_id", payload.ID, "kind", payload.Kind, "tx", payload.TxHash)
+		}
+		if err := s.repo.UpdateStatus(payload.ID, status, errMsg, appliedAt); err != nil {
+			return 0, err
+		}
+	}
+	return len(payloads), nil
+}
+
+// ResetPayloads 将指定交易的延迟数据恢复为待处理，交易被重新打包后再次写入
+func (s *PayloadService) ResetPayloads(txHashes []string) error {
+	return s.repo.ResetByTxHashes(txHashes)
+}
+
+// apply 按类型写入一条延迟数据
+func (s *PayloadService) apply(payload *model.PendingPayload) error {
+	switch payload.Kind {
+	case model.PayloadAssetImages:
+		events, err := s.syncRepo.FindEventsByTxHash(payload.TxHash)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.EventName != "AssetRegistered" {
+				continue
+			}
+			asset, err := s.assetRepo.FindByID(event.AssetID)
+			if err != nil {
+				return err
+			}
+			if asset == nil {
+				return fmt.Errorf("asset %d not found", event.AssetID)
+			}
+			if asset.Owner != payload.Submitter {
+				return fmt.Errorf("submitter %s does not own asset %d", payload.Submitter, event.AssetID)
+			}
+			images, err := s.decodeImages(payload.Data)
+			if err != nil {
+				return err
+			}
+			if err := s.imageService.AttachImages(event.AssetID, images); err != nil {
+				return err
+			}
+			_, err = s.assetService.FlagReusedPhotos(event.AssetID)
+			return err
+		}
+		return errors.New("transaction did not register an asset")
+	default:
+		return fmt.Errorf("unsupported payload kind %q", payload.Kind)
+	}
+}
+
+// normalize 校验延迟数据并转换为存储格式
+// 图片在提交时即写入 blob 存储，延迟数据中只保存图片记录
+func (s *PayloadService) normalize(kind string, data json.RawMessage) (string, error) {
+	switch kind {
+	case model.PayloadAssetImages:
+		var uris []string
+		if err := json.Unmarshal(data, &uris); err != nil {
+			return "", fmt.Errorf("%w: %s data must be an array of strings", ErrInvalidPayload, kind)
+		}
+		filtered := make([]string, 0, len(uris))
+		for _, uri := range uris {
+			if strings.HasPrefix(uri, "data:") {
+				filtered = append(filtered, uri)
+			}
+		}
+		if len(filtered) == 0 {
+			return "", fmt.Errorf("%w: no base64 images", ErrInvalidPayload)
+		}
+		images, err := s.imageService.StoreDataURIs(filtered)
+		if errors.Is(err, ErrInvalidImage) {
+			return "", fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if err != nil {
+			return "", err
+		}
+		bytes, err := json.Marshal(images)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported kind %q", ErrInvalidPayload, kind)
+	}
+}
+
+// decodeImages 解析 asset_images 延迟数据
+// 旧版本保存的是 base64 data URL 数组，此时先写入 blob 存储
+func (s *PayloadService) decodeImages(data string) ([]model.AssetImage, error) {
+	var images []model.AssetImage
+	if err := json.Unmarshal([]byte(data), &images); err == nil {
+		return images, nil
+	}
+	var uris []string
+	if err := json.Unmarshal([]byte(data), &uris); err != nil {
+		return nil, fmt.Errorf("malformed %s payload: %w", model.PayloadAssetImages, err)
+	}
+	return s.imageService.StoreDataURIs(uris)
+}
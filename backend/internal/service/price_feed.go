@@ -0,0 +1,227 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsupportedCurrency 价格源没有该币种的价格
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// weiPerEther 1 ETH 对应的 wei
+var weiPerEther = new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// PriceFeed ETH 的法币价格来源
+type PriceFeed interface {
+	// ETHPrice 返回 1 ETH 的价格，currency 为大写的币种代码（如 USD），没有该币种时返回 ErrUnsupportedCurrency
+	ETHPrice(ctx context.Context, currency string) (*big.Rat, error)
+}
+
+// StaticPriceFeed 固定价格，用于离线开发和测试
+type StaticPriceFeed struct {
+	prices map[string]*big.Rat
+}
+
+// NewStaticPriceFeed 解析 "USD=3200.5,CNY=23000" 格式的价格列表
+func NewStaticPriceFeed(spec string) (*StaticPriceFeed, error) {
+	prices, err := parsePrices(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticPriceFeed{prices: prices}, nil
+}
+
+func (f *StaticPriceFeed) ETHPrice(ctx context.Context, currency string) (*big.Rat, error) {
+	if price, ok := f.prices[currency]; ok {
+		return price, nil
+	}
+	return nil, fmt.Errorf("%w %s", ErrUnsupportedCurrency, currency)
+}
+
+// FilePriceFeed 从 JSON 文件读取价格，如 {"USD": "3200.5", "CNY": 23000}
+// 文件修改后自动重新读取，可由定时任务写入最新价格
+type FilePriceFeed struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	prices  map[string]*big.Rat
+}
+
+func NewFilePriceFeed(path string) *FilePriceFeed {
+	return &FilePriceFeed{path: path}
+}
+
+func (f *FilePriceFeed) ETHPrice(ctx context.Context, currency string) (*big.Rat, error) {
+	prices, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	if price, ok := prices[currency]; ok {
+		return price, nil
+	}
+	return nil, fmt.Errorf("%w %s", ErrUnsupportedCurrency, currency)
+}
+
+// load 文件修改时间变化时重新读取价格
+func (f *FilePriceFeed) load() (map[string]*big.Rat, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat price file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.prices != nil && info.ModTime().Equal(f.modTime) {
+		return f.prices, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price file: %w", err)
+	}
+	var raw map[string]json.Number
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse price file: %w", err)
+	}
+	prices := make(map[string]*big.Rat, len(raw))
+	for currency, value := range raw {
+		price, ok := new(big.Rat).SetString(value.String())
+		if !ok || price.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid price %q for %s in price file", value, currency)
+		}
+		prices[strings.ToUpper(currency)] = price
+	}
+	f.prices, f.modTime = prices, info.ModTime()
+	return prices, nil
+}
+
+// parsePrices 解析 "USD=3200.5,CNY=23000" 格式的价格列表
+func parsePrices(spec string) (map[string]*big.Rat, error) {
+	prices := make(map[string]*big.Rat)
+	for _, item := range strings.Split(spec, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		currency, value, ok := strings.Cut(item, "=")
+		price, valid := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || !valid || price.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid price %q, expected CURRENCY=PRICE", item)
+		}
+		prices[strings.ToUpper(strings.TrimSpace(currency))] = price
+	}
+	return prices, nil
+}
+
+var (
+	priceFeedMu     sync.RWMutex
+	priceFeed       PriceFeed
+	defaultCurrency string
+)
+
+// SetPriceFeed 设置价格源和默认折算的币种，feed 为 nil 时不提供法币价格
+func SetPriceFeed(feed PriceFeed, currency string) {
+	priceFeedMu.Lock()
+	defer priceFeedMu.Unlock()
+	priceFeed = feed
+	defaultCurrency = strings.ToUpper(currency)
+}
+
+func getPriceFeed() (PriceFeed, string) {
+	priceFeedMu.RLock()
+	defer priceFeedMu.RUnlock()
+	return priceFeed, defaultCurrency
+}
+
+// PriceService 把 wei 金额折算为法币
+type PriceService struct{}
+
+func NewPriceService() *PriceService {
+	return &PriceService{}
+}
+
+// Enabled 是否配置了价格源
+func (s *PriceService) Enabled() bool {
+	feed, _ := getPriceFeed()
+	return feed != nil
+}
+
+// Currency 返回要折算的币种：currency 为空时使用默认币种，都为空时返回空字符串
+func (s *PriceService) Currency(currency string) string {
+	_, fallback := getPriceFeed()
+	if currency = strings.ToUpper(strings.TrimSpace(currency)); currency != "" {
+		return currency
+	}
+	return fallback
+}
+
+// Convert 按当前 ETH 价格折算金额，保留两位小数
+func (s *PriceService) Convert(ctx context.Context, amount model.Wei, currency string) (*model.FiatValue, error) {
+	feed, _ := getPriceFeed()
+	if feed == nil {
+		return nil, errors.New("price feed is not configured")
+	}
+	rate, err := feed.ETHPrice(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	value := new(big.Rat).SetInt(amount.BigInt())
+	value.Quo(value, weiPerEther).Mul(value, rate)
+	return &model.FiatValue{
+		Currency: currency,
+		Amount:   value.FloatString(2),
+		Rate:     trimDecimal(rate.FloatString(8)),
+	}, nil
+}
+
+// FillAssets 为资产填充法币价格
+// 未配置价格源或没有币种时不填充；价格源暂时不可用时记录日志并跳过，只有不支持的币种返回错误
+func (s *PriceService) FillAssets(ctx context.Context, currency string, assets []model.Asset) error {
+	return s.fill(ctx, currency, len(assets), func(i int) (model.Wei, **model.FiatValue) {
+		return assets[i].Price, &assets[i].PriceFiat
+	})
+}
+
+// FillOrders 为订单填充法币价格，规则同 FillAssets
+func (s *PriceService) FillOrders(ctx context.Context, currency string, orders []model.Order) error {
+	return s.fill(ctx, currency, len(orders), func(i int) (model.Wei, **model.FiatValue) {
+		return orders[i].Price, &orders[i].PriceFiat
+	})
+}
+
+func (s *PriceService) fill(ctx context.Context, currency string, n int, item func(int) (model.Wei, **model.FiatValue)) error {
+	currency = s.Currency(currency)
+	if !s.Enabled() || currency == "" {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		price, fiat := item(i)
+		value, err := s.Convert(ctx, price, currency)
+		if errors.Is(err, ErrUnsupportedCurrency) {
+			return err
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "failed to convert price", "currency", currency, "error", err)
+			return nil
+		}
+		*fiat = value
+	}
+	return nil
+}
+
+// trimDecimal 去除小数末尾的零
+func trimDecimal(s string) string {
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
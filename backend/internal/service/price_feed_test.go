@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chain-vault-backend/internal/model"
+)
+
+func TestParsePrices(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"", map[string]string{}, false},
+		{"USD=3200.5", map[string]string{"USD": "6401/2"}, false},
+		{" usd = 3200 , CNY=23000,", map[string]string{"USD": "3200/1", "CNY": "23000/1"}, false},
+		{"USD", nil, true},
+		{"USD=abc", nil, true},
+		{"USD=0", nil, true},
+		{"USD=-1", nil, true},
+	}
+	for _, tt := range tests {
+		prices, err := parsePrices(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePrices(%q) = %v, want error", tt.spec, prices)
+			}
+			continue
+		}
+		if err != nil || len(prices) != len(tt.want) {
+			t.Errorf("parsePrices(%q) = %v, %v, want %v", tt.spec, prices, err, tt.want)
+			continue
+		}
+		for currency, want := range tt.want {
+			if got, ok := prices[currency]; !ok || got.String() != want {
+				t.Errorf("parsePrices(%q)[%s] = %v, want %s", tt.spec, currency, got, want)
+			}
+		}
+	}
+}
+
+func TestPriceServiceConvert(t *testing.T) {
+	feed, err := NewStaticPriceFeed("USD=3200.5,JPY=512345.123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetPriceFeed(feed, "usd")
+	t.Cleanup(func() { SetPriceFeed(nil, "") })
+	s := NewPriceService()
+
+	wei := func(s string) model.Wei {
+		w, err := model.ParseWei(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+	tests := []struct {
+		amount   model.Wei
+		currency string
+		amountF  string
+		rate     string
+		wantErr  error
+	}{
+		{wei("1000000000000000000"), "USD", "3200.50", "3200.5", nil},
+		{wei("1500000000000000000"), "USD", "4800.75", "3200.5", nil},
+		{wei("0"), "USD", "0.00", "3200.5", nil},
+		{wei("1"), "USD", "0.00", "3200.5", nil},
+		{wei("2000000000000000"), "JPY", "1024.69", "512345.12345679", nil},
+		{wei("1000000000000000000"), "EUR", "", "", ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		value, err := s.Convert(context.Background(), tt.amount, tt.currency)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Convert(%s, %s) error = %v, want %v", tt.amount, tt.currency, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Convert(%s, %s): %v", tt.amount, tt.currency, err)
+			continue
+		}
+		if value.Currency != tt.currency || value.Amount != tt.amountF || value.Rate != tt.rate {
+			t.Errorf("Convert(%s, %s) = %+v, want %s at %s", tt.amount, tt.currency, value, tt.amountF, tt.rate)
+		}
+	}
+
+	if got := s.Currency(""); got != "USD" {
+		t.Errorf("default currency = %q, want USD", got)
+	}
+	if got := s.Currency(" jpy "); got != "JPY" {
+		t.Errorf("Currency(\" jpy \") = %q, want JPY", got)
+	}
+}
+
+func TestPriceServiceFillAssets(t *testing.T) {
+	t.Cleanup(func() { SetPriceFeed(nil, "") })
+	s := NewPriceService()
+	assets := []model.Asset{{Price: model.NewWei(big.NewInt(1e18))}}
+
+	// 未配置价格源时不填充
+	SetPriceFeed(nil, "USD")
+	if err := s.FillAssets(context.Background(), "", assets); err != nil || assets[0].PriceFiat != nil {
+		t.Fatalf("without feed: %v, %+v", err, assets[0].PriceFiat)
+	}
+
+	feed, _ := NewStaticPriceFeed("USD=2000")
+	SetPriceFeed(feed, "USD")
+	if err := s.FillAssets(context.Background(), "", assets); err != nil {
+		t.Fatal(err)
+	}
+	if assets[0].PriceFiat == nil || assets[0].PriceFiat.Amount != "2000.00" {
+		t.Errorf("PriceFiat = %+v, want 2000.00 USD", assets[0].PriceFiat)
+	}
+	if err := s.FillAssets(context.Background(), "EUR", assets); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("unsupported currency error = %v", err)
+	}
+
+	// 价格源暂时不可用时跳过，不返回错误
+	SetPriceFeed(NewFilePriceFeed(filepath.Join(t.TempDir(), "missing.json")), "USD")
+	fresh := []model.Asset{{Price: model.NewWei(big.NewInt(1e18))}}
+	if err := s.FillAssets(context.Background(), "", fresh); err != nil || fresh[0].PriceFiat != nil {
+		t.Errorf("unavailable feed: %v, %+v", err, fresh[0].PriceFiat)
+	}
+}
+
+func TestFilePriceFeedReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	write := func(content string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	feed := NewFilePriceFeed(path)
+	base := time.Now().Add(-time.Hour)
+
+	write(`{"usd": "3200.5", "CNY": 23000}`, base)
+	if price, err := feed.ETHPrice(context.Background(), "USD"); err != nil || price.FloatString(1) != "3200.5" {
+		t.Fatalf("USD = %v, %v", price, err)
+	}
+	if price, err := feed.ETHPrice(context.Background(), "CNY"); err != nil || price.FloatString(0) != "23000" {
+		t.Fatalf("CNY = %v, %v", price, err)
+	}
+
+	write(`{"USD": 3300}`, base.Add(time.Minute))
+	if price, err := feed.ETHPrice(context.Background(), "USD"); err != nil || price.FloatString(0) != "3300" {
+		t.Errorf("after update USD = %v, %v", price, err)
+	}
+	if _, err := feed.ETHPrice(context.Background(), "CNY"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("removed currency error = %v", err)
+	}
+
+	write(`{"USD": -1}`, base.Add(2*time.Minute))
+	if _, err := feed.ETHPrice(context.Background(), "USD"); err == nil {
+		t.Error("negative price accepted")
+	}
+}
@@ -2,9 +2,18 @@ package service
 
 import (
 	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
 	"chain-vault-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
 )
 
+// ErrReviewExists 评价人已经评价过该订单
+var ErrReviewExists = errors.New("order already reviewed")
+
 type ReputationService struct {
 	repo *repository.ReputationRepository
 }
@@ -15,13 +24,23 @@ func NewReputationService() *ReputationService {
 	}
 }
 
+// WithTx 返回绑定到指定事务的服务
+func (s *ReputationService) WithTx(tx *gorm.DB) *ReputationService {
+	return &ReputationService{repo: s.repo.WithTx(tx)}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *ReputationService) WithContext(ctx context.Context) *ReputationService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
 // GetUserReputation 获取用户信誉
-func (s *ReputationService) GetUserReputation(userAddress string) (*model.UserReputation, error) {
+func (s *ReputationService) GetUserReputation(userAddress model.Address) (*model.UserReputation, error) {
 	return s.repo.GetOrCreateReputation(userAddress)
 }
 
 // OnOrderCompleted 订单完成时更新信誉
-func (s *ReputationService) OnOrderCompleted(sellerAddress, buyerAddress string) error {
+func (s *ReputationService) OnOrderCompleted(sellerAddress, buyerAddress model.Address) error {
 	// 卖家：完成订单 +20 经验
 	if err := s.repo.AddExperience(sellerAddress, 20); err != nil {
 		return err
@@ -42,7 +61,7 @@ func (s *ReputationService) OnOrderCompleted(sellerAddress, buyerAddress string)
 }
 
 // OnOrderCancelled 订单取消时更新信誉
-func (s *ReputationService) OnOrderCancelled(userAddress string) error {
+func (s *ReputationService) OnOrderCancelled(userAddress model.Address) error {
 	reputation, err := s.repo.GetOrCreateReputation(userAddress)
 	if err != nil {
 		return err
@@ -53,7 +72,7 @@ func (s *ReputationService) OnOrderCancelled(userAddress string) error {
 }
 
 // OnOrderRefunded 订单退款时更新信誉
-func (s *ReputationService) OnOrderRefunded(sellerAddress string) error {
+func (s *ReputationService) OnOrderRefunded(sellerAddress model.Address) error {
 	// 卖家被退款：-20 经验
 	if err := s.repo.AddExperience(sellerAddress, -20); err != nil {
 		return err
@@ -68,8 +87,92 @@ func (s *ReputationService) OnOrderRefunded(sellerAddress string) error {
 	return s.repo.UpdateReputation(reputation)
 }
 
-// CreateReview 创建评价
+// ApplyOrderEvent 将监听到的订单事件计入信誉
+// 同一订单的同一类事件只计入一次，返回本次是否计入
+func (s *ReputationService) ApplyOrderEvent(event *model.ReputationEvent) (bool, error) {
+	recorded, err := s.repo.RecordEvent(event)
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	switch event.Kind {
+	case model.ReputationOrderCompleted:
+		err = s.OnOrderCompleted(event.Seller, event.Buyer)
+	case model.ReputationOrderCancelled:
+		err = s.OnOrderCancelled(event.Actor)
+	case model.ReputationOrderRefunded:
+		err = s.OnOrderRefunded(event.Seller)
+	default:
+		err = fmt.Errorf("unknown reputation event kind %q", event.Kind)
+	}
+	return err == nil, err
+}
+
+// RevertEventsAfter 撤销指定区块之后计入的订单事件（用于链重组回滚）
+func (s *ReputationService) RevertEventsAfter(blockNum uint64) error {
+	events, err := s.repo.FindEventsAfter(blockNum)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		switch event.Kind {
+		case model.ReputationOrderCompleted:
+			err = s.revertOrderCompleted(event.Seller, event.Buyer)
+		case model.ReputationOrderCancelled:
+			err = s.adjustCounters(event.Actor, func(r *model.UserReputation) { r.CancelledOrders-- })
+		case model.ReputationOrderRefunded:
+			if err = s.repo.AddExperience(event.Seller, 20); err == nil {
+				err = s.adjustCounters(event.Seller, func(r *model.UserReputation) { r.RefundedOrders-- })
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.DeleteEvent(event.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertOrderCompleted 撤销 OnOrderCompleted 的经验值和订单计数
+func (s *ReputationService) revertOrderCompleted(sellerAddress, buyerAddress model.Address) error {
+	if err := s.repo.AddExperience(sellerAddress, -20); err != nil {
+		return err
+	}
+	if err := s.repo.AdjustOrderCount(sellerAddress, "seller", true, -1); err != nil {
+		return err
+	}
+	if err := s.repo.AddExperience(buyerAddress, -10); err != nil {
+		return err
+	}
+	return s.repo.AdjustOrderCount(buyerAddress, "buyer", true, -1)
+}
+
+// adjustCounters 修改用户信誉记录中的计数
+func (s *ReputationService) adjustCounters(userAddress model.Address, update func(r *model.UserReputation)) error {
+	reputation, err := s.repo.GetOrCreateReputation(userAddress)
+	if err != nil {
+		return err
+	}
+	update(reputation)
+	return s.repo.UpdateReputation(reputation)
+}
+
+// CreateReview 创建评价，同一评价人对同一订单只能评价一次
 func (s *ReputationService) CreateReview(review *model.UserReview) error {
+	existing, err := s.repo.FindReview(review.OrderID, review.ReviewerAddress)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrReviewExists
+	}
+	if review.Tags == "" {
+		review.Tags = "[]"
+	}
+	
 	// 创建评价记录
 	if err := s.repo.CreateReview(review); err != nil {
 		return err
@@ -91,6 +194,6 @@ func (s *ReputationService) CreateReview(review *model.UserReview) error {
 }
 
 // GetUserReviews 获取用户评价列表
-func (s *ReputationService) GetUserReviews(userAddress string, role string) ([]model.UserReview, error) {
-	return s.repo.GetReviewsByUser(userAddress, role)
+func (s *ReputationService) GetUserReviews(userAddress model.Address, role string, page pagination.Params) (*pagination.Page[model.UserReview], error) {
+	return s.repo.GetReviewsByUser(userAddress, role, page)
 }
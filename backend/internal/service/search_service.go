@@ -0,0 +1,268 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidSearch 搜索参数无效
+var ErrInvalidSearch = errors.New("invalid search")
+
+var (
+	metadataAttemptsMu  sync.RWMutex
+	maxMetadataAttempts = 5 // 读取元数据失败超过该次数后不再重试
+)
+
+// SetMetadataMaxAttempts 设置读取元数据的最大失败次数，超过后不再重试
+func SetMetadataMaxAttempts(n int) {
+	metadataAttemptsMu.Lock()
+	defer metadataAttemptsMu.Unlock()
+	maxMetadataAttempts = n
+}
+
+func getMetadataMaxAttempts() int {
+	metadataAttemptsMu.RLock()
+	defer metadataAttemptsMu.RUnlock()
+	return maxMetadataAttempts
+}
+
+// statusNames 验证状态在搜索参数和分面中的名称
+var statusNames = map[model.VerificationStatus]string{
+	model.Unverified: "unverified",
+	model.Pending:    "pending",
+	model.Verified:   "verified",
+	model.Rejected:   "rejected",
+}
+
+// SearchParams 搜索参数，字符串形式，由 Search 校验
+type SearchParams struct {
+	Keyword  string
+	Brand    string // 品牌地址
+	Category string
+	Status   string // unverified / pending / verified / rejected，或对应的数字 0-3
+	Listed   string // true / false
+	MinPrice string // wei
+	MaxPrice string
+	Sort     string // relevance / newest / price_asc / price_desc
+	Page     pagination.Params
+}
+
+// SearchResult 搜索结果
+type SearchResult struct {
+	*pagination.Page[model.Asset]
+	Sort   string
+	Facets map[string][]repository.FacetCount
+}
+
+type SearchService struct {
+	repo *repository.SearchRepository
+	ipfs *IPFSService
+}
+
+func NewSearchService() *SearchService {
+	return &SearchService{
+		repo: repository.NewSearchRepository(),
+		ipfs: NewIPFSService(),
+	}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *SearchService) WithContext(ctx context.Context) *SearchService {
+	return &SearchService{repo: s.repo.WithTx(dbWithContext(ctx)), ipfs: s.ipfs}
+}
+
+// Search 搜索资产，返回当前页、总数和分面统计
+func (s *SearchService) Search(params SearchParams) (*SearchResult, error) {
+	q, err := buildSearch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.repo.Search(q)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := s.repo.Facets(q)
+	if err != nil {
+		return nil, err
+	}
+	for i := range facets[repository.FacetStatus] {
+		facet := &facets[repository.FacetStatus][i]
+		if status, err := strconv.Atoi(facet.Value); err == nil {
+			facet.Value = statusNames[model.VerificationStatus(status)]
+		}
+	}
+	for i := range facets[repository.FacetListed] {
+		facet := &facets[repository.FacetListed][i]
+		facet.Value = strconv.FormatBool(facet.Value == "1" || facet.Value == "true")
+	}
+
+	return &SearchResult{
+		Page:   page,
+		Sort:   q.Sort,
+		Facets: facets,
+	}, nil
+}
+
+// buildSearch 校验搜索参数
+func buildSearch(params SearchParams) (*repository.AssetSearch, error) {
+	q := &repository.AssetSearch{
+		Keyword:  strings.TrimSpace(params.Keyword),
+		Category: strings.TrimSpace(params.Category),
+		Sort:     params.Sort,
+		Page:     params.Page,
+	}
+
+	if params.Status != "" {
+		status, ok := parseStatus(params.Status)
+		if !ok {
+			return nil, fmt.Errorf("%w: status must be one of unverified, pending, verified, rejected", ErrInvalidSearch)
+		}
+		q.Status = &status
+	}
+	if params.Listed != "" {
+		listed, err := strconv.ParseBool(params.Listed)
+		if err != nil {
+			return nil, fmt.Errorf("%w: listed must be true or false", ErrInvalidSearch)
+		}
+		q.Listed = &listed
+	}
+
+	var err error
+	if q.Brand, err = parseAddress(params.Brand); err != nil {
+		return nil, fmt.Errorf("%w: brand: %v", ErrInvalidSearch, err)
+	}
+	if q.MinPrice, err = parseWei(params.MinPrice); err != nil {
+		return nil, fmt.Errorf("%w: minPrice %v", ErrInvalidSearch, err)
+	}
+	if q.MaxPrice, err = parseWei(params.MaxPrice); err != nil {
+		return nil, fmt.Errorf("%w: maxPrice %v", ErrInvalidSearch, err)
+	}
+
+	switch q.Sort {
+	case "":
+		q.Sort = repository.SortNewest
+		if q.Keyword != "" {
+			q.Sort = repository.SortRelevance
+		}
+	case repository.SortRelevance, repository.SortNewest, repository.SortPriceAsc, repository.SortPriceDesc:
+	default:
+		return nil, fmt.Errorf("%w: sort must be one of relevance, newest, price_asc, price_desc", ErrInvalidSearch)
+	}
+	return q, nil
+}
+
+// parseStatus 解析验证状态的名称或数字
+func parseStatus(value string) (model.VerificationStatus, bool) {
+	for status, name := range statusNames {
+		if strings.EqualFold(value, name) || value == strconv.Itoa(int(status)) {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// parseWei 解析 wei 金额参数，空字符串表示不限制
+func parseWei(value string) (*model.Wei, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := model.ParseWei(value)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
+
+// RunMetadataIndexer 定期读取新资产的元数据并写入搜索文档，直到 ctx 取消
+func (s *SearchService) RunMetadataIndexer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if indexed, err := s.IndexMetadata(50); err != nil {
+			slog.ErrorContext(ctx, "failed to index asset metadata", "error", err)
+		} else if indexed > 0 {
+			slog.InfoContext(ctx, "indexed asset metadata for search", "assets", indexed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// IndexMetadata 读取一批资产的元数据，将分类、型号、颜色、尺码写入搜索文档，返回成功的数量
+// 读取失败的资产记录失败次数，超过 SetMetadataMaxAttempts 设置的次数后不再重试
+func (s *SearchService) IndexMetadata(limit int) (int, error) {
+	pending, err := s.repo.FindPendingMetadata(getMetadataMaxAttempts(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	for _, item := range pending {
+		metadata, err := s.loadMetadata(item.MetadataURI)
+		if err != nil {
+			slog.Warn("failed to load asset metadata", "asset_id", item.AssetID, "error", err)
+			if err := s.repo.RecordMetadataFailure(item.AssetID); err != nil {
+				return indexed, err
+			}
+			continue
+		}
+		product := metadata.Product
+		if err := s.repo.SaveMetadata(item.AssetID, product.Category, product.Model, product.Color, product.Size); err != nil {
+			return indexed, err
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// loadMetadata 读取元数据：data: URI 直接解码，ipfs:// 或 CID 从 IPFS 读取
+func (s *SearchService) loadMetadata(uri string) (*AssetMetadata, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		if strings.Contains(uri, "://") && !strings.HasPrefix(uri, "ipfs://") {
+			return nil, fmt.Errorf("unsupported metadata URI %q", uri)
+		}
+		return s.ipfs.GetMetadata(uri)
+	}
+
+	header, payload, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok {
+		return nil, errors.New("malformed data URI")
+	}
+	var data []byte
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 metadata: %w", err)
+		}
+		data = decoded
+	} else {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata data URI: %w", err)
+		}
+		data = []byte(unescaped)
+	}
+
+	var metadata AssetMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return &metadata, nil
+}
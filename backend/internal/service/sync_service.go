@@ -0,0 +1,100 @@
+package service
+
+import (
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/repository"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type SyncService struct {
+	repo *repository.SyncRepository
+}
+
+func NewSyncService() *SyncService {
+	return &SyncService{
+		repo: repository.NewSyncRepository(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *SyncService) WithTx(tx *gorm.DB) *SyncService {
+	return &SyncService{repo: s.repo.WithTx(tx)}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *SyncService) WithContext(ctx context.Context) *SyncService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// GetCheckpoint 获取合约已处理的最后区块，ok=false 表示从未同步过
+func (s *SyncService) GetCheckpoint(contractAddress string) (lastBlock uint64, ok bool, err error) {
+	state, err := s.repo.FindByContract(contractAddress)
+	if err != nil || state == nil {
+		return 0, false, err
+	}
+	return state.LastBlock, true, nil
+}
+
+// SaveCheckpoint 记录合约已处理的最后区块
+func (s *SyncService) SaveCheckpoint(contractAddress string, lastBlock uint64) error {
+	return s.repo.SaveLastBlock(contractAddress, lastBlock)
+}
+
+// IsEventProcessed 检查日志是否已处理过
+func (s *SyncService) IsEventProcessed(txHash string, logIndex uint) (bool, error) {
+	return s.repo.EventExists(txHash, logIndex)
+}
+
+// RecordEvent 记录已处理的事件日志
+func (s *SyncService) RecordEvent(event *model.ChainEvent) error {
+	return s.repo.CreateEvent(event)
+}
+
+// GetEventsAfter 获取指定区块之后的事件记录
+func (s *SyncService) GetEventsAfter(contractAddress string, blockNum uint64) ([]model.ChainEvent, error) {
+	return s.repo.FindEventsAfter(contractAddress, blockNum)
+}
+
+// GetEventsByTx 返回交易产生并已入库的事件
+func (s *SyncService) GetEventsByTx(txHash string) ([]model.ChainEvent, error) {
+	return s.repo.FindEventsByTxHash(txHash)
+}
+
+// GetLatestOwnershipEvent 获取资产在指定区块（含）之前最近一次的注册或转移事件
+func (s *SyncService) GetLatestOwnershipEvent(contractAddress string, assetID uint64, maxBlock uint64) (*model.ChainEvent, error) {
+	return s.repo.FindLatestAssetEvent(contractAddress, assetID, []string{"AssetRegistered", "AssetTransferred"}, maxBlock)
+}
+
+// HasBrandRegisteredBefore 检查品牌在指定区块（含）之前是否已注册
+func (s *SyncService) HasBrandRegisteredBefore(contractAddress string, brandAddress model.Address, maxBlock uint64) (bool, error) {
+	count, err := s.repo.CountAddressEvents(contractAddress, brandAddress, "BrandRegistered", maxBlock)
+	return count > 0, err
+}
+
+// RecordBlockHash 记录已处理区块的哈希
+func (s *SyncService) RecordBlockHash(contractAddress string, blockNum uint64, blockHash string) error {
+	return s.repo.SaveBlockHash(contractAddress, blockNum, blockHash)
+}
+
+// GetRecentBlocks 获取最近记录的区块哈希（按高度倒序）
+func (s *SyncService) GetRecentBlocks(contractAddress string, limit int) ([]model.ProcessedBlock, error) {
+	return s.repo.FindRecentBlocks(contractAddress, limit)
+}
+
+// PruneBlocks 清理指定区块之前的哈希记录
+func (s *SyncService) PruneBlocks(contractAddress string, beforeBlock uint64) error {
+	return s.repo.DeleteBlocksBefore(contractAddress, beforeBlock)
+}
+
+// Rewind 将同步状态回退到 ancestor：删除之后的事件与区块记录并重置进度
+func (s *SyncService) Rewind(contractAddress string, ancestor uint64) error {
+	if err := s.repo.DeleteEventsAfter(contractAddress, ancestor); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteBlocksAfter(contractAddress, ancestor); err != nil {
+		return err
+	}
+	return s.repo.SaveLastBlock(contractAddress, ancestor)
+}
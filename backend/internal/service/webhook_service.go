@@ -0,0 +1,431 @@
+package service
+
+import (
+	"bytes"
+	"chain-vault-backend/internal/events"
+	"chain-vault-backend/internal/model"
+	"chain-vault-backend/internal/pagination"
+	"chain-vault-backend/internal/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidWebhook 订阅的回调地址、范围或事件类型不合法
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrWebhookNotFound 订阅不存在或不属于当前地址
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+const (
+	// webhookRetryBase 第一次重试的等待时间，之后每次翻倍
+	webhookRetryBase = 30 * time.Second
+	// webhookRetryMax 重试等待时间的上限
+	webhookRetryMax = 6 * time.Hour
+	// webhookBatch 每轮投递的最大记录数
+	webhookBatch = 50
+)
+
+// webhookEventTypes 可以订阅的合约事件
+var webhookEventTypes = []string{
+	"AssetRegistered", "AssetTransferred", "AssetListed", "AssetUnlisted", "AssetVerified",
+	"BrandRegistered", "BrandAuthorized",
+	"OrderCreated", "OrderPaid", "OrderShipped", "OrderDelivered", "OrderCompleted", "OrderRefunded", "OrderCancelled",
+}
+
+// WebhookOptions Webhook 投递参数
+type WebhookOptions struct {
+	MaxAttempts int           // 投递失败的最大次数，之后转为死信
+	Timeout     time.Duration // 单次请求的超时时间
+	// AllowPrivateTargets 允许回调地址为回环、私有、链路本地地址，只用于本地开发
+	AllowPrivateTargets bool
+}
+
+var (
+	webhookOptionsMu sync.RWMutex
+	webhookOptions   = WebhookOptions{MaxAttempts: 10, Timeout: 10 * time.Second}
+)
+
+// SetWebhookOptions 设置 Webhook 的重试次数、请求超时和是否允许内网回调地址
+func SetWebhookOptions(opts WebhookOptions) {
+	webhookOptionsMu.Lock()
+	defer webhookOptionsMu.Unlock()
+	webhookOptions = opts
+}
+
+func getWebhookOptions() WebhookOptions {
+	webhookOptionsMu.RLock()
+	defer webhookOptionsMu.RUnlock()
+	return webhookOptions
+}
+
+// WebhookService 管理 Webhook 订阅，把链上事件写入发件箱并投递
+type WebhookService struct {
+	repo          *repository.WebhookRepository
+	streamService *EventStreamService
+}
+
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		repo:          repository.NewWebhookRepository(),
+		streamService: NewEventStreamService(),
+	}
+}
+
+// WithTx 返回绑定到指定事务的服务
+func (s *WebhookService) WithTx(tx *gorm.DB) *WebhookService {
+	return &WebhookService{
+		repo:          s.repo.WithTx(tx),
+		streamService: s.streamService.WithTx(tx),
+	}
+}
+
+// WithContext 返回使用 ctx 的服务，SQL 日志会带上 ctx 上的请求 ID
+func (s *WebhookService) WithContext(ctx context.Context) *WebhookService {
+	return s.WithTx(dbWithContext(ctx))
+}
+
+// CreateSubscription 为 address 登记回调地址，返回的订阅带有签名密钥
+func (s *WebhookService) CreateSubscription(address model.Address, scope, rawURL string, types []string) (*model.WebhookSubscription, error) {
+	if scope != model.WebhookScopeBrand && scope != model.WebhookScopeOwner {
+		return nil, fmt.Errorf("%w: scope must be %s or %s", ErrInvalidWebhook, model.WebhookScopeBrand, model.WebhookScopeOwner)
+	}
+	if err := validateWebhook(rawURL, types); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	subscription := &model.WebhookSubscription{
+		Address: address,
+		Scope:   scope,
+		URL:     rawURL,
+		Secret:  "whsec_" + hex.EncodeToString(secret),
+		Active:  true,
+	}
+	subscription.SetTypes(types)
+	if err := s.repo.CreateSubscription(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions 查询地址登记的订阅，address 为空时返回全部
+func (s *WebhookService) ListSubscriptions(address model.Address) ([]model.WebhookSubscription, error) {
+	return s.repo.FindSubscriptionsByAddress(address)
+}
+
+// GetSubscription 查询订阅，caller 不为空时只返回属于 caller 的订阅
+func (s *WebhookService) GetSubscription(id uint64, caller model.Address) (*model.WebhookSubscription, error) {
+	subscription, err := s.repo.FindSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil || (caller != "" && subscription.Address != caller) {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription 修改回调地址、事件类型或启用状态，nil 表示不修改
+func (s *WebhookService) UpdateSubscription(subscription *model.WebhookSubscription, rawURL *string, types *[]string, active *bool) error {
+	if rawURL != nil {
+		subscription.URL = *rawURL
+	}
+	if types != nil {
+		subscription.SetTypes(*types)
+	}
+	if active != nil {
+		subscription.Active = *active
+	}
+	if err := validateWebhook(subscription.URL, subscription.Types); err != nil {
+		return err
+	}
+	return s.repo.UpdateSubscription(subscription)
+}
+
+// DeleteSubscription 删除订阅及其投递记录
+func (s *WebhookService) DeleteSubscription(id uint64) error {
+	return s.repo.DeleteSubscription(id)
+}
+
+// ListDeliveries 分页查询订阅的投递记录
+func (s *WebhookService) ListDeliveries(subscriptionID uint64, status string, page pagination.Params) (*pagination.Page[model.WebhookDelivery], error) {
+	switch status {
+	case "", model.DeliveryPending, model.DeliveryDelivered, model.DeliveryDead:
+	default:
+		return nil, fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, status)
+	}
+	return s.repo.FindDeliveriesBySubscription(subscriptionID, status, page)
+}
+
+// ReplayDeliveries 重新投递指定记录（可以是已成功的记录），ids 为空时重放该订阅的全部死信，返回重放的数量
+func (s *WebhookService) ReplayDeliveries(subscriptionID uint64, ids []uint64) (int64, error) {
+	return s.repo.ResetDeliveries(subscriptionID, ids, time.Now())
+}
+
+// Enqueue 为事件写入匹配订阅的投递记录，由监听器在写入事件的事务中调用
+func (s *WebhookService) Enqueue(records []model.ChainEvent) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	built := make([]*events.Event, 0, len(records))
+	var addresses []model.Address
+	seen := make(map[model.Address]bool)
+	for _, record := range records {
+		ev, err := s.streamService.build(record)
+		if err != nil {
+			return fmt.Errorf("failed to build event %d: %w", record.ID, err)
+		}
+		built = append(built, ev)
+		for _, address := range eventAddresses(ev) {
+			if !seen[address] {
+				seen[address] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	subscriptions, err := s.repo.FindActiveSubscriptions(addresses)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var deliveries []model.WebhookDelivery
+	for _, ev := range built {
+		var payload []byte
+		for _, subscription := range subscriptions {
+			if !webhookMatches(subscription, ev) {
+				continue
+			}
+			if payload == nil {
+				if payload, err = json.Marshal(ev); err != nil {
+					return err
+				}
+			}
+			deliveries = append(deliveries, model.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        ev.ID,
+				EventType:      ev.Type,
+				Payload:        string(payload),
+				Status:         model.DeliveryPending,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+	return s.repo.CreateDeliveries(deliveries)
+}
+
+// DiscardPending 删除孤块事件尚未投递的记录，已投递的记录保留
+func (s *WebhookService) DiscardPending(records []model.ChainEvent) error {
+	ids := make([]uint64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return s.repo.DeletePendingByEvents(ids)
+}
+
+// RunDispatcher 定期投递到期的记录，直到 ctx 取消
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if delivered, failed, err := s.DeliverDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to deliver webhooks", "error", err)
+		} else if delivered > 0 || failed > 0 {
+			slog.InfoContext(ctx, "webhooks delivered", "delivered", delivered, "failed", failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue 投递一批到期的记录，返回成功和失败的数量
+// 失败的记录按指数退避重试，达到最大次数后转为死信
+func (s *WebhookService) DeliverDue(ctx context.Context) (delivered, failed int, err error) {
+	deliveries, err := s.repo.FindDueDeliveries(time.Now(), webhookBatch)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	opts := getWebhookOptions()
+	client := &http.Client{
+		Transport: webhookTransport(opts.AllowPrivateTargets),
+		Timeout:   opts.Timeout,
+		// 重定向视为失败，避免把签名后的内容发到订阅以外的地址
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	subscriptions := make(map[uint64]*model.WebhookSubscription)
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		delivery := &deliveries[i]
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			if subscription, err = s.repo.FindSubscription(delivery.SubscriptionID); err != nil {
+				return delivered, failed, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		now := time.Now()
+		delivery.Attempts++
+		if subscription == nil || !subscription.Active {
+			// 停用期间的投递直接转为死信，重新启用后可以重放
+			delivery.Status = model.DeliveryDead
+			delivery.LastStatusCode = 0
+			delivery.LastError = "subscription is disabled"
+		} else if code, err := s.send(ctx, client, subscription, delivery, now); err != nil {
+			delivery.LastStatusCode = code
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= opts.MaxAttempts {
+				delivery.Status = model.DeliveryDead
+			} else {
+				delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+			}
+		} else {
+			delivery.Status = model.DeliveryDelivered
+			delivery.LastStatusCode = code
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		}
+
+		if delivery.Status == model.DeliveryDelivered {
+			delivered++
+		} else {
+			failed++
+		}
+		if err := s.repo.UpdateDelivery(delivery); err != nil {
+			return delivered, failed, err
+		}
+	}
+	return delivered, failed, nil
+}
+
+// send 发送一次请求，返回响应状态码；非 2xx 视为失败
+// 响应内容不记录到投递记录中，避免订阅方借投递记录读取回调地址返回的内容
+func (s *WebhookService) send(ctx context.Context, client *http.Client, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ChainVault-Webhook/1.0")
+	req.Header.Set("X-ChainVault-Event", delivery.EventType)
+	req.Header.Set("X-ChainVault-Delivery", strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set("X-ChainVault-Signature", fmt.Sprintf("t=%d,v1=%s", timestamp, SignWebhook(subscription.Secret, timestamp, body)))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// 读完（最多 64KB）以便复用连接
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// SignWebhook 计算签名：HMAC-SHA256(secret, "<timestamp>.<body>") 的十六进制
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff 第 attempts 次失败后的等待时间：30 秒起每次翻倍，最多 6 小时
+func webhookBackoff(attempts int) time.Duration {
+	wait := webhookRetryBase
+	for i := 1; i < attempts && wait < webhookRetryMax; i++ {
+		wait *= 2
+	}
+	return min(wait, webhookRetryMax)
+}
+
+// webhookMatches 事件是否属于订阅的范围和事件类型
+func webhookMatches(subscription model.WebhookSubscription, ev *events.Event) bool {
+	if len(subscription.Types) > 0 && !contains(subscription.Types, ev.Type) {
+		return false
+	}
+	switch subscription.Scope {
+	case model.WebhookScopeBrand:
+		return ev.Brand == subscription.Address
+	case model.WebhookScopeOwner:
+		return contains(ev.Owners, subscription.Address) || contains(ev.Participants, subscription.Address)
+	}
+	return false
+}
+
+// eventAddresses 事件涉及的品牌、持有人和订单参与方
+func eventAddresses(ev *events.Event) []model.Address {
+	var addresses []model.Address
+	if ev.Brand != "" {
+		addresses = append(addresses, ev.Brand)
+	}
+	addresses = append(addresses, ev.Owners...)
+	return append(addresses, ev.Participants...)
+}
+
+// validateWebhook 校验回调地址和事件类型，回调地址不能指向内网
+func validateWebhook(rawURL string, types []string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials", ErrInvalidWebhook)
+	}
+	if err := checkWebhookHost(parsed.Hostname()); err != nil {
+		return err
+	}
+	for _, eventType := range types {
+		if !contains(webhookEventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+	return nil
+}
+
+func contains[T comparable](items []T, v T) bool {
+	for _, item := range items {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
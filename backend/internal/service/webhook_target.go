@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// webhookResolveTimeout 登记时解析回调域名的超时时间
+const webhookResolveTimeout = 5 * time.Second
+
+// blockedPrefixes 不允许作为回调目标的地址段，避免通过 Webhook 访问内网服务（SSRF）
+// 回环、私有、链路本地（含云厂商元数据地址 169.254.169.254）等由 isBlockedIP 中的方法判断
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"),  // 运营商级 NAT，部分云的元数据服务（如 100.100.100.200）
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF 协议分配
+	netip.MustParsePrefix("198.18.0.0/15"),  // 基准测试
+	netip.MustParsePrefix("240.0.0.0/4"),    // 保留及广播
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64，可能内嵌内网 IPv4 地址
+	netip.MustParsePrefix("64:ff9b:1::/48"), // 本地 NAT64
+	netip.MustParsePrefix("2001:db8::/32"),  // 文档示例
+	netip.MustParsePrefix("fec0::/10"),      // 已废弃的站点本地地址
+}
+
+// isBlockedIP 判断地址是否为回环、私有、链路本地等非公网地址
+func isBlockedIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWebhookHost 登记时检查回调主机：IP 直接检查，域名解析后检查全部地址
+// 投递时连接前会再次检查（见 webhookTransport），防止域名在登记后改为解析到内网地址
+func checkWebhookHost(host string) error {
+	if getWebhookOptions().AllowPrivateTargets {
+		return nil
+	}
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		if isBlockedIP(ip) {
+			return fmt.Errorf("%w: url must not point to a loopback, private or link-local address", ErrInvalidWebhook)
+		}
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: url must not point to localhost", ErrInvalidWebhook)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve host %s", ErrInvalidWebhook, host)
+	}
+	for _, ip := range ips {
+		if isBlockedIP(ip) {
+			return fmt.Errorf("%w: host %s resolves to a loopback, private or link-local address", ErrInvalidWebhook, host)
+		}
+	}
+	return nil
+}
+
+// 投递使用的连接池，按是否允许内网地址区分
+var (
+	publicWebhookTransport  = newWebhookTransport(false)
+	privateWebhookTransport = newWebhookTransport(true)
+)
+
+// webhookTransport 返回投递使用的连接池
+func webhookTransport(allowPrivate bool) *http.Transport {
+	if allowPrivate {
+		return privateWebhookTransport
+	}
+	return publicWebhookTransport
+}
+
+// newWebhookTransport 每次连接前检查实际连接的地址，
+// 域名在登记后改为解析到内网地址（DNS rebinding）时拒绝连接；不使用环境变量中的代理，避免绕过检查
+func newWebhookTransport(allowPrivate bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if allowPrivate {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if isBlockedIP(ip) {
+				return fmt.Errorf("webhook target address %s is not a public address", ip)
+			}
+			return nil
+		},
+	}
+	return &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
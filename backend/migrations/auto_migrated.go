@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// addedColumn 001 中有、但早期版本 AutoMigrate 建的表中没有的列
+type addedColumn struct {
+	table  string
+	column string
+	types  map[string]string // 方言 -> 列类型
+}
+
+var addedColumns = []addedColumn{
+	{"assets", "verifier", map[string]string{"mysql": "varchar(191)", "sqlite": "varchar(191)"}},
+	{"asset_owner_histories", "from_address", map[string]string{"mysql": "varchar(191)", "sqlite": "varchar(191)"}},
+	{"asset_owner_histories", "log_index", map[string]string{"mysql": "bigint unsigned", "sqlite": "integer"}},
+}
+
+// addedIndex 001 中有、但早期版本的表中没有的索引
+type addedIndex struct {
+	table   string
+	name    string
+	unique  bool
+	columns string
+}
+
+var addedIndexes = []addedIndex{
+	{"asset_owner_histories", "idx_asset_owner_histories_from_address", false, "`from_address`"},
+	{"user_reviews", "unique_review", true, "`order_id`,`reviewer_address`,`role`"},
+}
+
+// upgradeAutoMigrated 001：补齐早期 AutoMigrate 建的表中缺少的列和索引
+// 001 的 SQL 使用 CREATE TABLE IF NOT EXISTS，已存在的表会被整体跳过，
+// 这里逐个检查后用 ALTER TABLE / CREATE INDEX 补上；新建的数据库中都已存在，不做任何修改
+func upgradeAutoMigrated(tx *gorm.DB) error {
+	dialect := tx.Dialector.Name()
+	migrator := tx.Migrator()
+
+	for _, col := range addedColumns {
+		if migrator.HasColumn(col.table, col.column) {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s", col.table, col.column, col.types[dialect])
+		if err := tx.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to add %s.%s: %w", col.table, col.column, err)
+		}
+	}
+
+	for _, idx := range addedIndexes {
+		if migrator.HasIndex(idx.table, idx.name) {
+			continue
+		}
+		kind := "INDEX"
+		if idx.unique {
+			kind = "UNIQUE INDEX"
+		}
+		stmt := fmt.Sprintf("CREATE %s `%s` ON `%s`(%s)", kind, idx.name, idx.table, idx.columns)
+		if err := tx.Exec(stmt).Error; err != nil {
+			if idx.unique {
+				return fmt.Errorf("failed to create unique index %s on %s, remove duplicate rows first: %w", idx.name, idx.table, err)
+			}
+			return fmt.Errorf("failed to create index %s on %s: %w", idx.name, idx.table, err)
+		}
+	}
+	return nil
+}
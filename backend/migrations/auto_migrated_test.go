@@ -0,0 +1,190 @@
+package migrations
+
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"chain-vault-backend/internal/migrate"
+	"chain-vault-backend/internal/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 最初版本的模型，由 AutoMigrate 建表；user_reviews 没有唯一索引
+type baselineAsset struct {
+	ID           uint64 `gorm:"primaryKey"`
+	Owner        string `gorm:"type:varchar(191);index;not null"`
+	Brand        string `gorm:"type:varchar(191);index"`
+	Name         string `gorm:"type:varchar(500);not null"`
+	SerialNumber string `gorm:"type:varchar(191);uniqueIndex;not null"`
+	MetadataURI  string `gorm:"type:text"`
+	Images       string `gorm:"type:text"`
+	Status       int    `gorm:"default:0"`
+	IsListed     bool   `gorm:"default:false"`
+	Price        string `gorm:"type:varchar(191);default:0"`
+	CreatedAt    time.Time
+	TxHash       string `gorm:"type:varchar(191);index;not null"`
+	BlockNum     uint64 `gorm:"index;not null"`
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineAsset) TableName() string { return "assets" }
+
+type baselineOwnerHistory struct {
+	ID        uint64    `gorm:"primaryKey"`
+	AssetID   uint64    `gorm:"index;not null"`
+	Owner     string    `gorm:"type:varchar(191);index;not null"`
+	Timestamp time.Time `gorm:"not null"`
+	TxHash    string    `gorm:"type:varchar(191);index;not null"`
+	BlockNum  uint64    `gorm:"index;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineOwnerHistory) TableName() string { return "asset_owner_histories" }
+
+type baselineReview struct {
+	ID              uint64 `gorm:"primaryKey"`
+	OrderID         uint64 `gorm:"index;not null"`
+	ReviewerAddress string `gorm:"type:varchar(191);index;not null"`
+	RevieweeAddress string `gorm:"type:varchar(191);index;not null"`
+	Role            string `gorm:"type:varchar(16);not null"`
+	Rating          int    `gorm:"not null"`
+	Comment         string `gorm:"type:text"`
+	Tags            string `gorm:"type:json"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineReview) TableName() string { return "user_reviews" }
+
+// migrationFS 返回要执行的迁移文件；驱动没有编译 FTS5 时（未使用 -tags sqlite_fts5）只执行 005 之前的迁移
+func migrationFS(t *testing.T, db *gorm.DB) fs.FS {
+	t.Helper()
+	var enabled int
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled).Error; err != nil {
+		t.Fatal(err)
+	}
+	if enabled == 1 {
+		return FS
+	}
+	t.Log("SQLite built without FTS5, applying migrations before 005 only")
+
+	entries, err := fs.ReadDir(FS, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := fstest.MapFS{}
+	for _, entry := range entries {
+		version, _ := strconv.Atoi(strings.SplitN(entry.Name(), "_", 2)[0])
+		if version >= 5 {
+			continue
+		}
+		data, err := fs.ReadFile(FS, "sqlite/"+entry.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		files["sqlite/"+entry.Name()] = &fstest.MapFile{Data: data}
+	}
+	return files
+}
+
+// migrateUp 执行内嵌的迁移，只登记 files 中存在的版本的 Go 步骤
+func migrateUp(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	files := migrationFS(t, db)
+	all, err := migrate.Load(files, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	funcs := map[uint64]migrate.Func{}
+	for _, m := range all {
+		if fn, ok := Funcs[m.Version]; ok {
+			funcs[m.Version] = fn
+		}
+	}
+	migrator, err := migrate.New(db, files, funcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpgradeAutoMigrated(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline bool
+	}{
+		{"fresh database", false},
+		{"auto-migrated baseline", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sqlDB.SetMaxOpenConns(1)
+
+			if tt.baseline {
+				if err := db.AutoMigrate(&baselineAsset{}, &baselineOwnerHistory{}, &baselineReview{}); err != nil {
+					t.Fatal(err)
+				}
+				if err := db.Create(&baselineOwnerHistory{AssetID: 1, Owner: checksumAddress, Timestamp: time.Now(), TxHash: "0x01", BlockNum: 1}).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+			migrateUp(t, db)
+
+			for _, col := range addedColumns {
+				if !db.Migrator().HasColumn(col.table, col.column) {
+					t.Errorf("column %s.%s is missing", col.table, col.column)
+				}
+			}
+			for _, idx := range addedIndexes {
+				if !db.Migrator().HasIndex(idx.table, idx.name) {
+					t.Errorf("index %s on %s is missing", idx.name, idx.table)
+				}
+			}
+
+			// 当前模型可以写入
+			history := &model.AssetOwnerHistory{AssetID: 2, FromAddress: checksumAddress, Owner: placeholderAddress,
+				Timestamp: time.Now(), TxHash: "0x02", BlockNum: 2, LogIndex: 3}
+			if err := db.Create(history).Error; err != nil {
+				t.Fatalf("failed to write ownership history: %v", err)
+			}
+			var count int64
+			db.Model(&model.AssetOwnerHistory{}).Count(&count)
+			want := int64(1)
+			if tt.baseline {
+				want = 2
+			}
+			if count != want {
+				t.Errorf("ownership histories = %d, want %d", count, want)
+			}
+
+			review := model.UserReview{OrderID: 1, ReviewerAddress: checksumAddress, RevieweeAddress: placeholderAddress, Role: "seller", Rating: 5}
+			if err := db.Create(&review).Error; err != nil {
+				t.Fatal(err)
+			}
+			review.ID = 0
+			if err := db.Create(&review).Error; err == nil {
+				t.Error("duplicate review was accepted")
+			}
+		})
+	}
+}
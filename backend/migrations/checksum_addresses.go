@@ -0,0 +1,182 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"chain-vault-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// addressColumn 保存以太坊地址的列
+type addressColumn struct {
+	table  string
+	column string
+	// merge 有唯一索引的列：规范化后与已有的校验和地址重复时，把非规范行合并到规范行
+	merge func(tx *gorm.DB, from, into string) error
+}
+
+var addressColumns = []addressColumn{
+	{"assets", "owner", nil},
+	{"assets", "brand", nil},
+	{"assets", "verifier", nil},
+	{"brands", "brand_address", mergeBrand},
+	{"orders", "seller", nil},
+	{"orders", "buyer", nil},
+	{"asset_owner_histories", "from_address", nil},
+	{"asset_owner_histories", "owner", nil},
+	{"user_reputations", "user_address", mergeReputation},
+	{"user_reviews", "reviewer_address", nil},
+	{"user_reviews", "reviewee_address", nil},
+	{"reputation_events", "seller", nil},
+	{"reputation_events", "buyer", nil},
+	{"reputation_events", "actor", nil},
+	{"chain_events", "address", nil},
+	{"auth_sessions", "address", nil},
+	{"pending_payloads", "submitter", nil},
+}
+
+// checksumAddresses 007：把地址统一为 EIP-55 校验和格式
+// 校验和需要 keccak256，无法用 SQL 计算。旧版本的链下写入（如评价）使用请求中的原始地址，
+// 链上数据使用校验和地址，唯一列中同一地址可能同时存在两种形式：
+// 此时把非规范行的数据合并到校验和形式的行（见 mergeReputation、mergeBrand）后再删除。
+// 不是有效地址的值保持不变
+func checksumAddresses(tx *gorm.DB) error {
+	for _, col := range addressColumns {
+		var values []string
+		if err := tx.Table(col.table).Distinct(col.column).Where(col.column+" <> ''").Pluck(col.column, &values).Error; err != nil {
+			return fmt.Errorf("failed to read %s.%s: %w", col.table, col.column, err)
+		}
+
+		existing := make(map[string]bool, len(values))
+		for _, value := range values {
+			existing[value] = true
+		}
+		for _, value := range values {
+			canonical, err := model.ParseAddress(value)
+			if err != nil || string(canonical) == value {
+				continue
+			}
+			if col.merge != nil && existing[string(canonical)] {
+				if err = col.merge(tx, value, string(canonical)); err == nil {
+					err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", col.table, col.column), value).Error
+				}
+			} else {
+				err = tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", col.table, col.column, col.column), string(canonical), value).Error
+				existing[string(canonical)] = true
+			}
+			if err != nil {
+				return fmt.Errorf("failed to normalize %s.%s %q: %w", col.table, col.column, value, err)
+			}
+		}
+	}
+	return nil
+}
+
+// reputationRow 合并信誉记录时读取的列
+type reputationRow struct {
+	ExperiencePoints  int
+	TotalOrders       int
+	CompletedOrders   int
+	CancelledOrders   int
+	RefundedOrders    int
+	SellerOrders      int
+	SellerCompleted   int
+	SellerRating      float64
+	SellerRatingCount int
+	BuyerOrders       int
+	BuyerCompleted    int
+	BuyerRating       float64
+	BuyerRatingCount  int
+	Badges            sql.NullString
+	Achievements      sql.NullString
+}
+
+// mergeReputation 把 from 的信誉记录合并到 into：经验值和计数相加，评分按评价数加权平均，
+// 等级按合并后的经验值重新计算；徽章和成就在 into 为空时取 from 的
+func mergeReputation(tx *gorm.DB, from, into string) error {
+	var src, dst reputationRow
+	if err := tx.Table("user_reputations").Where("user_address = ?", from).Take(&src).Error; err != nil {
+		return err
+	}
+	if err := tx.Table("user_reputations").Where("user_address = ?", into).Take(&dst).Error; err != nil {
+		return err
+	}
+
+	experience := dst.ExperiencePoints + src.ExperiencePoints
+	level, stars := model.CalculateLevel(experience)
+	return tx.Table("user_reputations").Where("user_address = ?", into).Updates(map[string]any{
+		"experience_points":   experience,
+		"level":               level,
+		"stars":               stars,
+		"total_orders":        dst.TotalOrders + src.TotalOrders,
+		"completed_orders":    dst.CompletedOrders + src.CompletedOrders,
+		"cancelled_orders":    dst.CancelledOrders + src.CancelledOrders,
+		"refunded_orders":     dst.RefundedOrders + src.RefundedOrders,
+		"seller_orders":       dst.SellerOrders + src.SellerOrders,
+		"seller_completed":    dst.SellerCompleted + src.SellerCompleted,
+		"seller_rating":       mergeRating(dst.SellerRating, dst.SellerRatingCount, src.SellerRating, src.SellerRatingCount),
+		"seller_rating_count": dst.SellerRatingCount + src.SellerRatingCount,
+		"buyer_orders":        dst.BuyerOrders + src.BuyerOrders,
+		"buyer_completed":     dst.BuyerCompleted + src.BuyerCompleted,
+		"buyer_rating":        mergeRating(dst.BuyerRating, dst.BuyerRatingCount, src.BuyerRating, src.BuyerRatingCount),
+		"buyer_rating_count":  dst.BuyerRatingCount + src.BuyerRatingCount,
+		"badges":              mergeJSONList(dst.Badges, src.Badges),
+		"achievements":        mergeJSONList(dst.Achievements, src.Achievements),
+	}).Error
+}
+
+// mergeRating 两组平均评分按评价数加权合并，都没有评价时保留 a
+func mergeRating(a float64, countA int, b float64, countB int) float64 {
+	if countA+countB <= 0 {
+		return a
+	}
+	return (a*float64(countA) + b*float64(countB)) / float64(countA+countB)
+}
+
+// mergeJSONList into 为空数组时取 from
+func mergeJSONList(into, from sql.NullString) sql.NullString {
+	if into.Valid && into.String != "" && into.String != "[]" {
+		return into
+	}
+	if from.Valid && from.String != "" {
+		return from
+	}
+	return into
+}
+
+// brandRow 合并品牌记录时读取的列
+type brandRow struct {
+	BrandName    string
+	IsAuthorized bool
+	RegisteredAt time.Time
+	TxHash       sql.NullString
+	BlockNum     uint64
+}
+
+// mergeBrand 品牌记录都来自链上事件，保留区块号较大（即较新）的一行的内容，注册时间取较早的
+func mergeBrand(tx *gorm.DB, from, into string) error {
+	var src, dst brandRow
+	if err := tx.Table("brands").Where("brand_address = ?", from).Take(&src).Error; err != nil {
+		return err
+	}
+	if err := tx.Table("brands").Where("brand_address = ?", into).Take(&dst).Error; err != nil {
+		return err
+	}
+	if src.BlockNum <= dst.BlockNum {
+		return nil
+	}
+
+	registeredAt := dst.RegisteredAt
+	if src.RegisteredAt.Before(registeredAt) {
+		registeredAt = src.RegisteredAt
+	}
+	return tx.Table("brands").Where("brand_address = ?", into).Updates(map[string]any{
+		"brand_name":    src.BrandName,
+		"is_authorized": src.IsAuthorized,
+		"registered_at": registeredAt,
+		"tx_hash":       src.TxHash,
+		"block_num":     src.BlockNum,
+	}).Error
+}
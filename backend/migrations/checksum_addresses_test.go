@@ -0,0 +1,206 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"chain-vault-backend/internal/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	lowerAddress    = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+	checksumAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	// placeholderAddress 先以该地址写入，再改为小写地址：model.Address 写入时总是转为校验和格式
+	placeholderAddress = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+)
+
+// openTestDB 内存 SQLite，按 addressColumns 建表：信誉和品牌表使用模型的完整结构，其余只建地址列
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 每个连接是独立的内存数据库
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&model.UserReputation{}, &model.Brand{}); err != nil {
+		t.Fatal(err)
+	}
+	columns := map[string][]string{}
+	for _, col := range addressColumns {
+		if col.table != "user_reputations" && col.table != "brands" {
+			columns[col.table] = append(columns[col.table], col.column+" varchar(191)")
+		}
+	}
+	for table, defs := range columns {
+		if err := db.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(defs, ", "))).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// setLowercase 把 placeholderAddress 改为小写形式的 lowerAddress，模拟旧版本写入的地址
+func setLowercase(t *testing.T, db *gorm.DB, table, column string) {
+	t.Helper()
+	err := db.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", table, column, column), lowerAddress, placeholderAddress).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChecksumAddressesMergesReputation(t *testing.T) {
+	db := openTestDB(t)
+	// 链上订单写入的校验和行，以及旧版本评价写入的小写行
+	rows := []model.UserReputation{
+		{UserAddress: checksumAddress, ExperiencePoints: 40, TotalOrders: 2, CompletedOrders: 2, SellerOrders: 2, SellerCompleted: 2,
+			SellerRating: 4, SellerRatingCount: 1, BuyerRating: 5, Badges: "[]", Achievements: "[]"},
+		{UserAddress: placeholderAddress, ExperiencePoints: 10, SellerRating: 5, SellerRatingCount: 3, BuyerRating: 5,
+			Badges: `["trusted"]`, Achievements: "[]"},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	setLowercase(t, db, "user_reputations", "user_address")
+	if err := checksumAddresses(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []model.UserReputation
+	if err := db.Find(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d reputation rows, want 1", len(got))
+	}
+	r := got[0]
+	level, stars := model.CalculateLevel(50)
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"user_address", r.UserAddress, model.Address(checksumAddress)},
+		{"experience_points", r.ExperiencePoints, 50},
+		{"level", r.Level, level},
+		{"stars", r.Stars, stars},
+		{"total_orders", r.TotalOrders, 2},
+		{"seller_completed", r.SellerCompleted, 2},
+		{"seller_rating_count", r.SellerRatingCount, 4},
+		{"seller_rating", r.SellerRating, 4.75},
+		{"buyer_rating", r.BuyerRating, 5.0},
+		{"badges", r.Badges, `["trusted"]`},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestChecksumAddressesMergesBrand(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(24 * time.Hour)
+	tests := []struct {
+		name      string
+		canonical model.Brand
+		lower     model.Brand
+		wantName  string
+		wantAuth  bool
+		wantBlock uint64
+		wantRegAt time.Time
+	}{
+		{
+			name:      "lowercase row is newer",
+			canonical: model.Brand{BrandName: "Old", RegisteredAt: later, BlockNum: 10},
+			lower:     model.Brand{BrandName: "New", IsAuthorized: true, RegisteredAt: earlier, BlockNum: 20},
+			wantName:  "New", wantAuth: true, wantBlock: 20, wantRegAt: earlier,
+		},
+		{
+			name:      "checksum row is newer",
+			canonical: model.Brand{BrandName: "Current", IsAuthorized: true, RegisteredAt: earlier, BlockNum: 30},
+			lower:     model.Brand{BrandName: "Stale", RegisteredAt: later, BlockNum: 5},
+			wantName:  "Current", wantAuth: true, wantBlock: 30, wantRegAt: earlier,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			tt.canonical.BrandAddress = checksumAddress
+			tt.lower.BrandAddress = placeholderAddress
+			if err := db.Create(&[]model.Brand{tt.canonical, tt.lower}).Error; err != nil {
+				t.Fatal(err)
+			}
+			setLowercase(t, db, "brands", "brand_address")
+			if err := checksumAddresses(db); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []model.Brand
+			if err := db.Find(&got).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d brand rows, want 1", len(got))
+			}
+			b := got[0]
+			if b.BrandAddress != checksumAddress || b.BrandName != tt.wantName || b.IsAuthorized != tt.wantAuth ||
+				b.BlockNum != tt.wantBlock || !b.RegisteredAt.Equal(tt.wantRegAt) {
+				t.Errorf("got %+v, want name=%s authorized=%v block=%d registeredAt=%s",
+					b, tt.wantName, tt.wantAuth, tt.wantBlock, tt.wantRegAt)
+			}
+		})
+	}
+}
+
+func TestChecksumAddressesRenamesColumns(t *testing.T) {
+	db := openTestDB(t)
+	values := []string{lowerAddress, checksumAddress, "not-an-address"}
+	for _, value := range values {
+		if err := db.Exec("INSERT INTO orders (seller, buyer) VALUES (?, ?)", value, value).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := checksumAddresses(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var sellers []string
+	if err := db.Table("orders").Order("rowid").Pluck("seller", &sellers).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []string{checksumAddress, checksumAddress, "not-an-address"}
+	for i := range want {
+		if sellers[i] != want[i] {
+			t.Errorf("seller[%d] = %q, want %q", i, sellers[i], want[i])
+		}
+	}
+}
+
+func TestMergeRating(t *testing.T) {
+	tests := []struct {
+		a      float64
+		countA int
+		b      float64
+		countB int
+		want   float64
+	}{
+		{5, 0, 5, 0, 5},
+		{4, 1, 5, 3, 4.75},
+		{5, 0, 3, 2, 3},
+		{2, 2, 5, 0, 2},
+	}
+	for _, tt := range tests {
+		if got := mergeRating(tt.a, tt.countA, tt.b, tt.countB); got != tt.want {
+			t.Errorf("mergeRating(%v, %d, %v, %d) = %v, want %v", tt.a, tt.countA, tt.b, tt.countB, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package migrations 内嵌数据库迁移文件
+//
+// mysql/ 和 sqlite/ 目录分别存放两种方言的 SQL，文件名格式为
+// <版本>_<名称>.up.sql 和 <版本>_<名称>.down.sql，两个目录中的版本需保持一致。
+// 新迁移使用 go run ./cmd/migrate create <名称> 创建。
+// SQL 无法完成的数据转换写成 Go 函数并登记到 Funcs，在同版本的 up SQL 之后执行。
+package migrations
+
+import (
+	"embed"
+
+	"chain-vault-backend/internal/migrate"
+)
+
+// FS 内嵌的迁移文件
+//
+//go:embed mysql/*.sql sqlite/*.sql
+var FS embed.FS
+
+// Funcs 按版本登记的 Go 迁移步骤
+var Funcs = map[uint64]migrate.Func{
+	1: upgradeAutoMigrated,
+	7: checksumAddresses,
+}
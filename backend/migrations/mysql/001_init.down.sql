@@ -0,0 +1,15 @@
+-- 删除全部表
+DROP TABLE IF EXISTS `reputation_events`;
+DROP TABLE IF EXISTS `level_configs`;
+DROP TABLE IF EXISTS `user_reviews`;
+DROP TABLE IF EXISTS `user_reputations`;
+DROP TABLE IF EXISTS `auth_sessions`;
+DROP TABLE IF EXISTS `auth_nonces`;
+DROP TABLE IF EXISTS `pending_payloads`;
+DROP TABLE IF EXISTS `chain_events`;
+DROP TABLE IF EXISTS `processed_blocks`;
+DROP TABLE IF EXISTS `sync_state`;
+DROP TABLE IF EXISTS `asset_owner_histories`;
+DROP TABLE IF EXISTS `orders`;
+DROP TABLE IF EXISTS `brands`;
+DROP TABLE IF EXISTS `assets`;
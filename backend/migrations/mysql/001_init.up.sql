@@ -0,0 +1,285 @@
+-- 初始表结构
+-- 对已由 AutoMigrate 创建过表的数据库，已存在的表会被跳过，
+-- 其中缺少的列和索引由 Go 步骤补齐（见 migrations/auto_migrated.go）
+
+-- 资产
+CREATE TABLE IF NOT EXISTS `assets` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `owner` varchar(191) NOT NULL,
+    `brand` varchar(191),
+    `name` varchar(500) NOT NULL,
+    `serial_number` varchar(191) NOT NULL,
+    `metadata_uri` text,
+    `images` text,
+    `status` bigint DEFAULT 0,
+    `verifier` varchar(191),
+    `is_listed` boolean DEFAULT false,
+    `price` varchar(191) DEFAULT '0',
+    `created_at` datetime(3) NOT NULL,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` bigint unsigned NOT NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_assets_owner` (`owner`),
+    INDEX `idx_assets_brand` (`brand`),
+    UNIQUE INDEX `idx_assets_serial_number` (`serial_number`),
+    INDEX `idx_assets_tx_hash` (`tx_hash`),
+    INDEX `idx_assets_block_num` (`block_num`),
+    INDEX `idx_assets_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 品牌
+CREATE TABLE IF NOT EXISTS `brands` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `brand_address` varchar(191) NOT NULL,
+    `brand_name` varchar(191) NOT NULL,
+    `is_authorized` boolean DEFAULT false,
+    `registered_at` datetime(3) NOT NULL,
+    `tx_hash` varchar(191),
+    `block_num` bigint unsigned,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_brands_brand_address` (`brand_address`),
+    INDEX `idx_brands_tx_hash` (`tx_hash`),
+    INDEX `idx_brands_block_num` (`block_num`),
+    INDEX `idx_brands_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 订单
+CREATE TABLE IF NOT EXISTS `orders` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `asset_id` bigint unsigned NOT NULL,
+    `seller` varchar(191) NOT NULL,
+    `buyer` varchar(191) NOT NULL,
+    `price` varchar(191) NOT NULL,
+    `status` bigint DEFAULT 0,
+    `order_created_at` datetime(3) NOT NULL,
+    `paid_at` datetime(3) NULL,
+    `shipped_at` datetime(3) NULL,
+    `delivered_at` datetime(3) NULL,
+    `completed_at` datetime(3) NULL,
+    `can_refund` boolean DEFAULT true,
+    `refund_deadline` datetime(3) NULL,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` bigint unsigned NOT NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_orders_asset_id` (`asset_id`),
+    INDEX `idx_orders_seller` (`seller`),
+    INDEX `idx_orders_buyer` (`buyer`),
+    INDEX `idx_orders_tx_hash` (`tx_hash`),
+    INDEX `idx_orders_block_num` (`block_num`),
+    INDEX `idx_orders_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 资产所有权历史
+CREATE TABLE IF NOT EXISTS `asset_owner_histories` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `asset_id` bigint unsigned NOT NULL,
+    `from_address` varchar(191),
+    `owner` varchar(191) NOT NULL,
+    `timestamp` datetime(3) NOT NULL,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` bigint unsigned NOT NULL,
+    `log_index` bigint unsigned,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_asset_owner_histories_asset_id` (`asset_id`),
+    INDEX `idx_asset_owner_histories_from_address` (`from_address`),
+    INDEX `idx_asset_owner_histories_owner` (`owner`),
+    INDEX `idx_asset_owner_histories_tx_hash` (`tx_hash`),
+    INDEX `idx_asset_owner_histories_block_num` (`block_num`),
+    INDEX `idx_asset_owner_histories_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 事件监听器同步进度
+CREATE TABLE IF NOT EXISTS `sync_state` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `last_block` bigint unsigned NOT NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_sync_state_contract_address` (`contract_address`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 已处理区块的哈希，用于检测链重组
+CREATE TABLE IF NOT EXISTS `processed_blocks` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `block_num` bigint unsigned NOT NULL,
+    `block_hash` varchar(66) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_processed_block` (`contract_address`,`block_num`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 已处理的链上事件，用于幂等处理和重组回滚
+CREATE TABLE IF NOT EXISTS `chain_events` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `block_num` bigint unsigned NOT NULL,
+    `block_hash` varchar(66) NOT NULL,
+    `tx_hash` varchar(66) NOT NULL,
+    `log_index` bigint unsigned NOT NULL,
+    `event_name` varchar(64) NOT NULL,
+    `asset_id` bigint unsigned,
+    `order_id` bigint unsigned,
+    `address` varchar(191),
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_chain_events_contract_address` (`contract_address`),
+    INDEX `idx_chain_events_block_num` (`block_num`),
+    UNIQUE INDEX `idx_chain_event_log` (`tx_hash`,`log_index`),
+    INDEX `idx_chain_events_event_name` (`event_name`),
+    INDEX `idx_chain_events_asset_id` (`asset_id`),
+    INDEX `idx_chain_events_order_id` (`order_id`),
+    INDEX `idx_chain_events_address` (`address`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 依附于交易哈希的延迟数据
+CREATE TABLE IF NOT EXISTS `pending_payloads` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `tx_hash` varchar(66) NOT NULL,
+    `kind` varchar(64) NOT NULL,
+    `submitter` varchar(42) NOT NULL,
+    `data` text,
+    `status` varchar(32) NOT NULL,
+    `error` text,
+    `applied_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_pending_payloads_tx_hash` (`tx_hash`),
+    INDEX `idx_pending_payloads_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 钱包登录随机数
+CREATE TABLE IF NOT EXISTS `auth_nonces` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `nonce` varchar(64) NOT NULL,
+    `expires_at` datetime(3) NULL,
+    `used_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_auth_nonces_nonce` (`nonce`),
+    INDEX `idx_auth_nonces_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 钱包登录会话
+CREATE TABLE IF NOT EXISTS `auth_sessions` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `token_hash` varchar(64) NOT NULL,
+    `address` varchar(42) NOT NULL,
+    `chain_id` bigint unsigned,
+    `expires_at` datetime(3) NULL,
+    `revoked_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_auth_sessions_token_hash` (`token_hash`),
+    INDEX `idx_auth_sessions_address` (`address`),
+    INDEX `idx_auth_sessions_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 用户信誉
+CREATE TABLE IF NOT EXISTS `user_reputations` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_address` varchar(191) NOT NULL,
+    `level` bigint DEFAULT 1,
+    `stars` bigint DEFAULT 0,
+    `experience_points` bigint DEFAULT 0,
+    `total_orders` bigint DEFAULT 0,
+    `completed_orders` bigint DEFAULT 0,
+    `cancelled_orders` bigint DEFAULT 0,
+    `refunded_orders` bigint DEFAULT 0,
+    `seller_orders` bigint DEFAULT 0,
+    `seller_completed` bigint DEFAULT 0,
+    `seller_rating` decimal(3,2) DEFAULT 5,
+    `seller_rating_count` bigint DEFAULT 0,
+    `buyer_orders` bigint DEFAULT 0,
+    `buyer_completed` bigint DEFAULT 0,
+    `buyer_rating` decimal(3,2) DEFAULT 5,
+    `buyer_rating_count` bigint DEFAULT 0,
+    `on_time_delivery_rate` decimal(5,2) DEFAULT 100,
+    `response_time_hours` decimal(10,2) DEFAULT 24,
+    `dispute_rate` decimal(5,2) DEFAULT 0,
+    `badges` json,
+    `achievements` json,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_user_reputations_user_address` (`user_address`),
+    INDEX `idx_user_reputations_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 用户评价
+CREATE TABLE IF NOT EXISTS `user_reviews` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `order_id` bigint unsigned NOT NULL,
+    `reviewer_address` varchar(191) NOT NULL,
+    `reviewee_address` varchar(191) NOT NULL,
+    `role` varchar(16) NOT NULL,
+    `rating` bigint NOT NULL,
+    `comment` text,
+    `tags` json,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_user_reviews_order_id` (`order_id`),
+    UNIQUE INDEX `unique_review` (`order_id`,`reviewer_address`,`role`),
+    INDEX `idx_user_reviews_reviewer_address` (`reviewer_address`),
+    INDEX `idx_user_reviews_reviewee_address` (`reviewee_address`),
+    INDEX `idx_user_reviews_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 等级配置
+CREATE TABLE IF NOT EXISTS `level_configs` (
+    `id` bigint AUTO_INCREMENT,
+    `level` bigint NOT NULL,
+    `min_exp` bigint NOT NULL,
+    `stars` bigint NOT NULL,
+    `title` varchar(50) NOT NULL,
+    `benefits` json,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_level_configs_level` (`level`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 已计入信誉的订单事件
+CREATE TABLE IF NOT EXISTS `reputation_events` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `order_id` bigint unsigned NOT NULL,
+    `kind` varchar(16) NOT NULL,
+    `seller` varchar(191),
+    `buyer` varchar(191),
+    `actor` varchar(191),
+    `tx_hash` varchar(66),
+    `block_num` bigint unsigned,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_reputation_order_kind` (`order_id`,`kind`),
+    INDEX `idx_reputation_events_block_num` (`block_num`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 默认等级配置（与 model.CalculateLevel 一致）
+INSERT IGNORE INTO `level_configs` (`level`, `min_exp`, `stars`, `title`, `benefits`, `created_at`, `updated_at`) VALUES
+(1, 0, 0, '新手', '{"max_listings": 5, "fee_discount": 0}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(2, 100, 1, '铜牌会员', '{"max_listings": 10, "fee_discount": 5}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(3, 300, 1, '铜牌精英', '{"max_listings": 15, "fee_discount": 8}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(4, 600, 2, '银牌会员', '{"max_listings": 25, "fee_discount": 10}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(5, 1000, 2, '银牌精英', '{"max_listings": 40, "fee_discount": 12}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(6, 1500, 3, '金牌会员', '{"max_listings": 60, "fee_discount": 15}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(7, 2200, 3, '金牌精英', '{"max_listings": 80, "fee_discount": 18}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(8, 3000, 4, '白金会员', '{"max_listings": 100, "fee_discount": 20}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(9, 4000, 4, '白金精英', '{"max_listings": 150, "fee_discount": 22}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(10, 5500, 5, '钻石会员', '{"max_listings": 999, "fee_discount": 25}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
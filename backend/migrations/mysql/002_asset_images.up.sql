@@ -0,0 +1,18 @@
+-- 资产图片，图片内容存储在 blob 存储中
+-- assets.images 中的旧 base64 图片由 API 启动时迁移到该表并清空
+CREATE TABLE `asset_images` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `asset_id` bigint unsigned NOT NULL,
+    `position` bigint NOT NULL,
+    `hash` varchar(64) NOT NULL,
+    `mime_type` varchar(64) NOT NULL,
+    `width` bigint DEFAULT 0,
+    `height` bigint DEFAULT 0,
+    `size` bigint DEFAULT 0,
+    `storage` varchar(16) NOT NULL,
+    `location` varchar(191) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_asset_image_position` (`asset_id`, `position`),
+    INDEX `idx_asset_images_hash` (`hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `image_variants`;
+DROP INDEX `idx_asset_images_phash` ON `asset_images`;
+ALTER TABLE `asset_images` DROP COLUMN `phash`;
@@ -0,0 +1,9 @@
+-- 感知哈希索引：64 位哈希拆成 8 段，每段 8 位
+-- 汉明距离不超过 7 的两个哈希至少有一段完全相同，查询相近图片时先按段取候选再计算距离
+CREATE TABLE `phash_bands` (
+    `phash` varchar(16) NOT NULL,
+    `band` smallint NOT NULL,
+    `value` smallint NOT NULL,
+    PRIMARY KEY (`phash`, `band`),
+    INDEX `idx_phash_bands_band_value` (`band`, `value`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
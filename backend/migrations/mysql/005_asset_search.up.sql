@@ -0,0 +1,26 @@
+-- 资产搜索文档：汇总资产名称、序列号、品牌名称和元数据中的分类、型号、颜色、尺码
+-- FULLTEXT 索引使用 ngram 分词，中文和序列号片段都可以匹配
+CREATE TABLE `asset_search_docs` (
+    `asset_id` bigint unsigned NOT NULL,
+    `name` varchar(500) NOT NULL DEFAULT '',
+    `serial_number` varchar(191) NOT NULL DEFAULT '',
+    `brand_name` varchar(191) NOT NULL DEFAULT '',
+    `category` varchar(191) NOT NULL DEFAULT '',
+    `model` varchar(191) NOT NULL DEFAULT '',
+    `color` varchar(191) NOT NULL DEFAULT '',
+    `size` varchar(191) NOT NULL DEFAULT '',
+    `metadata_indexed` boolean NOT NULL DEFAULT false,
+    `metadata_attempts` int NOT NULL DEFAULT 0,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`asset_id`),
+    INDEX `idx_asset_search_docs_category` (`category`),
+    FULLTEXT INDEX `ft_asset_search_docs` (`name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`) WITH PARSER ngram
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 已有资产的名称、序列号和品牌名称，元数据由 API 启动后在后台补全
+INSERT INTO `asset_search_docs` (`asset_id`, `name`, `serial_number`, `brand_name`, `updated_at`)
+SELECT `assets`.`id`, `assets`.`name`, `assets`.`serial_number`, COALESCE(`brands`.`brand_name`, ''), NOW(3)
+FROM `assets`
+LEFT JOIN `brands` ON `brands`.`brand_address` = `assets`.`brand` AND `brands`.`deleted_at` IS NULL
+WHERE `assets`.`deleted_at` IS NULL;
+
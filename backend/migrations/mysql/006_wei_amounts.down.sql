@@ -0,0 +1,6 @@
+DROP INDEX `idx_assets_price` ON `assets`;
+ALTER TABLE `assets` MODIFY `price` varchar(191) DEFAULT '0';
+UPDATE `assets` SET `price` = COALESCE(NULLIF(TRIM(LEADING '0' FROM `price`), ''), '0');
+
+ALTER TABLE `orders` MODIFY `price` varchar(191) NOT NULL;
+UPDATE `orders` SET `price` = COALESCE(NULLIF(TRIM(LEADING '0' FROM `price`), ''), '0');
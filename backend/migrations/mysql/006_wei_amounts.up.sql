@@ -0,0 +1,8 @@
+-- 金额改为补零到 78 位的十进制字符串（uint256 最多 78 位），定长后字典序即数值大小，见 model.Wei
+-- MySQL 的 DECIMAL 最多 65 位，放不下 uint256
+UPDATE `assets` SET `price` = LPAD(COALESCE(NULLIF(`price`, ''), '0'), 78, '0');
+ALTER TABLE `assets` MODIFY `price` char(78) NOT NULL DEFAULT '000000000000000000000000000000000000000000000000000000000000000000000000000000';
+CREATE INDEX `idx_assets_price` ON `assets`(`price`);
+
+UPDATE `orders` SET `price` = LPAD(COALESCE(NULLIF(`price`, ''), '0'), 78, '0');
+ALTER TABLE `orders` MODIFY `price` char(78) NOT NULL;
@@ -0,0 +1,2 @@
+-- 地址统一为 EIP-55 校验和格式，按地址查询改为等值比较
+-- 校验和需要 keccak256，无法用 SQL 计算，转换在 Go 步骤 checksumAddresses 中完成（见 migrations.Funcs）
@@ -0,0 +1,34 @@
+-- Webhook 订阅：品牌方或卖家登记的回调地址
+CREATE TABLE `webhook_subscriptions` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `address` varchar(42) NOT NULL,
+    `scope` varchar(16) NOT NULL,
+    `url` varchar(2048) NOT NULL,
+    `secret` varchar(128) NOT NULL,
+    `event_types` varchar(1024) NOT NULL DEFAULT '',
+    `active` boolean NOT NULL DEFAULT true,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_webhook_subscriptions_address` (`address`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Webhook 发件箱：监听器在写入事件的同一事务中为匹配的订阅各写一行，后台任务投递和重试
+CREATE TABLE `webhook_deliveries` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `subscription_id` bigint unsigned NOT NULL,
+    `event_id` bigint unsigned NOT NULL,
+    `event_type` varchar(64) NOT NULL,
+    `payload` mediumtext NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `attempts` bigint NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime(3) NOT NULL,
+    `last_status_code` bigint,
+    `last_error` text,
+    `delivered_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_webhook_delivery_event` (`subscription_id`, `event_id`),
+    INDEX `idx_webhook_delivery_due` (`status`, `next_attempt_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,271 @@
+-- 初始表结构
+-- 对已由 AutoMigrate 创建过表的数据库，已存在的表和索引会被跳过，
+-- 其中缺少的列和索引由 Go 步骤补齐（见 migrations/auto_migrated.go）
+
+-- 资产
+CREATE TABLE IF NOT EXISTS `assets` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `owner` varchar(191) NOT NULL,
+    `brand` varchar(191),
+    `name` varchar(500) NOT NULL,
+    `serial_number` varchar(191) NOT NULL,
+    `metadata_uri` text,
+    `images` text,
+    `status` integer DEFAULT 0,
+    `verifier` varchar(191),
+    `is_listed` numeric DEFAULT false,
+    `price` varchar(191) DEFAULT '0',
+    `created_at` datetime NOT NULL,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` integer NOT NULL,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_assets_owner` ON `assets`(`owner`);
+CREATE INDEX IF NOT EXISTS `idx_assets_brand` ON `assets`(`brand`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_assets_serial_number` ON `assets`(`serial_number`);
+CREATE INDEX IF NOT EXISTS `idx_assets_tx_hash` ON `assets`(`tx_hash`);
+CREATE INDEX IF NOT EXISTS `idx_assets_block_num` ON `assets`(`block_num`);
+CREATE INDEX IF NOT EXISTS `idx_assets_deleted_at` ON `assets`(`deleted_at`);
+
+-- 品牌
+CREATE TABLE IF NOT EXISTS `brands` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `brand_address` varchar(191) NOT NULL,
+    `brand_name` varchar(191) NOT NULL,
+    `is_authorized` numeric DEFAULT false,
+    `registered_at` datetime NOT NULL,
+    `tx_hash` varchar(191),
+    `block_num` integer,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_brands_brand_address` ON `brands`(`brand_address`);
+CREATE INDEX IF NOT EXISTS `idx_brands_tx_hash` ON `brands`(`tx_hash`);
+CREATE INDEX IF NOT EXISTS `idx_brands_block_num` ON `brands`(`block_num`);
+CREATE INDEX IF NOT EXISTS `idx_brands_deleted_at` ON `brands`(`deleted_at`);
+
+-- 订单
+CREATE TABLE IF NOT EXISTS `orders` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `asset_id` integer NOT NULL,
+    `seller` varchar(191) NOT NULL,
+    `buyer` varchar(191) NOT NULL,
+    `price` varchar(191) NOT NULL,
+    `status` integer DEFAULT 0,
+    `order_created_at` datetime NOT NULL,
+    `paid_at` datetime,
+    `shipped_at` datetime,
+    `delivered_at` datetime,
+    `completed_at` datetime,
+    `can_refund` numeric DEFAULT true,
+    `refund_deadline` datetime,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` integer NOT NULL,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_orders_asset_id` ON `orders`(`asset_id`);
+CREATE INDEX IF NOT EXISTS `idx_orders_seller` ON `orders`(`seller`);
+CREATE INDEX IF NOT EXISTS `idx_orders_buyer` ON `orders`(`buyer`);
+CREATE INDEX IF NOT EXISTS `idx_orders_tx_hash` ON `orders`(`tx_hash`);
+CREATE INDEX IF NOT EXISTS `idx_orders_block_num` ON `orders`(`block_num`);
+CREATE INDEX IF NOT EXISTS `idx_orders_deleted_at` ON `orders`(`deleted_at`);
+
+-- 资产所有权历史
+CREATE TABLE IF NOT EXISTS `asset_owner_histories` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `asset_id` integer NOT NULL,
+    `from_address` varchar(191),
+    `owner` varchar(191) NOT NULL,
+    `timestamp` datetime NOT NULL,
+    `tx_hash` varchar(191) NOT NULL,
+    `block_num` integer NOT NULL,
+    `log_index` integer,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_asset_owner_histories_asset_id` ON `asset_owner_histories`(`asset_id`);
+-- from_address 的索引由 Go 步骤创建：早期建的表中还没有该列，在这里建索引会失败
+CREATE INDEX IF NOT EXISTS `idx_asset_owner_histories_owner` ON `asset_owner_histories`(`owner`);
+CREATE INDEX IF NOT EXISTS `idx_asset_owner_histories_tx_hash` ON `asset_owner_histories`(`tx_hash`);
+CREATE INDEX IF NOT EXISTS `idx_asset_owner_histories_block_num` ON `asset_owner_histories`(`block_num`);
+CREATE INDEX IF NOT EXISTS `idx_asset_owner_histories_deleted_at` ON `asset_owner_histories`(`deleted_at`);
+
+-- 事件监听器同步进度
+CREATE TABLE IF NOT EXISTS `sync_state` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `last_block` integer NOT NULL,
+    `updated_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_sync_state_contract_address` ON `sync_state`(`contract_address`);
+
+-- 已处理区块的哈希，用于检测链重组
+CREATE TABLE IF NOT EXISTS `processed_blocks` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `block_num` integer NOT NULL,
+    `block_hash` varchar(66) NOT NULL,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_processed_block` ON `processed_blocks`(`contract_address`,`block_num`);
+
+-- 已处理的链上事件，用于幂等处理和重组回滚
+CREATE TABLE IF NOT EXISTS `chain_events` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `contract_address` varchar(191) NOT NULL,
+    `block_num` integer NOT NULL,
+    `block_hash` varchar(66) NOT NULL,
+    `tx_hash` varchar(66) NOT NULL,
+    `log_index` integer NOT NULL,
+    `event_name` varchar(64) NOT NULL,
+    `asset_id` integer,
+    `order_id` integer,
+    `address` varchar(191),
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_contract_address` ON `chain_events`(`contract_address`);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_block_num` ON `chain_events`(`block_num`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_chain_event_log` ON `chain_events`(`tx_hash`,`log_index`);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_event_name` ON `chain_events`(`event_name`);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_asset_id` ON `chain_events`(`asset_id`);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_order_id` ON `chain_events`(`order_id`);
+CREATE INDEX IF NOT EXISTS `idx_chain_events_address` ON `chain_events`(`address`);
+
+-- 依附于交易哈希的延迟数据
+CREATE TABLE IF NOT EXISTS `pending_payloads` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `tx_hash` varchar(66) NOT NULL,
+    `kind` varchar(64) NOT NULL,
+    `submitter` varchar(42) NOT NULL,
+    `data` text,
+    `status` varchar(32) NOT NULL,
+    `error` text,
+    `applied_at` datetime,
+    `created_at` datetime,
+    `updated_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_pending_payloads_tx_hash` ON `pending_payloads`(`tx_hash`);
+CREATE INDEX IF NOT EXISTS `idx_pending_payloads_status` ON `pending_payloads`(`status`);
+
+-- 钱包登录随机数
+CREATE TABLE IF NOT EXISTS `auth_nonces` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `nonce` varchar(64) NOT NULL,
+    `expires_at` datetime,
+    `used_at` datetime,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_auth_nonces_nonce` ON `auth_nonces`(`nonce`);
+CREATE INDEX IF NOT EXISTS `idx_auth_nonces_expires_at` ON `auth_nonces`(`expires_at`);
+
+-- 钱包登录会话
+CREATE TABLE IF NOT EXISTS `auth_sessions` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `token_hash` varchar(64) NOT NULL,
+    `address` varchar(42) NOT NULL,
+    `chain_id` integer,
+    `expires_at` datetime,
+    `revoked_at` datetime,
+    `created_at` datetime,
+    `updated_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_auth_sessions_token_hash` ON `auth_sessions`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_auth_sessions_address` ON `auth_sessions`(`address`);
+CREATE INDEX IF NOT EXISTS `idx_auth_sessions_expires_at` ON `auth_sessions`(`expires_at`);
+
+-- 用户信誉
+CREATE TABLE IF NOT EXISTS `user_reputations` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_address` varchar(191) NOT NULL,
+    `level` integer DEFAULT 1,
+    `stars` integer DEFAULT 0,
+    `experience_points` integer DEFAULT 0,
+    `total_orders` integer DEFAULT 0,
+    `completed_orders` integer DEFAULT 0,
+    `cancelled_orders` integer DEFAULT 0,
+    `refunded_orders` integer DEFAULT 0,
+    `seller_orders` integer DEFAULT 0,
+    `seller_completed` integer DEFAULT 0,
+    `seller_rating` decimal(3,2) DEFAULT 5,
+    `seller_rating_count` integer DEFAULT 0,
+    `buyer_orders` integer DEFAULT 0,
+    `buyer_completed` integer DEFAULT 0,
+    `buyer_rating` decimal(3,2) DEFAULT 5,
+    `buyer_rating_count` integer DEFAULT 0,
+    `on_time_delivery_rate` decimal(5,2) DEFAULT 100,
+    `response_time_hours` decimal(10,2) DEFAULT 24,
+    `dispute_rate` decimal(5,2) DEFAULT 0,
+    `badges` json,
+    `achievements` json,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_reputations_user_address` ON `user_reputations`(`user_address`);
+CREATE INDEX IF NOT EXISTS `idx_user_reputations_deleted_at` ON `user_reputations`(`deleted_at`);
+
+-- 用户评价
+CREATE TABLE IF NOT EXISTS `user_reviews` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `order_id` integer NOT NULL,
+    `reviewer_address` varchar(191) NOT NULL,
+    `reviewee_address` varchar(191) NOT NULL,
+    `role` varchar(16) NOT NULL,
+    `rating` integer NOT NULL,
+    `comment` text,
+    `tags` json,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_user_reviews_order_id` ON `user_reviews`(`order_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `unique_review` ON `user_reviews`(`order_id`,`reviewer_address`,`role`);
+CREATE INDEX IF NOT EXISTS `idx_user_reviews_reviewer_address` ON `user_reviews`(`reviewer_address`);
+CREATE INDEX IF NOT EXISTS `idx_user_reviews_reviewee_address` ON `user_reviews`(`reviewee_address`);
+CREATE INDEX IF NOT EXISTS `idx_user_reviews_deleted_at` ON `user_reviews`(`deleted_at`);
+
+-- 等级配置
+CREATE TABLE IF NOT EXISTS `level_configs` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `level` integer NOT NULL,
+    `min_exp` integer NOT NULL,
+    `stars` integer NOT NULL,
+    `title` varchar(50) NOT NULL,
+    `benefits` json,
+    `created_at` datetime,
+    `updated_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_level_configs_level` ON `level_configs`(`level`);
+
+-- 已计入信誉的订单事件
+CREATE TABLE IF NOT EXISTS `reputation_events` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `order_id` integer NOT NULL,
+    `kind` varchar(16) NOT NULL,
+    `seller` varchar(191),
+    `buyer` varchar(191),
+    `actor` varchar(191),
+    `tx_hash` varchar(66),
+    `block_num` integer,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_reputation_order_kind` ON `reputation_events`(`order_id`,`kind`);
+CREATE INDEX IF NOT EXISTS `idx_reputation_events_block_num` ON `reputation_events`(`block_num`);
+
+-- 默认等级配置（与 model.CalculateLevel 一致）
+INSERT OR IGNORE INTO `level_configs` (`level`, `min_exp`, `stars`, `title`, `benefits`, `created_at`, `updated_at`) VALUES
+(1, 0, 0, '新手', '{"max_listings": 5, "fee_discount": 0}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(2, 100, 1, '铜牌会员', '{"max_listings": 10, "fee_discount": 5}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(3, 300, 1, '铜牌精英', '{"max_listings": 15, "fee_discount": 8}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(4, 600, 2, '银牌会员', '{"max_listings": 25, "fee_discount": 10}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(5, 1000, 2, '银牌精英', '{"max_listings": 40, "fee_discount": 12}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(6, 1500, 3, '金牌会员', '{"max_listings": 60, "fee_discount": 15}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(7, 2200, 3, '金牌精英', '{"max_listings": 80, "fee_discount": 18}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(8, 3000, 4, '白金会员', '{"max_listings": 100, "fee_discount": 20}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(9, 4000, 4, '白金精英', '{"max_listings": 150, "fee_discount": 22}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+(10, 5500, 5, '钻石会员', '{"max_listings": 999, "fee_discount": 25}', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
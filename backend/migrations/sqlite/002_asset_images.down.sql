@@ -0,0 +1,2 @@
+-- 图片内容仍保留在 blob 存储中，但不会写回 assets.images
+DROP TABLE IF EXISTS `asset_images`;
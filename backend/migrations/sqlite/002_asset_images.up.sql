@@ -0,0 +1,17 @@
+-- 资产图片，图片内容存储在 blob 存储中
+-- assets.images 中的旧 base64 图片由 API 启动时迁移到该表并清空
+CREATE TABLE `asset_images` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `asset_id` integer NOT NULL,
+    `position` integer NOT NULL,
+    `hash` varchar(64) NOT NULL,
+    `mime_type` varchar(64) NOT NULL,
+    `width` integer DEFAULT 0,
+    `height` integer DEFAULT 0,
+    `size` integer DEFAULT 0,
+    `storage` varchar(16) NOT NULL,
+    `location` varchar(191) NOT NULL,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX `idx_asset_image_position` ON `asset_images`(`asset_id`, `position`);
+CREATE INDEX `idx_asset_images_hash` ON `asset_images`(`hash`);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `image_variants`;
+DROP INDEX IF EXISTS `idx_asset_images_phash`;
+ALTER TABLE `asset_images` DROP COLUMN `phash`;
@@ -0,0 +1,19 @@
+-- 图片感知哈希，用于发现重复使用的商品照片
+ALTER TABLE `asset_images` ADD COLUMN `phash` varchar(16) NOT NULL DEFAULT '';
+CREATE INDEX `idx_asset_images_phash` ON `asset_images`(`phash`);
+
+-- 图片的缩放版本，按原图内容哈希共享
+CREATE TABLE `image_variants` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `source_hash` varchar(64) NOT NULL,
+    `variant` varchar(16) NOT NULL,
+    `hash` varchar(64) NOT NULL,
+    `mime_type` varchar(64) NOT NULL,
+    `width` integer DEFAULT 0,
+    `height` integer DEFAULT 0,
+    `size` integer DEFAULT 0,
+    `storage` varchar(16) NOT NULL,
+    `location` varchar(191) NOT NULL,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX `idx_image_variant` ON `image_variants`(`source_hash`, `variant`);
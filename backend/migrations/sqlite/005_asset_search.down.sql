@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS `asset_search_docs_au`;
+DROP TRIGGER IF EXISTS `asset_search_docs_ad`;
+DROP TRIGGER IF EXISTS `asset_search_docs_ai`;
+DROP TABLE IF EXISTS `asset_search_fts`;
+DROP TABLE IF EXISTS `asset_search_docs`;
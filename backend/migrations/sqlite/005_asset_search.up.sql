@@ -0,0 +1,47 @@
+-- 资产搜索文档：汇总资产名称、序列号、品牌名称和元数据中的分类、型号、颜色、尺码
+CREATE TABLE `asset_search_docs` (
+    `asset_id` integer PRIMARY KEY,
+    `name` varchar(500) NOT NULL DEFAULT '',
+    `serial_number` varchar(191) NOT NULL DEFAULT '',
+    `brand_name` varchar(191) NOT NULL DEFAULT '',
+    `category` varchar(191) NOT NULL DEFAULT '',
+    `model` varchar(191) NOT NULL DEFAULT '',
+    `color` varchar(191) NOT NULL DEFAULT '',
+    `size` varchar(191) NOT NULL DEFAULT '',
+    `metadata_indexed` numeric NOT NULL DEFAULT false,
+    `metadata_attempts` integer NOT NULL DEFAULT 0,
+    `updated_at` datetime
+);
+CREATE INDEX `idx_asset_search_docs_category` ON `asset_search_docs`(`category`);
+
+-- FTS5 全文索引（trigram 分词，中文和序列号片段都可以匹配），内容来自 asset_search_docs，由触发器同步
+-- 需要以 -tags sqlite_fts5 编译（Makefile 已设置）
+CREATE VIRTUAL TABLE `asset_search_fts` USING fts5(
+    `name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`,
+    content='asset_search_docs', content_rowid='asset_id', tokenize='trigram'
+);
+
+CREATE TRIGGER `asset_search_docs_ai` AFTER INSERT ON `asset_search_docs` BEGIN
+    INSERT INTO `asset_search_fts`(rowid, `name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`)
+    VALUES (new.`asset_id`, new.`name`, new.`serial_number`, new.`brand_name`, new.`category`, new.`model`, new.`color`, new.`size`);
+END;
+
+CREATE TRIGGER `asset_search_docs_ad` AFTER DELETE ON `asset_search_docs` BEGIN
+    INSERT INTO `asset_search_fts`(`asset_search_fts`, rowid, `name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`)
+    VALUES ('delete', old.`asset_id`, old.`name`, old.`serial_number`, old.`brand_name`, old.`category`, old.`model`, old.`color`, old.`size`);
+END;
+
+CREATE TRIGGER `asset_search_docs_au` AFTER UPDATE ON `asset_search_docs` BEGIN
+    INSERT INTO `asset_search_fts`(`asset_search_fts`, rowid, `name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`)
+    VALUES ('delete', old.`asset_id`, old.`name`, old.`serial_number`, old.`brand_name`, old.`category`, old.`model`, old.`color`, old.`size`);
+    INSERT INTO `asset_search_fts`(rowid, `name`, `serial_number`, `brand_name`, `category`, `model`, `color`, `size`)
+    VALUES (new.`asset_id`, new.`name`, new.`serial_number`, new.`brand_name`, new.`category`, new.`model`, new.`color`, new.`size`);
+END;
+
+-- 已有资产的名称、序列号和品牌名称，元数据由 API 启动后在后台补全
+INSERT INTO `asset_search_docs` (`asset_id`, `name`, `serial_number`, `brand_name`, `updated_at`)
+SELECT `assets`.`id`, `assets`.`name`, `assets`.`serial_number`, COALESCE(`brands`.`brand_name`, ''), CURRENT_TIMESTAMP
+FROM `assets`
+LEFT JOIN `brands` ON `brands`.`brand_address` = `assets`.`brand` AND `brands`.`deleted_at` IS NULL
+WHERE `assets`.`deleted_at` IS NULL;
+
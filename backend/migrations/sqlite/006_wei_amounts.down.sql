@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS `idx_assets_price`;
+UPDATE `assets` SET `price` = COALESCE(NULLIF(ltrim(`price`, '0'), ''), '0');
+UPDATE `orders` SET `price` = COALESCE(NULLIF(ltrim(`price`, '0'), ''), '0');
@@ -0,0 +1,6 @@
+-- 金额改为补零到 78 位的十进制字符串（uint256 最多 78 位），定长后字典序即数值大小，见 model.Wei
+-- SQLite 不能修改列类型，只转换已有数据；列的默认值 '0' 不再使用，写入时总是带上补零后的金额
+UPDATE `assets` SET `price` = substr('000000000000000000000000000000000000000000000000000000000000000000000000000000', 1, 78 - length(COALESCE(NULLIF(`price`, ''), '0'))) || COALESCE(NULLIF(`price`, ''), '0');
+CREATE INDEX `idx_assets_price` ON `assets`(`price`);
+
+UPDATE `orders` SET `price` = substr('000000000000000000000000000000000000000000000000000000000000000000000000000000', 1, 78 - length(COALESCE(NULLIF(`price`, ''), '0'))) || COALESCE(NULLIF(`price`, ''), '0');
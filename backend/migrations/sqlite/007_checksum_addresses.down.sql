@@ -0,0 +1 @@
+-- 校验和格式的地址对旧版本同样有效，回滚不还原原来的大小写
@@ -0,0 +1,32 @@
+-- Webhook 订阅：品牌方或卖家登记的回调地址
+CREATE TABLE `webhook_subscriptions` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `address` varchar(42) NOT NULL,
+    `scope` varchar(16) NOT NULL,
+    `url` varchar(2048) NOT NULL,
+    `secret` varchar(128) NOT NULL,
+    `event_types` varchar(1024) NOT NULL DEFAULT '',
+    `active` numeric NOT NULL DEFAULT true,
+    `created_at` datetime,
+    `updated_at` datetime
+);
+CREATE INDEX `idx_webhook_subscriptions_address` ON `webhook_subscriptions`(`address`);
+
+-- Webhook 发件箱：监听器在写入事件的同一事务中为匹配的订阅各写一行，后台任务投递和重试
+CREATE TABLE `webhook_deliveries` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `subscription_id` integer NOT NULL,
+    `event_id` integer NOT NULL,
+    `event_type` varchar(64) NOT NULL,
+    `payload` text NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `attempts` integer NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime NOT NULL,
+    `last_status_code` integer,
+    `last_error` text,
+    `delivered_at` datetime,
+    `created_at` datetime,
+    `updated_at` datetime
+);
+CREATE UNIQUE INDEX `idx_webhook_delivery_event` ON `webhook_deliveries`(`subscription_id`, `event_id`);
+CREATE INDEX `idx_webhook_delivery_due` ON `webhook_deliveries`(`status`, `next_attempt_at`);
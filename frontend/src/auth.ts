@@ -0,0 +1,82 @@
+/**
+ * 钱包签名登录（Sign-In with Ethereum, EIP-4361）
+ *
+ * 后端的写接口需要携带 Authorization: Bearer <token>
+ * token 按地址缓存在 localStorage 中，过期或切换账户时重新签名
+ */
+
+import { ethers } from 'ethers';
+
+const STORAGE_KEY = 'chainvault.auth';
+
+interface StoredSession {
+  address: string;
+  token: string;
+  expiresAt: string;
+}
+
+// 获取当前钱包账户的登录 token，没有有效 token 时请求钱包签名
+export async function getAuthToken(apiUrl: string): Promise<string> {
+  if (!window.ethereum) {
+    throw new Error('请先安装 MetaMask');
+  }
+
+  const provider = new ethers.BrowserProvider(window.ethereum);
+  const signer = await provider.getSigner();
+  const address = await signer.getAddress();
+
+  const cached = localStorage.getItem(STORAGE_KEY);
+  if (cached) {
+    const session: StoredSession = JSON.parse(cached);
+    // 提前 1 分钟视为过期
+    if (session.address.toLowerCase() === address.toLowerCase() &&
+        new Date(session.expiresAt).getTime() - 60_000 > Date.now()) {
+      return session.token;
+    }
+  }
+
+  const nonceResponse = await fetch(`${apiUrl}/auth/nonce`);
+  if (!nonceResponse.ok) {
+    throw new Error('获取登录随机数失败');
+  }
+  const { data: { nonce } } = await nonceResponse.json();
+
+  const { chainId } = await provider.getNetwork();
+  const message = [
+    `${window.location.host} wants you to sign in with your Ethereum account:`,
+    address,
+    '',
+    'Sign in to ChainVault',
+    '',
+    `URI: ${window.location.origin}`,
+    'Version: 1',
+    `Chain ID: ${chainId}`,
+    `Nonce: ${nonce}`,
+    `Issued At: ${new Date().toISOString()}`,
+  ].join('\n');
+  const signature = await signer.signMessage(message);
+
+  const verifyResponse = await fetch(`${apiUrl}/auth/verify`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ message, signature }),
+  });
+  const result = await verifyResponse.json();
+  if (!verifyResponse.ok) {
+    throw new Error(result.error || '登录失败');
+  }
+
+  const session: StoredSession = {
+    address,
+    token: result.token,
+    expiresAt: result.data.expiresAt,
+  };
+  localStorage.setItem(STORAGE_KEY, JSON.stringify(session));
+  return session.token;
+}
+
+// 返回带登录 token 的请求头
+export async function authHeaders(apiUrl: string, headers: Record<string, string> = {}): Promise<Record<string, string>> {
+  const token = await getAuthToken(apiUrl);
+  return { ...headers, Authorization: `Bearer ${token}` };
+}
@@ -1,5 +1,7 @@
 import { useState } from 'react'
 
+const API_URL = 'http://localhost:8080'
+
 interface AssetCardProps {
   asset: any
   onOpenDetail: () => void
@@ -43,16 +45,9 @@ export default function AssetCard({
   const getDisplayImage = () => {
     if (imageError) return null
     
-    // 优先使用 images 字段
-    if (asset.images) {
-      try {
-        const images = JSON.parse(asset.images)
-        if (Array.isArray(images) && images.length > 0) {
-          return images[0]
-        }
-      } catch (e) {
-        console.error('Failed to parse images:', e)
-      }
+    // 优先使用后端生成的缩略图
+    if (Array.isArray(asset.thumbnails) && asset.thumbnails.length > 0) {
+      return `${API_URL}${asset.thumbnails[0]}`
     }
     
     // 其次使用 metadata 中的 image
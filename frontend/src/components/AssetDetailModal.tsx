@@ -6,6 +6,8 @@
 import React, { useState } from 'react';
 import './AssetDetailModal.css';
 
+const API_URL = 'http://localhost:8080';
+
 interface Asset {
   id: number
   owner: string
@@ -13,7 +15,7 @@ interface Asset {
   name: string
   serialNumber: string
   metadataURI: string
-  images?: string
+  images?: string[]  // 后端图片地址，如 /assets/1/images/0?v=...
   status: number
   createdAt: string
   isListed: boolean
@@ -67,23 +69,16 @@ const AssetDetailModal: React.FC<AssetDetailModalProps> = ({
 
   if (!isOpen || !asset) return null
 
-  // 解析图片数据
-  let images: string[] = []
-  if (asset.images) {
-    try {
-      const parsed = JSON.parse(asset.images)
-      // 如果是数组，直接使用；如果是字符串，转为数组
-      images = Array.isArray(parsed) ? parsed : [parsed]
-    } catch (e) {
-      // 如果解析失败，尝试作为单个字符串
-      images = [asset.images]
-    }
-  }
+  const images: string[] = asset.images || []
 
   // 过滤空值并转换图片 URL
   const imageUrls = images
     .filter(img => img && img.trim())
     .map(img => {
+      // 后端图片地址
+      if (img.startsWith('/')) {
+        return `${API_URL}${img}`
+      }
       // 如果是 base64 数据，直接使用
       if (img.startsWith('data:image/')) {
         return img
@@ -6,6 +6,8 @@ import 'swiper/css/navigation'
 import 'swiper/css/pagination'
 import 'swiper/css/zoom'
 
+const API_URL = 'http://localhost:8080'
+
 interface AssetDetailModalV2Props {
   asset: any
   onClose: () => void
@@ -27,16 +29,9 @@ export default function AssetDetailModalV2({
     // 解析图片
     const imageList: string[] = []
     
-    // 从 images 字段获取
-    if (asset.images) {
-      try {
-        const parsed = JSON.parse(asset.images)
-        if (Array.isArray(parsed)) {
-          imageList.push(...parsed)
-        }
-      } catch (e) {
-        console.error('Failed to parse images:', e)
-      }
+    // 从 images 字段获取（后端图片地址）
+    if (Array.isArray(asset.images)) {
+      imageList.push(...asset.images.map((url: string) => `${API_URL}${url}`))
     }
     
     // 从 metadata 获取
@@ -13,6 +13,7 @@ import React, { useState } from 'react';
 import { ethers } from 'ethers';
 import ImageUpload from './ImageUpload';
 import WorldAreaSelector from './WorldAreaSelector';
+import { authHeaders } from '../auth';
 
 // API 地址
 const API_URL = 'http://localhost:8080';
@@ -312,7 +313,7 @@ const AssetRegistrationForm: React.FC<AssetRegistrationFormProps> = ({
       
       const metadataResponse = await fetch(`${API_URL}/ipfs/metadata`, {
         method: 'POST',
-        headers: { 'Content-Type': 'application/json' },
+        headers: await authHeaders(API_URL, { 'Content-Type': 'application/json' }),
         body: JSON.stringify({
           name: formData.name,
           description: formData.description,
@@ -429,8 +430,8 @@ const AssetRegistrationForm: React.FC<AssetRegistrationFormProps> = ({
             
             const updateResponse = await fetch(`${API_URL}/assets/${assetIdStr}/images`, {
               method: 'PUT',
-              headers: { 'Content-Type': 'application/json' },
-              body: JSON.stringify({ images: base64Images })
+              headers: await authHeaders(API_URL, { 'Content-Type': 'application/json' }),
+              body: JSON.stringify({ images: base64Images, txHash: receipt.hash })
             });
             
             console.log('📥 响应状态:', updateResponse.status);
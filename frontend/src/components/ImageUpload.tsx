@@ -11,6 +11,7 @@
  */
 
 import React, { useState, useRef } from 'react';
+import { authHeaders } from '../auth';
 
 // 上传的图片信息
 interface UploadedImage {
@@ -106,6 +107,7 @@ const ImageUpload: React.FC<ImageUploadProps> = ({
       // 上传到后端 IPFS 接口
       const response = await fetch(`${apiUrl}/ipfs/upload/images`, {  // 改为 /images
         method: 'POST',
+        headers: await authHeaders(apiUrl),
         body: formData
       });
       
@@ -152,12 +152,19 @@ echo -e "${CYAN}═════════════════════
 cd "$PROJECT_ROOT/backend" || exit 1
 
 echo -e "${YELLOW}🔄 正在编译后端服务...${NC}"
-go build -o main cmd/api/main.go
+go build -tags sqlite_fts5 -o main cmd/api/main.go
 if [ $? -ne 0 ]; then
     echo -e "${RED}❌ 后端编译失败！${NC}"
     exit 1
 fi
 
+echo -e "${YELLOW}🔄 正在执行数据库迁移...${NC}"
+go run -tags sqlite_fts5 ./cmd/migrate up
+if [ $? -ne 0 ]; then
+    echo -e "${RED}❌ 数据库迁移失败！${NC}"
+    exit 1
+fi
+
 echo -e "${YELLOW}🔄 正在启动后端服务...${NC}"
 ./main > /tmp/backend.log 2>&1 &
 BACKEND_PID=$!